@@ -5,6 +5,7 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -17,8 +18,11 @@ import (
 	ctrlruntimelog "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/adrg/xdg"
+	"github.com/openshift/ci-chat-bot/pkg/grpcadmin"
 	"github.com/openshift/ci-chat-bot/pkg/manager"
 	"github.com/openshift/ci-chat-bot/pkg/slack"
+	"github.com/openshift/ci-chat-bot/pkg/slack/middleware"
+	"github.com/openshift/ci-chat-bot/pkg/tracing"
 	"github.com/openshift/ci-chat-bot/pkg/utils"
 	botversion "github.com/openshift/ci-chat-bot/pkg/version"
 
@@ -81,6 +85,53 @@ type options struct {
 	leaseServerCredentialsFile string
 	leaseClient                manager.LeaseClient
 
+	envPresetURL string
+	adminChannel string
+	otlpEndpoint string
+
+	officeHoursConfigPath string
+	costBudgetConfigPath  string
+	maintenanceConfigPath string
+
+	buildClusterArchitectures []string
+
+	gangwayEndpoint  string
+	gangwayTokenPath string
+	gangwayDeckURL   string
+
+	dashboardUserHeader string
+
+	grpcAdminAddress   string
+	grpcAdminTokenPath string
+	grpcUserAddress    string
+	grpcUserTokensPath string
+
+	credentialsSecretNamespace string
+	vaultAddr                  string
+	vaultTokenPath             string
+	vaultPathPrefix            string
+
+	oidcIssuerURL        string
+	oidcClientID         string
+	oidcClientSecretPath string
+	oidcUsernameClaim    string
+
+	auditProxyListen string
+	auditProxyURL    string
+
+	messageTemplatesPath string
+
+	versionResolversPath   string
+	deprecatedVersionsPath string
+	supportMatrixPath      string
+
+	expiryGraceWindow     time.Duration
+	enablePreemption      bool
+	conformanceTestsImage string
+
+	commandRateLimit int
+	commandRateBurst int
+
 	rosaClusterLimit         int
 	rosaClusterAdminUsername string
 	rosaSubnetListPath       string
@@ -138,6 +189,40 @@ func run() error {
 	pflag.DurationVar(&opt.GracePeriod, "grace-period", 5*time.Second, "On shutdown, try to handle remaining events for the specified duration.")
 	pflag.StringVar(&opt.leaseServer, "lease-server", citools.URLForService(citools.ServiceBoskos), "Address of the server that manages leases. Used to identify accounts with more available leases.")
 	pflag.StringVar(&opt.leaseServerCredentialsFile, "lease-server-credentials-file", "", "The path to credentials file used to access the lease server. The content is of the form <username>:<password>.")
+	pflag.StringVar(&opt.envPresetURL, "env-preset-url", "", "Base URL that `env-preset=<name>` parameters for workflow commands are resolved against; <name>.yaml is appended. Leave empty to disable env presets.")
+	pflag.IntVar(&opt.commandRateLimit, "command-rate-limit", middleware.DefaultRateLimit, "Average number of commands per minute a single user may run before being throttled.")
+	pflag.IntVar(&opt.commandRateBurst, "command-rate-burst", middleware.DefaultRateBurst, "Number of back-to-back commands a single user may run before the rate limit applies.")
+	pflag.StringVar(&opt.adminChannel, "admin-channel", "", "Slack channel ID that operational reports, such as reclaimed leaked cloud resources, are posted to. Leave empty to disable reports.")
+	pflag.StringVar(&opt.otlpEndpoint, "otlp-endpoint", "", "OTLP gRPC endpoint, such as otel-collector:4317, that OpenTelemetry trace spans are exported to. Leave empty to disable tracing.")
+	pflag.StringVar(&opt.officeHoursConfigPath, "office-hours-config", "", "Path to config file describing when non-exempt clusters are automatically destroyed. Leave empty to disable automatic teardown.")
+	pflag.StringVar(&opt.costBudgetConfigPath, "cost-budget-config", "", "Path to config file assigning monthly cost budgets to groups of users. Leave empty to disable budget enforcement.")
+	pflag.StringVar(&opt.maintenanceConfigPath, "maintenance-config", "", "Path to config file describing recurring maintenance windows during which new launches are refused. Leave empty to disable.")
+	pflag.StringSliceVar(&opt.buildClusterArchitectures, "build-cluster-architectures", nil, "cluster=arch[;arch] entries labeling which payload architectures each build cluster can run. Clusters without an entry are assumed to be amd64 only. Leave empty to disable architecture routing.")
+	pflag.StringVar(&opt.gangwayEndpoint, "gangway-endpoint", "", "Base URL of prow's gangway API. When set, jobs are submitted through gangway instead of creating ProwJob resources directly, so the bot can run outside the prow cluster. Leave empty to create ProwJobs directly.")
+	pflag.StringVar(&opt.gangwayTokenPath, "gangway-token-file", "", "Path to the bearer token used to authenticate against the gangway API.")
+	pflag.StringVar(&opt.gangwayDeckURL, "gangway-deck-url", "", "Base URL of the deck instance used to build job links for gangway submissions. Leave empty to link to the gangway execution instead.")
+	pflag.StringVar(&opt.grpcAdminAddress, "grpc-admin-address", "", "host:port for the gRPC admin API used by the companion CLI. Leave empty to disable it.")
+	pflag.StringVar(&opt.grpcAdminTokenPath, "grpc-admin-token-file", "", "Path to the shared bearer token the gRPC admin API requires. Required when --grpc-admin-address is set.")
+	pflag.StringVar(&opt.grpcUserAddress, "grpc-user-address", "", "host:port for the gRPC user API that the cluster-bot-cli launch/status/done commands use. Leave empty to disable it.")
+	pflag.StringVar(&opt.grpcUserTokensPath, "grpc-user-tokens-file", "", "Path to a YAML map of API token to {user, userName, channel} Slack identity. Required when --grpc-user-address is set.")
+	pflag.StringVar(&opt.credentialsSecretNamespace, "credentials-secret-namespace", "", "Namespace on the build cluster that `auth to secret:<name>` writes kubeconfig secrets into. Leave empty to disable secret delivery.")
+	pflag.StringVar(&opt.vaultAddr, "vault-addr", "", "Base URL of the Vault server that `auth to vault:<path>` writes to. Leave empty to disable vault delivery.")
+	pflag.StringVar(&opt.vaultTokenPath, "vault-token-file", "", "Path to the Vault token used for credential delivery. Required when --vault-addr is set.")
+	pflag.StringVar(&opt.vaultPathPrefix, "vault-path-prefix", "", "Prefix every user-supplied vault path must start with, e.g. secret/data/cluster-bot.")
+	pflag.StringVar(&opt.oidcIssuerURL, "oidc-issuer-url", "", "OIDC issuer configured as an identity provider on launched clusters, letting `auth token` mint short-lived user tokens instead of relying on kubeadmin. Leave empty to disable.")
+	pflag.StringVar(&opt.oidcClientID, "oidc-client-id", "", "Client ID for the bot at the OIDC issuer. The issuer must allow this client to exchange tokens on behalf of users.")
+	pflag.StringVar(&opt.oidcClientSecretPath, "oidc-client-secret-file", "", "Path to the client secret for --oidc-client-id. Required when --oidc-issuer-url is set.")
+	pflag.StringVar(&opt.oidcUsernameClaim, "oidc-username-claim", "", "Claim the issuer presents the requester's Slack user name in. Defaults to preferred_username.")
+	pflag.StringVar(&opt.auditProxyListen, "audit-proxy-listen", "", "Address to serve the API audit proxy on, e.g. :8444. When set, kubeconfigs handed to users point at the proxy and every API call is logged per user. Empty disables the proxy.")
+	pflag.StringVar(&opt.auditProxyURL, "audit-proxy-url", "", "External URL users reach the audit proxy at, e.g. the TLS route in front of --audit-proxy-listen. Required with --audit-proxy-listen.")
+	pflag.StringVar(&opt.dashboardUserHeader, "dashboard-user-header", "", "Serve the operator dashboard at /dashboard, trusting this header (e.g. X-Forwarded-User from an oauth-proxy sidecar) for the viewer's identity. Empty disables the dashboard.")
+	pflag.StringVar(&opt.messageTemplatesPath, "message-templates", "", "Path to a YAML file of notification name to Go template text, overriding the stock Slack notifications (ready, failed, starting, destroyed, ...). Templates are validated at startup.")
+	pflag.StringVar(&opt.versionResolversPath, "version-resolvers-config", "", "Path to a YAML list of prefix-routed version resolvers (release-controller, ocm, quay, static), e.g. routing `okd-` versions to the OKD release controller. Versions without a configured prefix resolve from the CI imagestreams as before.")
+	pflag.StringVar(&opt.deprecatedVersionsPath, "deprecated-versions-config", "", "Path to a YAML list of deprecated or EOL versions (major.minor or version prefix, with an optional note). Launching one requires the `force` option and requests are counted for `admin deprecation-report`.")
+	pflag.StringVar(&opt.supportMatrixPath, "support-matrix-config", "", "Path to a YAML list of unsupported version/platform/architecture/variant combinations. Requests matching a rule are rejected at parse time with a precise error instead of a prow job that fails later.")
+	pflag.DurationVar(&opt.expiryGraceWindow, "expiry-grace-window", 15*time.Minute, "How long to defer destroying an expired cluster while its owner may keep its artifacts or extend it. Set to 0 to destroy expired clusters immediately.")
+	pflag.BoolVar(&opt.enablePreemption, "enable-preemption", false, "Allow a `priority=release-blocker` launch that is blocked on platform quota to tear down the nearest-to-expiry lower-priority cluster. The preempted owner is notified and the cluster's metadata is archived for relaunch.")
+	pflag.StringVar(&opt.conformanceTestsImage, "conformance-tests-image", "", "Pull spec of an image containing the openshift-tests binary, used by the `conformance` command to run suites against launched clusters. Empty disables the command.")
 	pflag.StringVar(&opt.overrideLaunchLabel, "override-launch-label", "", "Override the default launch label for jobs. Used for local debugging.")
 	pflag.StringVar(&opt.overrideRosaSecretName, "override-rosa-secret-name", "", "Override the default secret name for rosa cluster tracking. Used for local debugging.")
 	pflag.IntVar(&opt.rosaClusterLimit, "rosa-cluster-limit", 15, "Maximum number of ROSA clusters that can exist at the same time. Set to 0 for no limit.")
@@ -166,6 +251,25 @@ func run() error {
 		return fmt.Errorf("unable to validate program arguments: %w", err)
 	}
 
+	if len(opt.messageTemplatesPath) > 0 {
+		if err := slack.LoadMessageTemplates(opt.messageTemplatesPath); err != nil {
+			return fmt.Errorf("unable to load --message-templates: %w", err)
+		}
+		klog.Infof("Loaded message templates from %s", opt.messageTemplatesPath)
+	}
+
+	shutdownTracing, err := tracing.Init(context.Background(), opt.otlpEndpoint)
+	if err != nil {
+		return fmt.Errorf("unable to initialize tracing: %w", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			klog.Warningf("Failed to flush trace spans on shutdown: %v", err)
+		}
+	}()
+
 	if opt.overrideLaunchLabel != "" {
 		utils.LaunchLabel = opt.overrideLaunchLabel
 	}
@@ -173,12 +277,6 @@ func run() error {
 		manager.RosaClusterSecretName = opt.overrideRosaSecretName
 	}
 
-	if err := opt.KubernetesOptions.AddKubeconfigChangeCallback(func() {
-		klog.Infof("received kubeconfig changed event, exiting to make the kubelet restart us so we can pick them up")
-		os.Exit(0)
-	}); err != nil {
-		return fmt.Errorf("failed to set up kubeconfig watches: %w", err)
-	}
 	kubeConfigs, err := opt.KubernetesOptions.LoadClusterConfigs()
 	if err != nil {
 		return fmt.Errorf("could not load kube configs: %w", err)
@@ -187,6 +285,25 @@ func run() error {
 	if err != nil {
 		return fmt.Errorf("could not process kube configs: %w", err)
 	}
+	// rebuild the build cluster clients in place when credentials rotate, so
+	// rotation does not require a restart that drops in-flight job tracking
+	if err := opt.KubernetesOptions.AddKubeconfigChangeCallback(func() {
+		klog.Infof("received kubeconfig changed event, rebuilding build cluster clients")
+		updatedConfigs, err := opt.KubernetesOptions.LoadClusterConfigs()
+		if err != nil {
+			klog.Errorf("failed to reload kube configs, keeping the previous credentials: %v", err)
+			return
+		}
+		updatedClients, err := processKubeConfigs(updatedConfigs)
+		if err != nil {
+			klog.Errorf("failed to rebuild build cluster clients, keeping the previous credentials: %v", err)
+			return
+		}
+		buildClusterClientConfigs.Replace(updatedClients)
+		klog.Infof("rotated credentials for %d build cluster(s)", len(updatedClients))
+	}); err != nil {
+		return fmt.Errorf("failed to set up kubeconfig watches: %w", err)
+	}
 
 	resolverURL, err := url.Parse(opt.ConfigResolver)
 	if err != nil {
@@ -276,6 +393,30 @@ func run() error {
 	workflows := manager.WorkflowConfig{}
 	go manageWorkflowConfig(opt.WorkflowConfigPath, &workflows)
 
+	officeHours := manager.OfficeHoursConfig{}
+	if len(opt.officeHoursConfigPath) > 0 {
+		go manageOfficeHoursConfig(opt.officeHoursConfigPath, &officeHours)
+	}
+
+	costBudgets := manager.CostBudgetConfig{}
+	if len(opt.costBudgetConfigPath) > 0 {
+		go manageCostBudgetConfig(opt.costBudgetConfigPath, &costBudgets)
+	}
+
+	maintenance := manager.MaintenanceConfig{}
+	if len(opt.maintenanceConfigPath) > 0 {
+		go manageMaintenanceConfig(opt.maintenanceConfigPath, &maintenance)
+	}
+
+	buildClusterArchitectures := make(map[string]sets.Set[string])
+	for _, entry := range opt.buildClusterArchitectures {
+		cluster, archs, found := strings.Cut(entry, "=")
+		if !found || len(cluster) == 0 || len(archs) == 0 {
+			return fmt.Errorf("--build-cluster-architectures entries must be of the form cluster=arch[;arch], got %q", entry)
+		}
+		buildClusterArchitectures[cluster] = sets.New[string](strings.Split(archs, ";")...)
+	}
+
 	var ghClient github.Client
 
 	if token := os.Getenv("GITHUB_TOKEN"); len(token) > 0 {
@@ -343,8 +484,171 @@ func run() error {
 		hiveClient,
 		mceNamespaceClient,
 		dpcrCoreClient,
+		opt.envPresetURL,
+		&officeHours,
+		&costBudgets,
+		&maintenance,
+		buildClusterArchitectures,
 	)
 
+	if len(opt.gangwayEndpoint) > 0 {
+		var token string
+		if len(opt.gangwayTokenPath) > 0 {
+			data, err := os.ReadFile(opt.gangwayTokenPath)
+			if err != nil {
+				return fmt.Errorf("unable to read --gangway-token-file: %w", err)
+			}
+			token = strings.TrimSpace(string(data))
+		}
+		executor, err := manager.NewGangwayExecutor(opt.gangwayEndpoint, opt.gangwayDeckURL, token)
+		if err != nil {
+			return err
+		}
+		jobManager.SetExecutor(executor)
+		klog.Infof("Submitting jobs through gangway at %s", opt.gangwayEndpoint)
+	}
+
+	if len(opt.grpcAdminAddress) > 0 {
+		if len(opt.grpcAdminTokenPath) == 0 {
+			return fmt.Errorf("--grpc-admin-token-file is required with --grpc-admin-address")
+		}
+		data, err := os.ReadFile(opt.grpcAdminTokenPath)
+		if err != nil {
+			return fmt.Errorf("unable to read --grpc-admin-token-file: %w", err)
+		}
+		token := strings.TrimSpace(string(data))
+		if len(token) == 0 {
+			return fmt.Errorf("--grpc-admin-token-file must not be empty")
+		}
+		listener, err := net.Listen("tcp", opt.grpcAdminAddress)
+		if err != nil {
+			return fmt.Errorf("unable to listen on --grpc-admin-address: %w", err)
+		}
+		grpcServer := grpcadmin.NewServer(jobManager, token)
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil {
+				klog.Errorf("gRPC admin API server failed: %v", err)
+			}
+		}()
+		klog.Infof("gRPC admin API listening on %s", opt.grpcAdminAddress)
+	}
+
+	if len(opt.credentialsSecretNamespace) > 0 || len(opt.vaultAddr) > 0 {
+		var vaultToken string
+		if len(opt.vaultAddr) > 0 {
+			if len(opt.vaultTokenPath) == 0 {
+				return fmt.Errorf("--vault-token-file is required with --vault-addr")
+			}
+			data, err := os.ReadFile(opt.vaultTokenPath)
+			if err != nil {
+				return fmt.Errorf("unable to read --vault-token-file: %w", err)
+			}
+			vaultToken = strings.TrimSpace(string(data))
+		}
+		jobManager.SetCredentialDelivery(manager.CredentialDeliveryConfig{
+			SecretNamespace: opt.credentialsSecretNamespace,
+			VaultAddr:       opt.vaultAddr,
+			VaultToken:      vaultToken,
+			VaultPathPrefix: opt.vaultPathPrefix,
+		})
+		klog.Infof("Credential delivery configured (secret namespace %q, vault %q)", opt.credentialsSecretNamespace, opt.vaultAddr)
+	}
+
+	if len(opt.oidcIssuerURL) > 0 {
+		if len(opt.oidcClientID) == 0 || len(opt.oidcClientSecretPath) == 0 {
+			return fmt.Errorf("--oidc-client-id and --oidc-client-secret-file are required with --oidc-issuer-url")
+		}
+		data, err := os.ReadFile(opt.oidcClientSecretPath)
+		if err != nil {
+			return fmt.Errorf("unable to read --oidc-client-secret-file: %w", err)
+		}
+		jobManager.SetOIDCConfig(manager.OIDCConfig{
+			IssuerURL:     opt.oidcIssuerURL,
+			ClientID:      opt.oidcClientID,
+			ClientSecret:  strings.TrimSpace(string(data)),
+			UsernameClaim: opt.oidcUsernameClaim,
+		})
+		klog.Infof("OIDC issuer %s configured for launched clusters", opt.oidcIssuerURL)
+	}
+
+	if len(opt.auditProxyListen) > 0 {
+		if len(opt.auditProxyURL) == 0 {
+			return fmt.Errorf("--audit-proxy-url is required with --audit-proxy-listen")
+		}
+		auditProxy := manager.NewAuditProxy(opt.auditProxyURL)
+		jobManager.SetAuditProxy(auditProxy)
+		go func() {
+			if err := http.ListenAndServe(opt.auditProxyListen, auditProxy); err != nil {
+				klog.Exitf("audit proxy failed: %v", err)
+			}
+		}()
+		klog.Infof("Audit proxy listening on %s, reachable at %s", opt.auditProxyListen, opt.auditProxyURL)
+	}
+
+	if len(opt.versionResolversPath) > 0 {
+		routes, err := manager.LoadVersionResolvers(opt.versionResolversPath)
+		if err != nil {
+			return fmt.Errorf("unable to load --version-resolvers-config: %w", err)
+		}
+		jobManager.SetVersionResolvers(routes)
+		klog.Infof("Loaded %d version resolver(s) from %s", len(routes), opt.versionResolversPath)
+	}
+
+	if len(opt.deprecatedVersionsPath) > 0 {
+		entries, err := manager.LoadDeprecatedVersions(opt.deprecatedVersionsPath)
+		if err != nil {
+			return fmt.Errorf("unable to load --deprecated-versions-config: %w", err)
+		}
+		jobManager.SetDeprecatedVersions(entries)
+		klog.Infof("Loaded %d deprecated version(s) from %s", len(entries), opt.deprecatedVersionsPath)
+	}
+
+	if len(opt.supportMatrixPath) > 0 {
+		rules, err := manager.LoadSupportMatrix(opt.supportMatrixPath)
+		if err != nil {
+			return fmt.Errorf("unable to load --support-matrix-config: %w", err)
+		}
+		jobManager.SetSupportMatrix(rules)
+		klog.Infof("Loaded %d support matrix rule(s) from %s", len(rules), opt.supportMatrixPath)
+	}
+
+	jobManager.SetExpiryGraceWindow(opt.expiryGraceWindow)
+	jobManager.SetPreemptionEnabled(opt.enablePreemption)
+	jobManager.SetConformanceImage(opt.conformanceTestsImage)
+	if opt.enablePreemption {
+		klog.Infof("Preemption enabled: release-blocker launches may tear down lower-priority clusters when a platform is at quota")
+	}
+
+	if len(opt.grpcUserAddress) > 0 {
+		if len(opt.grpcUserTokensPath) == 0 {
+			return fmt.Errorf("--grpc-user-tokens-file is required with --grpc-user-address")
+		}
+		data, err := os.ReadFile(opt.grpcUserTokensPath)
+		if err != nil {
+			return fmt.Errorf("unable to read --grpc-user-tokens-file: %w", err)
+		}
+		tokens := make(map[string]grpcadmin.UserIdentity)
+		if err := yaml.Unmarshal(data, &tokens); err != nil {
+			return fmt.Errorf("unable to parse --grpc-user-tokens-file: %w", err)
+		}
+		for token, identity := range tokens {
+			if len(token) == 0 || len(identity.User) == 0 || len(identity.Channel) == 0 {
+				return fmt.Errorf("every entry in --grpc-user-tokens-file needs a non-empty token, user, and channel")
+			}
+		}
+		listener, err := net.Listen("tcp", opt.grpcUserAddress)
+		if err != nil {
+			return fmt.Errorf("unable to listen on --grpc-user-address: %w", err)
+		}
+		userServer := grpcadmin.NewUserServer(jobManager, tokens)
+		go func() {
+			if err := userServer.Serve(listener); err != nil {
+				klog.Errorf("gRPC user API server failed: %v", err)
+			}
+		}()
+		klog.Infof("gRPC user API listening on %s with %d token(s)", opt.grpcUserAddress, len(tokens))
+	}
+
 	klog.Infof("Waiting for caches to sync")
 	cache.WaitForCacheSync(ctx.Done(), hasSynced...)
 
@@ -352,14 +656,14 @@ func run() error {
 		return fmt.Errorf("unable to load initial configuration: %w", err)
 	}
 
-	bot := slack.NewBot(botToken, botSigningSecret, opt.GracePeriod, opt.Port, &workflows)
+	bot := slack.NewBot(botToken, botSigningSecret, opt.GracePeriod, opt.Port, &workflows, opt.adminChannel)
 	jiraclient, err := opt.jiraOptions.Client()
 	httpClient := &http.Client{Timeout: 60 * time.Second}
 	if err != nil {
 		klog.Errorf("Failed to load the Jira Client: %s", err)
-		Start(bot, nil, jobManager, nil, health, opt.InstrumentationOptions, clusterBotMetrics)
+		Start(bot, nil, jobManager, nil, health, opt.InstrumentationOptions, clusterBotMetrics, opt.commandRateLimit, opt.commandRateBurst, opt.dashboardUserHeader)
 	} else {
-		Start(bot, jiraclient.JiraClient(), jobManager, httpClient, health, opt.InstrumentationOptions, clusterBotMetrics)
+		Start(bot, jiraclient.JiraClient(), jobManager, httpClient, health, opt.InstrumentationOptions, clusterBotMetrics, opt.commandRateLimit, opt.commandRateBurst, opt.dashboardUserHeader)
 	}
 
 	return err
@@ -430,11 +734,80 @@ func manageWorkflowConfig(path string, workflows *manager.WorkflowConfig) {
 		} else {
 			workflows.Workflows = make(map[string]manager.WorkflowConfigItem)
 		}
+		if config.Presets != nil {
+			workflows.Presets = config.Presets
+		} else {
+			workflows.Presets = make(map[string]string)
+		}
+		workflows.MicroshiftWorkflow = config.MicroshiftWorkflow
+		if config.PerfscaleProfiles != nil {
+			workflows.PerfscaleProfiles = config.PerfscaleProfiles
+		} else {
+			workflows.PerfscaleProfiles = make(map[string]manager.PerfscaleProfile)
+		}
 		workflows.Mutex.Unlock()
 		time.Sleep(2 * time.Minute)
 	}
 }
 
+func manageOfficeHoursConfig(path string, officeHours *manager.OfficeHoursConfig) {
+	for {
+		// mirror manageWorkflowConfig: a broken config change must not
+		// crashloop the bot, so parse errors only disable the policy
+		var config manager.OfficeHoursConfig
+		rawConfig, err := os.ReadFile(path)
+		if err != nil {
+			klog.Errorf("Failed to load office hours config file at %s: %v", path, err)
+		} else if err := yaml.Unmarshal(rawConfig, &config); err != nil {
+			klog.Errorf("Failed to unmarshal office hours config: %v", err)
+		}
+
+		officeHours.Mutex.Lock()
+		officeHours.TeardownTime = config.TeardownTime
+		officeHours.Timezone = config.Timezone
+		officeHours.Events = config.Events
+		officeHours.Mutex.Unlock()
+		time.Sleep(2 * time.Minute)
+	}
+}
+
+func manageCostBudgetConfig(path string, budgets *manager.CostBudgetConfig) {
+	for {
+		var config manager.CostBudgetConfig
+		rawConfig, err := os.ReadFile(path)
+		if err != nil {
+			klog.Errorf("Failed to load cost budget config file at %s: %v", path, err)
+		} else if err := yaml.Unmarshal(rawConfig, &config); err != nil {
+			klog.Errorf("Failed to unmarshal cost budget config: %v", err)
+		}
+
+		budgets.Mutex.Lock()
+		budgets.HourlyRate = config.HourlyRate
+		budgets.OverrideToken = config.OverrideToken
+		budgets.Groups = config.Groups
+		budgets.Mutex.Unlock()
+		time.Sleep(2 * time.Minute)
+	}
+}
+
+func manageMaintenanceConfig(path string, maintenance *manager.MaintenanceConfig) {
+	for {
+		var config manager.MaintenanceConfig
+		rawConfig, err := os.ReadFile(path)
+		if err != nil {
+			klog.Errorf("Failed to load maintenance config file at %s: %v", path, err)
+		} else if err := yaml.Unmarshal(rawConfig, &config); err != nil {
+			klog.Errorf("Failed to unmarshal maintenance config: %v", err)
+		}
+
+		maintenance.Mutex.Lock()
+		maintenance.Timezone = config.Timezone
+		maintenance.Windows = config.Windows
+		maintenance.Mutex.Unlock()
+		time.Sleep(2 * time.Minute)
+	}
+}
+
 func loadLeaseCredentials(leaseServerCredentialsFile string) (string, func() []byte, error) {
 	if err := secret.Add(leaseServerCredentialsFile); err != nil {
 		return "", nil, fmt.Errorf("failed to start secret agent on file %s: %s", leaseServerCredentialsFile, string(secret.Censor([]byte(err.Error()))))
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	jiraClient "github.com/andygrunwald/go-jira"
+	"github.com/openshift/ci-chat-bot/pkg/dashboard"
 	"github.com/openshift/ci-chat-bot/pkg/jira"
 	"github.com/openshift/ci-chat-bot/pkg/manager"
 	"github.com/openshift/ci-chat-bot/pkg/slack"
@@ -14,6 +15,7 @@ import (
 	eventrouter "github.com/openshift/ci-chat-bot/pkg/slack/events/router"
 	interactionhandler "github.com/openshift/ci-chat-bot/pkg/slack/interactions"
 	interactionrouter "github.com/openshift/ci-chat-bot/pkg/slack/interactions/router"
+	"github.com/openshift/ci-chat-bot/pkg/slack/middleware"
 	"github.com/sirupsen/logrus"
 	slackClient "github.com/slack-go/slack"
 	"github.com/slack-go/slack/slackevents"
@@ -31,11 +33,21 @@ func l(fragment string, children ...simplifypath.Node) simplifypath.Node {
 	return simplifypath.L(fragment, children...)
 }
 
-func Start(bot *slack.Bot, jiraclient *jiraClient.Client, jobManager manager.JobManager, httpclient *http.Client, health *pjutil.Health, iOpts prowflagutil.InstrumentationOptions, clusterBotMetrics *metrics.Metrics) {
+func Start(bot *slack.Bot, jiraclient *jiraClient.Client, jobManager manager.JobManager, httpclient *http.Client, health *pjutil.Health, iOpts prowflagutil.InstrumentationOptions, clusterBotMetrics *metrics.Metrics, rateLimit, rateBurst int, dashboardUserHeader string) {
 	slackclient := slackClient.New(bot.BotToken)
 	jobManager.SetNotifier(bot.JobResponder(slackclient))
 	jobManager.SetRosaNotifier(bot.RosaResponder(slackclient))
 	jobManager.SetMceNotifier(bot.MceResponder(slackclient))
+	jobManager.SetReaperNotifier(bot.ReaperResponder(slackclient))
+	jobManager.SetAdminNotifier(bot.AdminResponder(slackclient))
+	jobManager.SetVersionWatchNotifier(bot.VersionWatchResponder(slackclient, jobManager))
+	jobManager.SetTeardownNotifier(bot.TeardownResponder(slackclient, jobManager))
+	jobManager.SetQueueNotifier(bot.QueueResponder(slackclient, jobManager))
+	jobManager.SetExpiryNotifier(bot.ExpiryResponder(slackclient))
+	jobManager.SetChaosNotifier(bot.ChaosResponder(slackclient))
+	jobManager.SetConformanceNotifier(bot.ConformanceResponder(slackclient))
+	jobManager.SetBackupNotifier(bot.BackupResponder(slackclient))
+	bot.StartDigestLoop(slackclient)
 	var issueFiler jira.IssueFiler
 	if jiraclient != nil {
 		var err error
@@ -54,6 +66,7 @@ func Start(bot *slack.Bot, jiraclient *jiraClient.Client, jobManager manager.Job
 		l("slack",
 			l("events-endpoint"),
 		),
+		l("dashboard"),
 	))
 	handler := metrics.TraceHandler(simplifier, clusterBotMetrics.HTTPRequestDuration, clusterBotMetrics.HTTPResponseSize)
 	pprof.Instrument(iOpts)
@@ -61,7 +74,18 @@ func Start(bot *slack.Bot, jiraclient *jiraClient.Client, jobManager manager.Job
 	// handle the root to allow for a simple uptime probe
 	mux.Handle("/", handler(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) { writer.WriteHeader(http.StatusOK) })))
 	mux.Handle("/readyz", handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))) // report ready once the server is up and responding
-	mux.Handle("/slack/events-endpoint", handler(handleEvent(bot.BotSigningSecret, eventrouter.ForEvents(slackclient, jobManager, bot.SupportedCommands(), issueFiler))))
+	// cross-cutting concerns are layered around command dispatch here; custom
+	// deployments can insert their own middleware into this chain
+	commands := middleware.Wrap(bot.SupportedCommands(),
+		middleware.RateLimit(rateLimit, rateBurst),
+		middleware.Tracing(),
+		middleware.Audit(),
+		middleware.Metrics(),
+	)
+	if len(dashboardUserHeader) > 0 {
+		mux.Handle("/dashboard", handler(dashboard.NewHandler(jobManager, dashboardUserHeader)))
+	}
+	mux.Handle("/slack/events-endpoint", handler(handleEvent(bot.BotSigningSecret, eventrouter.ForEvents(slackclient, jobManager, commands, issueFiler))))
 	mux.Handle("/slack/interactive-endpoint", handler(handleInteraction(bot.BotSigningSecret, interactionrouter.ForModals(slackclient, jobManager, httpclient))))
 	server := &http.Server{Addr: ":" + strconv.Itoa(bot.Port), Handler: mux, ReadHeaderTimeout: 10 * time.Second}
 	health.ServeReady(func() bool {
@@ -0,0 +1,295 @@
+// cluster-bot-cli drives the bot's gRPC APIs from the command line so power
+// users can launch and manage clusters from scripts instead of Slack. User
+// commands authenticate with a per-user API token that the bot maps to a
+// Slack identity, so CLI callers get the same quota, ownership, and budget
+// treatment as Slack users; admin commands use the shared admin token.
+//
+// Examples:
+//
+//	cluster-bot-cli --address bot:8443 launch 4.16 aws --wait --kubeconfig-out ./kc
+//	cluster-bot-cli --address bot:8443 status
+//	cluster-bot-cli --address bot:8443 done
+//	cluster-bot-cli --address bot:8444 --caller alice admin force-done chat-bot-2024-...
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openshift/ci-chat-bot/pkg/grpcadmin"
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type options struct {
+	address       string
+	tokenPath     string
+	plaintext     bool
+	caller        string
+	wait          bool
+	waitTimeout   time.Duration
+	kubeconfigOut string
+}
+
+func main() {
+	opt := &options{}
+	pflag.StringVar(&opt.address, "address", "", "host:port of the bot's gRPC API.")
+	pflag.StringVar(&opt.tokenPath, "token-file", "", "Path to the API token. Defaults to the CLUSTER_BOT_TOKEN environment variable.")
+	pflag.BoolVar(&opt.plaintext, "plaintext", false, "Connect without TLS, for use inside the cluster or over a trusted tunnel.")
+	pflag.StringVar(&opt.caller, "caller", "", "Operator name recorded in the audit log for admin commands.")
+	pflag.BoolVar(&opt.wait, "wait", false, "After launch, poll until the cluster is ready.")
+	pflag.DurationVar(&opt.waitTimeout, "wait-timeout", 90*time.Minute, "How long --wait polls before giving up.")
+	pflag.StringVar(&opt.kubeconfigOut, "kubeconfig-out", "", "Write the cluster's kubeconfig to this path once it is ready; implies --wait when used with launch.")
+	pflag.Usage = usage
+	pflag.Parse()
+
+	if err := run(opt, pflag.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `usage: cluster-bot-cli [flags] <command>
+
+user commands (authenticated by your API token):
+  launch [image_or_version_or_prs] [options]   request a cluster, e.g. launch 4.16 aws
+  status                                       show your current launch
+  kubeconfig                                   print your cluster's kubeconfig
+  done                                         tear your cluster down
+
+admin commands (authenticated by the shared admin token, --caller required):
+  admin list [timezone]
+  admin force-done <name>
+  admin extend <name> <duration>
+  admin set-max-clusters <limit>
+  admin audit-log <name>
+
+flags:
+`)
+	pflag.PrintDefaults()
+}
+
+func run(opt *options, args []string) error {
+	if len(args) == 0 {
+		usage()
+		return fmt.Errorf("a command is required")
+	}
+	if len(opt.address) == 0 {
+		return fmt.Errorf("--address is required")
+	}
+	token, err := loadToken(opt.tokenPath)
+	if err != nil {
+		return err
+	}
+
+	creds := credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	if opt.plaintext {
+		creds = insecure.NewCredentials()
+	}
+	conn, err := grpc.NewClient(opt.address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	if args[0] == "admin" {
+		if len(opt.caller) == 0 {
+			return fmt.Errorf("--caller is required for admin commands")
+		}
+		return runAdmin(grpcadmin.NewClient(conn, token, opt.caller), args[1:])
+	}
+	return runUser(opt, grpcadmin.NewUserClient(conn, token), args)
+}
+
+func loadToken(path string) (string, error) {
+	if len(path) > 0 {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("unable to read --token-file: %w", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	if token := strings.TrimSpace(os.Getenv("CLUSTER_BOT_TOKEN")); len(token) > 0 {
+		return token, nil
+	}
+	return "", fmt.Errorf("an API token is required; pass --token-file or set CLUSTER_BOT_TOKEN")
+}
+
+func runUser(opt *options, client *grpcadmin.UserClient, args []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	switch args[0] {
+	case "launch":
+		var input, launchOptions string
+		if len(args) > 1 {
+			input = args[1]
+		}
+		if len(args) > 2 {
+			launchOptions = args[2]
+		}
+		msg, err := client.Launch(ctx, input, launchOptions)
+		if err != nil {
+			return err
+		}
+		fmt.Println(msg)
+		if opt.wait || len(opt.kubeconfigOut) > 0 {
+			return waitForCluster(opt, client)
+		}
+		return nil
+	case "status":
+		status, err := client.Status(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Println(status.Message)
+		if len(status.URL) > 0 {
+			fmt.Printf("logs: %s\n", status.URL)
+		}
+		return nil
+	case "kubeconfig":
+		resp, err := client.Kubeconfig(ctx)
+		if err != nil {
+			return err
+		}
+		return writeKubeconfig(opt.kubeconfigOut, resp)
+	case "done":
+		msg, err := client.Done(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Println(msg)
+		return nil
+	default:
+		usage()
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+// waitForCluster polls until the launch is ready or has failed, then writes
+// the kubeconfig if requested.
+func waitForCluster(opt *options, client *grpcadmin.UserClient) error {
+	deadline := time.Now().Add(opt.waitTimeout)
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		status, err := client.Status(ctx)
+		cancel()
+		switch {
+		case err != nil:
+			fmt.Fprintf(os.Stderr, "warning: unable to check status: %v\n", err)
+		case status.Failed:
+			return fmt.Errorf("%s", status.Message)
+		case status.Ready:
+			fmt.Println(status.Message)
+			if len(opt.kubeconfigOut) > 0 {
+				ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				resp, err := client.Kubeconfig(ctx)
+				cancel()
+				if err != nil {
+					return err
+				}
+				return writeKubeconfig(opt.kubeconfigOut, resp)
+			}
+			return nil
+		default:
+			fmt.Println(status.Message)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("gave up waiting after %s; the launch may still complete, check with the status command", opt.waitTimeout)
+		}
+		time.Sleep(30 * time.Second)
+	}
+}
+
+func writeKubeconfig(path string, resp *grpcadmin.UserKubeconfigResponse) error {
+	if len(path) == 0 {
+		fmt.Print(resp.Kubeconfig)
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(resp.Kubeconfig), 0600); err != nil {
+		return fmt.Errorf("unable to write kubeconfig: %w", err)
+	}
+	fmt.Printf("wrote kubeconfig to %s\n", path)
+	if len(resp.PasswordSnippet) > 0 {
+		fmt.Println(resp.PasswordSnippet)
+	}
+	return nil
+}
+
+func runAdmin(client *grpcadmin.Client, args []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if len(args) == 0 {
+		usage()
+		return fmt.Errorf("an admin command is required")
+	}
+	switch args[0] {
+	case "list":
+		timezone := ""
+		if len(args) > 1 {
+			timezone = args[1]
+		}
+		report, err := client.List(ctx, timezone)
+		if err != nil {
+			return err
+		}
+		fmt.Println(report)
+		return nil
+	case "force-done":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: admin force-done <name>")
+		}
+		msg, err := client.ForceDone(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		fmt.Println(msg)
+		return nil
+	case "extend":
+		if len(args) < 3 {
+			return fmt.Errorf("usage: admin extend <name> <duration>")
+		}
+		msg, err := client.Extend(ctx, args[1], args[2])
+		if err != nil {
+			return err
+		}
+		fmt.Println(msg)
+		return nil
+	case "set-max-clusters":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: admin set-max-clusters <limit>")
+		}
+		limit, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("the limit must be a number: %w", err)
+		}
+		msg, err := client.SetMaxClusters(ctx, limit)
+		if err != nil {
+			return err
+		}
+		fmt.Println(msg)
+		return nil
+	case "audit-log":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: admin audit-log <name>")
+		}
+		log, err := client.AuditLog(ctx, args[1])
+		if err != nil {
+			return err
+		}
+		fmt.Println(log)
+		return nil
+	default:
+		usage()
+		return fmt.Errorf("unknown admin command %q", args[0])
+	}
+}
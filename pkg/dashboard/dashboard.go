@@ -0,0 +1,89 @@
+// Package dashboard serves a small read-only web UI with the bot's live
+// state - the launch queue, active clusters, recent failures, and per-user
+// consumption - so operators can see what the bot is doing without kubectl
+// access to its namespace.
+//
+// The handler does not implement an OAuth flow itself; it is meant to be
+// deployed behind an authenticating proxy (such as the OpenShift oauth-proxy
+// sidecar) and trusts the forwarded-user header that proxy sets. Requests
+// without the header are rejected, so the dashboard is unusable if the route
+// bypasses the proxy.
+package dashboard
+
+import (
+	"html/template"
+	"net/http"
+	"time"
+
+	"github.com/openshift/ci-chat-bot/pkg/manager"
+	"k8s.io/klog"
+)
+
+const pageTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<title>cluster bot</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.8em; text-align: left; }
+th { background: #eee; }
+h2 { margin-bottom: 0.3em; }
+.empty { color: #888; }
+</style>
+</head>
+<body>
+<h1>cluster bot</h1>
+<p>{{len .Clusters}}/{{.MaxClusters}} clusters up, {{len .Queued}} queued - as of {{.Now.Format "2006-01-02 15:04:05 MST"}}</p>
+
+<h2>Active clusters</h2>
+{{if .Clusters}}<table>
+<tr><th>Name</th><th>Platform</th><th>Version</th><th>User</th><th>State</th><th>Requested</th><th>Expires</th></tr>
+{{range .Clusters}}<tr><td>{{.Name}}</td><td>{{.Platform}}</td><td>{{.Version}}</td><td>{{.User}}</td><td>{{.State}}</td><td>{{.RequestedAt.Format "15:04 Jan 2"}}</td><td>{{.ExpiresAt.Format "15:04 Jan 2"}}</td></tr>
+{{end}}</table>{{else}}<p class="empty">none</p>{{end}}
+
+<h2>Queue</h2>
+{{if .Queued}}<table>
+<tr><th>User</th><th>Platform</th><th>Requested</th></tr>
+{{range .Queued}}<tr><td>{{.User}}</td><td>{{.Platform}}</td><td>{{.RequestedAt.Format "15:04 Jan 2"}}</td></tr>
+{{end}}</table>{{else}}<p class="empty">empty</p>{{end}}
+
+<h2>Recent failures</h2>
+{{if .Failures}}<table>
+<tr><th>Name</th><th>Platform</th><th>User</th><th>When</th><th>Failure</th></tr>
+{{range .Failures}}<tr><td>{{.Name}}</td><td>{{.Platform}}</td><td>{{.User}}</td><td>{{.At.Format "15:04 Jan 2"}}</td><td>{{.Failure}}</td></tr>
+{{end}}</table>{{else}}<p class="empty">none</p>{{end}}
+
+<h2>Per-user consumption</h2>
+{{if .Users}}<table>
+<tr><th>User</th><th>Running clusters</th></tr>
+{{range .Users}}<tr><td>{{.User}}</td><td>{{.Running}}</td></tr>
+{{end}}</table>{{else}}<p class="empty">none</p>{{end}}
+</body>
+</html>
+`
+
+var page = template.Must(template.New("dashboard").Parse(pageTemplate))
+
+type pageData struct {
+	manager.DashboardData
+	Now time.Time
+}
+
+// NewHandler returns the dashboard handler. userHeader names the header the
+// authenticating proxy sets with the logged-in user's identity; requests
+// without it are rejected.
+func NewHandler(m manager.JobManager, userHeader string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user := r.Header.Get(userHeader)
+		if len(user) == 0 {
+			http.Error(w, "forbidden: no authenticated user", http.StatusForbidden)
+			return
+		}
+		klog.V(2).Infof("dashboard request from %s", user)
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := page.Execute(w, pageData{DashboardData: m.DashboardSnapshot(), Now: time.Now()}); err != nil {
+			klog.Errorf("Failed to render dashboard: %v", err)
+		}
+	})
+}
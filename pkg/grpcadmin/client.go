@@ -0,0 +1,74 @@
+package grpcadmin
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Client wraps a gRPC connection to the admin API for the companion CLI. It
+// attaches the bearer token and the operator's identity to every call and
+// selects the JSON codec the service requires.
+type Client struct {
+	conn   *grpc.ClientConn
+	token  string
+	caller string
+}
+
+// NewClient builds a client on an established connection. caller names the
+// operator for the server-side audit log.
+func NewClient(conn *grpc.ClientConn, token, caller string) *Client {
+	return &Client{conn: conn, token: token, caller: caller}
+}
+
+func (c *Client) invoke(ctx context.Context, method string, in, out interface{}) error {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+c.token, CallerKey, c.caller)
+	return c.conn.Invoke(ctx, "/"+ServiceName+"/"+method, in, out, grpc.CallContentSubtype(CodecName))
+}
+
+// List returns the admin report of all running jobs, rendered in the given
+// IANA timezone (empty means UTC).
+func (c *Client) List(ctx context.Context, timezone string) (string, error) {
+	out := &ListResponse{}
+	if err := c.invoke(ctx, "List", &ListRequest{Timezone: timezone}, out); err != nil {
+		return "", err
+	}
+	return out.Report, nil
+}
+
+// ForceDone terminates the named job regardless of owner.
+func (c *Client) ForceDone(ctx context.Context, name string) (string, error) {
+	out := &MessageResponse{}
+	if err := c.invoke(ctx, "ForceDone", &ForceDoneRequest{Name: name}, out); err != nil {
+		return "", err
+	}
+	return out.Message, nil
+}
+
+// Extend pushes back the named cluster's expiry by a Go-syntax duration.
+func (c *Client) Extend(ctx context.Context, name, duration string) (string, error) {
+	out := &MessageResponse{}
+	if err := c.invoke(ctx, "Extend", &ExtendRequest{Name: name, Duration: duration}, out); err != nil {
+		return "", err
+	}
+	return out.Message, nil
+}
+
+// SetMaxClusters adjusts the global concurrent cluster quota.
+func (c *Client) SetMaxClusters(ctx context.Context, limit int) (string, error) {
+	out := &MessageResponse{}
+	if err := c.invoke(ctx, "SetMaxClusters", &SetMaxClustersRequest{Limit: limit}, out); err != nil {
+		return "", err
+	}
+	return out.Message, nil
+}
+
+// AuditLog exports the audit proxy's access log for the named cluster.
+func (c *Client) AuditLog(ctx context.Context, name string) (string, error) {
+	out := &MessageResponse{}
+	if err := c.invoke(ctx, "AuditLog", &AuditLogRequest{Name: name}, out); err != nil {
+		return "", err
+	}
+	return out.Message, nil
+}
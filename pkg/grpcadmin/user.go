@@ -0,0 +1,320 @@
+package grpcadmin
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"time"
+
+	"github.com/openshift/ci-chat-bot/pkg/manager"
+	"github.com/openshift/ci-chat-bot/pkg/slack"
+	"github.com/openshift/ci-chat-bot/pkg/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog"
+)
+
+// The user service is the programmatic equivalent of the Slack launch flow:
+// per-user API tokens map to a Slack identity, and requests run through the
+// same manager entry points, so a CLI caller is subject to exactly the quota,
+// ownership, and budget treatment they would get by typing the command.
+
+// UserServiceName is the fully qualified gRPC name of the user service.
+const UserServiceName = "cichatbot.user.v1.User"
+
+// UserIdentity is the Slack identity an API token acts as.
+type UserIdentity struct {
+	// User is the Slack user ID that owns launched clusters.
+	User string `yaml:"user"`
+	// UserName is the human-readable name recorded on jobs.
+	UserName string `yaml:"userName"`
+	// Channel receives the notifications the bot would otherwise DM.
+	Channel string `yaml:"channel"`
+}
+
+// UserLaunchRequest mirrors the Slack launch command's two arguments.
+type UserLaunchRequest struct {
+	// ImageOrVersionOrPRs is a version, image, or comma-delimited PR list.
+	ImageOrVersionOrPRs string `json:"imageOrVersionOrPRs,omitempty"`
+	// Options is the comma-delimited launch options list, e.g. "aws,compact".
+	Options string `json:"options,omitempty"`
+}
+
+// UserStatusRequest asks about the caller's current launch.
+type UserStatusRequest struct{}
+
+// UserStatusResponse summarizes the caller's current launch.
+type UserStatusResponse struct {
+	Name string `json:"name"`
+	// Ready and Failed are the machine-readable outcome; Message is prose
+	// for display and callers should not parse it.
+	Ready   bool   `json:"ready"`
+	Failed  bool   `json:"failed,omitempty"`
+	Message string `json:"message"`
+	URL     string `json:"url,omitempty"`
+}
+
+// UserKubeconfigRequest asks for the caller's cluster credentials.
+type UserKubeconfigRequest struct{}
+
+// UserKubeconfigResponse carries the caller's cluster credentials.
+type UserKubeconfigResponse struct {
+	Kubeconfig      string `json:"kubeconfig"`
+	PasswordSnippet string `json:"passwordSnippet,omitempty"`
+}
+
+// UserDoneRequest tears down the caller's cluster.
+type UserDoneRequest struct{}
+
+type userService interface {
+	Launch(context.Context, *UserLaunchRequest) (*MessageResponse, error)
+	Status(context.Context, *UserStatusRequest) (*UserStatusResponse, error)
+	Kubeconfig(context.Context, *UserKubeconfigRequest) (*UserKubeconfigResponse, error)
+	Done(context.Context, *UserDoneRequest) (*MessageResponse, error)
+}
+
+type identityContextKey struct{}
+
+// identityFrom returns the identity the auth interceptor resolved for this
+// call; the interceptor guarantees it is present on accepted calls.
+func identityFrom(ctx context.Context) UserIdentity {
+	identity, _ := ctx.Value(identityContextKey{}).(UserIdentity)
+	return identity
+}
+
+// userAuthInterceptor resolves the per-user bearer token to its identity,
+// rejects unknown tokens, and audits every accepted call.
+func userAuthInterceptor(tokens map[string]UserIdentity) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "missing metadata")
+		}
+		authorization := md.Get("authorization")
+		if len(authorization) == 0 {
+			return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+		}
+		var identity UserIdentity
+		var found bool
+		for token, id := range tokens {
+			if subtle.ConstantTimeCompare([]byte(authorization[0]), []byte("Bearer "+token)) == 1 {
+				identity, found = id, true
+				break
+			}
+		}
+		if !found {
+			return nil, status.Error(codes.Unauthenticated, "invalid bearer token")
+		}
+		klog.Infof("grpc user audit: user=%q method=%s request=%+v", identity.User, info.FullMethod, req)
+		return handler(context.WithValue(ctx, identityContextKey{}, identity), req)
+	}
+}
+
+type userServer struct {
+	jobs manager.JobManager
+}
+
+func (s *userServer) Launch(ctx context.Context, req *UserLaunchRequest) (*MessageResponse, error) {
+	identity := identityFrom(ctx)
+	from, err := slack.ParseImageInput(req.ImageOrVersionOrPRs)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var inputs [][]string
+	if len(from) > 0 {
+		inputs = [][]string{from}
+	}
+	platform, architecture, params, err := slack.ParseOptions(req.Options, inputs, manager.JobTypeInstall)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	msg, err := s.jobs.LaunchJobForUser(&manager.JobRequest{
+		OriginalMessage: fmt.Sprintf("cli: launch %s %s", req.ImageOrVersionOrPRs, req.Options),
+		User:            identity.User,
+		UserName:        identity.UserName,
+		CorrelationID:   utils.CorrelationID("", identity.User, time.Now().Format(time.RFC3339Nano)),
+		Inputs:          inputs,
+		Type:            manager.JobTypeInstall,
+		Channel:         identity.Channel,
+		Platform:        platform,
+		JobParams:       params,
+		Architecture:    architecture,
+	})
+	// LaunchJobForUser reports its success message through the error return
+	if err != nil {
+		msg = err.Error()
+	}
+	return &MessageResponse{Message: msg}, nil
+}
+
+func (s *userServer) Status(ctx context.Context, _ *UserStatusRequest) (*UserStatusResponse, error) {
+	identity := identityFrom(ctx)
+	job, err := s.jobs.GetLaunchJob(identity.User)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	resp := &UserStatusResponse{Name: job.Name, URL: job.URL}
+	switch {
+	case len(job.Failure) > 0:
+		resp.Failed = true
+		resp.Message = fmt.Sprintf("cluster failed to launch: %s", job.Failure)
+	case len(job.Credentials) > 0:
+		resp.Ready = true
+		resp.Message = fmt.Sprintf("cluster is ready and will be shut down in %d minutes", int(time.Until(job.ExpiresAt)/time.Minute))
+	default:
+		resp.Message = fmt.Sprintf("cluster is still starting (launched %d minutes ago)", int(time.Since(job.RequestedAt)/time.Minute))
+	}
+	return resp, nil
+}
+
+func (s *userServer) Kubeconfig(ctx context.Context, _ *UserKubeconfigRequest) (*UserKubeconfigResponse, error) {
+	identity := identityFrom(ctx)
+	job, err := s.jobs.GetLaunchJob(identity.User)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if len(job.Credentials) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "the cluster is not ready yet")
+	}
+	return &UserKubeconfigResponse{Kubeconfig: job.Credentials, PasswordSnippet: job.PasswordSnippet}, nil
+}
+
+func (s *userServer) Done(ctx context.Context, _ *UserDoneRequest) (*MessageResponse, error) {
+	identity := identityFrom(ctx)
+	msg, err := s.jobs.TerminateJobForUser(identity.User)
+	if err != nil {
+		return nil, err
+	}
+	return &MessageResponse{Message: msg}, nil
+}
+
+func userLaunchHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserLaunchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(userService).Launch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + UserServiceName + "/Launch"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(userService).Launch(ctx, req.(*UserLaunchRequest))
+	})
+}
+
+func userStatusHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(userService).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + UserServiceName + "/Status"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(userService).Status(ctx, req.(*UserStatusRequest))
+	})
+}
+
+func userKubeconfigHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserKubeconfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(userService).Kubeconfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + UserServiceName + "/Kubeconfig"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(userService).Kubeconfig(ctx, req.(*UserKubeconfigRequest))
+	})
+}
+
+func userDoneHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserDoneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(userService).Done(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + UserServiceName + "/Done"}
+	return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(userService).Done(ctx, req.(*UserDoneRequest))
+	})
+}
+
+var userServiceDesc = grpc.ServiceDesc{
+	ServiceName: UserServiceName,
+	HandlerType: (*userService)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Launch", Handler: userLaunchHandler},
+		{MethodName: "Status", Handler: userStatusHandler},
+		{MethodName: "Kubeconfig", Handler: userKubeconfigHandler},
+		{MethodName: "Done", Handler: userDoneHandler},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "hand-written; see the package documentation",
+}
+
+// NewUserServer returns a gRPC server exposing the user service, guarded by
+// the per-user tokens.
+func NewUserServer(m manager.JobManager, tokens map[string]UserIdentity) *grpc.Server {
+	s := grpc.NewServer(grpc.UnaryInterceptor(userAuthInterceptor(tokens)))
+	s.RegisterService(&userServiceDesc, &userServer{jobs: m})
+	return s
+}
+
+// UserClient wraps a gRPC connection to the user service for the CLI.
+type UserClient struct {
+	conn  *grpc.ClientConn
+	token string
+}
+
+// NewUserClient builds a client on an established connection.
+func NewUserClient(conn *grpc.ClientConn, token string) *UserClient {
+	return &UserClient{conn: conn, token: token}
+}
+
+func (c *UserClient) invoke(ctx context.Context, method string, in, out interface{}) error {
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+c.token)
+	return c.conn.Invoke(ctx, "/"+UserServiceName+"/"+method, in, out, grpc.CallContentSubtype(CodecName))
+}
+
+// Launch requests a cluster with the Slack launch command's two arguments.
+func (c *UserClient) Launch(ctx context.Context, imageOrVersionOrPRs, options string) (string, error) {
+	out := &MessageResponse{}
+	if err := c.invoke(ctx, "Launch", &UserLaunchRequest{ImageOrVersionOrPRs: imageOrVersionOrPRs, Options: options}, out); err != nil {
+		return "", err
+	}
+	return out.Message, nil
+}
+
+// Status reports on the caller's current launch.
+func (c *UserClient) Status(ctx context.Context) (*UserStatusResponse, error) {
+	out := &UserStatusResponse{}
+	if err := c.invoke(ctx, "Status", &UserStatusRequest{}, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Kubeconfig fetches the caller's cluster credentials.
+func (c *UserClient) Kubeconfig(ctx context.Context) (*UserKubeconfigResponse, error) {
+	out := &UserKubeconfigResponse{}
+	if err := c.invoke(ctx, "Kubeconfig", &UserKubeconfigRequest{}, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Done tears down the caller's cluster.
+func (c *UserClient) Done(ctx context.Context) (string, error) {
+	out := &MessageResponse{}
+	if err := c.invoke(ctx, "Done", &UserDoneRequest{}, out); err != nil {
+		return "", err
+	}
+	return out.Message, nil
+}
@@ -0,0 +1,108 @@
+package harness
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/slack-go/slack/slackevents"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	prowapiv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+
+	"github.com/openshift/ci-chat-bot/pkg/manager"
+	"github.com/openshift/ci-chat-bot/pkg/slack"
+)
+
+// recordingJobManager embeds the JobManager interface and overrides only the
+// methods a launch flow touches, recording the request it receives.
+type recordingJobManager struct {
+	manager.JobManager
+	launched *manager.JobRequest
+}
+
+func (m *recordingJobManager) GetWorkflowConfig() *manager.WorkflowConfig {
+	return &manager.WorkflowConfig{}
+}
+
+func (m *recordingJobManager) GetUserPreferences(user string) map[string]string {
+	return nil
+}
+
+func (m *recordingJobManager) GetUserSSHKey(user string) (string, bool) {
+	return "", false
+}
+
+func (m *recordingJobManager) LaunchJobForUser(req *manager.JobRequest) (string, error) {
+	m.launched = req
+	return "cluster requested", nil
+}
+
+func TestLaunchCommandFlow(t *testing.T) {
+	server := NewSlackServer()
+	defer server.Close()
+	bot := slack.NewBot("fake-token", "fake-secret", 0, 8080, &manager.WorkflowConfig{}, "")
+	jobManager := &recordingJobManager{}
+	event := &slackevents.MessageEvent{User: "U1", Channel: "D1", Text: "launch 4.18 aws"}
+	var response string
+	matched := false
+	for _, command := range bot.SupportedCommands() {
+		if properties, ok := command.Match(event.Text); ok {
+			matched = true
+			response = command.Execute(server.Client(), jobManager, event, properties)
+			break
+		}
+	}
+	if !matched {
+		t.Fatal("no command matched the launch message")
+	}
+	if response != "cluster requested" {
+		t.Errorf("got response %q, expected %q", response, "cluster requested")
+	}
+	if jobManager.launched == nil {
+		t.Fatal("LaunchJobForUser was not invoked")
+	}
+	if jobManager.launched.Platform != "aws" {
+		t.Errorf("got platform %q, expected %q", jobManager.launched.Platform, "aws")
+	}
+	if len(jobManager.launched.Inputs) != 1 || jobManager.launched.Inputs[0][0] != "4.18" {
+		t.Errorf("got inputs %v, expected [[4.18]]", jobManager.launched.Inputs)
+	}
+}
+
+func TestNotifierPostsToSlack(t *testing.T) {
+	server := NewSlackServer()
+	defer server.Close()
+	bot := slack.NewBot("fake-token", "fake-secret", 0, 8080, &manager.WorkflowConfig{}, "CADMIN")
+	bot.ReaperResponder(server.Client())([]string{"operator role `leaked-role`"})
+	messages := server.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("got %d messages, expected 1", len(messages))
+	}
+	if messages[0].Channel != "CADMIN" {
+		t.Errorf("got channel %q, expected %q", messages[0].Channel, "CADMIN")
+	}
+	if !strings.Contains(messages[0].Text, "leaked-role") {
+		t.Errorf("message %q does not mention the reclaimed resource", messages[0].Text)
+	}
+}
+
+func TestFakeProwClient(t *testing.T) {
+	client := NewFakeProwClient()
+	jobs := client.ProwJobs("ci")
+	if _, err := jobs.Create(context.TODO(), &prowapiv1.ProwJob{ObjectMeta: metav1.ObjectMeta{Name: "job-1"}}, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if _, err := jobs.Create(context.TODO(), &prowapiv1.ProwJob{ObjectMeta: metav1.ObjectMeta{Name: "job-1"}}, metav1.CreateOptions{}); err == nil {
+		t.Error("expected duplicate create to fail")
+	}
+	list, err := jobs.List(context.TODO(), metav1.ListOptions{})
+	if err != nil || len(list.Items) != 1 {
+		t.Fatalf("got list %v (err %v), expected one job", list, err)
+	}
+	if err := jobs.Delete(context.TODO(), "job-1", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if _, err := jobs.Get(context.TODO(), "job-1", metav1.GetOptions{}); err == nil {
+		t.Error("expected get after delete to fail")
+	}
+}
@@ -0,0 +1,137 @@
+package harness
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/rest"
+
+	prowapiv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	prowjobClient "sigs.k8s.io/prow/pkg/client/clientset/versioned/typed/prowjobs/v1"
+)
+
+// FakeProwClient is an in-memory implementation of the typed prow job client
+// so tests can observe the prowjobs the manager creates without a cluster.
+type FakeProwClient struct {
+	lock sync.Mutex
+	jobs map[string]map[string]*prowapiv1.ProwJob
+}
+
+// NewFakeProwClient returns an empty in-memory prow job client.
+func NewFakeProwClient() *FakeProwClient {
+	return &FakeProwClient{jobs: map[string]map[string]*prowapiv1.ProwJob{}}
+}
+
+// RESTClient implements prowjobClient.ProwV1Interface; the fake has none.
+func (c *FakeProwClient) RESTClient() rest.Interface {
+	return nil
+}
+
+// ProwJobs implements prowjobClient.ProwV1Interface.
+func (c *FakeProwClient) ProwJobs(namespace string) prowjobClient.ProwJobInterface {
+	return &fakeProwJobs{client: c, namespace: namespace}
+}
+
+// Jobs returns the prow jobs created in the namespace so far.
+func (c *FakeProwClient) Jobs(namespace string) []*prowapiv1.ProwJob {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	var out []*prowapiv1.ProwJob
+	for _, job := range c.jobs[namespace] {
+		out = append(out, job.DeepCopy())
+	}
+	return out
+}
+
+type fakeProwJobs struct {
+	client    *FakeProwClient
+	namespace string
+}
+
+func (f *fakeProwJobs) namespaced() map[string]*prowapiv1.ProwJob {
+	jobs, ok := f.client.jobs[f.namespace]
+	if !ok {
+		jobs = map[string]*prowapiv1.ProwJob{}
+		f.client.jobs[f.namespace] = jobs
+	}
+	return jobs
+}
+
+func (f *fakeProwJobs) Create(ctx context.Context, prowJob *prowapiv1.ProwJob, opts metav1.CreateOptions) (*prowapiv1.ProwJob, error) {
+	f.client.lock.Lock()
+	defer f.client.lock.Unlock()
+	jobs := f.namespaced()
+	if _, ok := jobs[prowJob.Name]; ok {
+		return nil, fmt.Errorf("prowjob %s already exists", prowJob.Name)
+	}
+	copied := prowJob.DeepCopy()
+	copied.Namespace = f.namespace
+	jobs[copied.Name] = copied
+	return copied.DeepCopy(), nil
+}
+
+func (f *fakeProwJobs) Update(ctx context.Context, prowJob *prowapiv1.ProwJob, opts metav1.UpdateOptions) (*prowapiv1.ProwJob, error) {
+	f.client.lock.Lock()
+	defer f.client.lock.Unlock()
+	jobs := f.namespaced()
+	if _, ok := jobs[prowJob.Name]; !ok {
+		return nil, fmt.Errorf("prowjob %s not found", prowJob.Name)
+	}
+	copied := prowJob.DeepCopy()
+	jobs[copied.Name] = copied
+	return copied.DeepCopy(), nil
+}
+
+func (f *fakeProwJobs) UpdateStatus(ctx context.Context, prowJob *prowapiv1.ProwJob, opts metav1.UpdateOptions) (*prowapiv1.ProwJob, error) {
+	return f.Update(ctx, prowJob, opts)
+}
+
+func (f *fakeProwJobs) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	f.client.lock.Lock()
+	defer f.client.lock.Unlock()
+	jobs := f.namespaced()
+	if _, ok := jobs[name]; !ok {
+		return fmt.Errorf("prowjob %s not found", name)
+	}
+	delete(jobs, name)
+	return nil
+}
+
+func (f *fakeProwJobs) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	f.client.lock.Lock()
+	defer f.client.lock.Unlock()
+	f.client.jobs[f.namespace] = map[string]*prowapiv1.ProwJob{}
+	return nil
+}
+
+func (f *fakeProwJobs) Get(ctx context.Context, name string, options metav1.GetOptions) (*prowapiv1.ProwJob, error) {
+	f.client.lock.Lock()
+	defer f.client.lock.Unlock()
+	job, ok := f.namespaced()[name]
+	if !ok {
+		return nil, fmt.Errorf("prowjob %s not found", name)
+	}
+	return job.DeepCopy(), nil
+}
+
+func (f *fakeProwJobs) List(ctx context.Context, opts metav1.ListOptions) (*prowapiv1.ProwJobList, error) {
+	f.client.lock.Lock()
+	defer f.client.lock.Unlock()
+	list := &prowapiv1.ProwJobList{}
+	for _, job := range f.namespaced() {
+		list.Items = append(list.Items, *job.DeepCopy())
+	}
+	return list, nil
+}
+
+func (f *fakeProwJobs) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return watch.NewFake(), nil
+}
+
+func (f *fakeProwJobs) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (*prowapiv1.ProwJob, error) {
+	return nil, fmt.Errorf("patch is not supported by the fake prow client")
+}
@@ -0,0 +1,106 @@
+// Package harness provides lightweight in-process fakes for the bot's
+// external dependencies - the Slack Web API and the prow job client - so that
+// end-to-end command flows (parse, manager, prowjob creation, notification)
+// can be exercised from plain Go tests without a live Slack workspace or
+// cluster. It is exported so downstream forks can reuse it in their own tests.
+package harness
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/slack-go/slack"
+)
+
+// PostedMessage records one chat.postMessage call made against the fake
+// server.
+type PostedMessage struct {
+	Channel string
+	Text    string
+}
+
+// SlackServer is a fake Slack Web API server backed by httptest. It answers
+// the endpoints the bot uses and records outgoing messages for assertions.
+type SlackServer struct {
+	server *httptest.Server
+
+	lock     sync.Mutex
+	messages []PostedMessage
+
+	// Users maps user IDs to the user objects returned by users.info.
+	// Unknown users get a minimal default.
+	Users map[string]*slack.User
+	// ChannelMembers is returned by conversations.members for any channel.
+	ChannelMembers []string
+}
+
+// NewSlackServer starts a fake Slack Web API server. Callers must Close it.
+func NewSlackServer() *SlackServer {
+	s := &SlackServer{Users: map[string]*slack.User{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/chat.postMessage", s.handlePostMessage)
+	mux.HandleFunc("/users.info", s.handleUserInfo)
+	mux.HandleFunc("/conversations.members", s.handleConversationMembers)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]interface{}{"ok": true})
+	})
+	s.server = httptest.NewServer(mux)
+	return s
+}
+
+// Client returns a slack client wired to the fake server.
+func (s *SlackServer) Client() *slack.Client {
+	return slack.New("fake-token", slack.OptionAPIURL(s.server.URL+"/"))
+}
+
+// Close shuts the fake server down.
+func (s *SlackServer) Close() {
+	s.server.Close()
+}
+
+// Messages returns a copy of the messages posted so far.
+func (s *SlackServer) Messages() []PostedMessage {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]PostedMessage{}, s.messages...)
+}
+
+func (s *SlackServer) handlePostMessage(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.lock.Lock()
+	s.messages = append(s.messages, PostedMessage{Channel: r.FormValue("channel"), Text: r.FormValue("text")})
+	s.lock.Unlock()
+	writeJSON(w, map[string]interface{}{"ok": true, "channel": r.FormValue("channel"), "ts": fmt.Sprintf("%d", len(s.messages))})
+}
+
+func (s *SlackServer) handleUserInfo(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	id := r.FormValue("user")
+	user, ok := s.Users[id]
+	if !ok {
+		user = &slack.User{ID: id, Name: "user-" + id, TZ: "UTC"}
+	}
+	writeJSON(w, map[string]interface{}{"ok": true, "user": user})
+}
+
+func (s *SlackServer) handleConversationMembers(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]interface{}{
+		"ok":                s.ChannelMembers != nil,
+		"members":           s.ChannelMembers,
+		"response_metadata": map[string]interface{}{"next_cursor": ""},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,69 @@
+// Package i18n provides a small message catalog for the user-facing strings
+// the bot renders most often, so help and error messages can be read by
+// non-English-speaking field teams. English is the reference catalog; locales
+// fall back to it for any key they do not translate.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DefaultLocale is the reference catalog every other locale falls back to.
+const DefaultLocale = "en"
+
+var catalogs = map[string]map[string]string{
+	"en": {
+		"unrecognized-command":         "unrecognized command, msg me `help` for a list of all commands",
+		"unrecognized-command-suggest": "unrecognized command `%s` - did you mean `%s`? msg me `help` for a list of all commands",
+		"unparsed-command":             "I couldn't parse that `%s` command. Expected one of:\n```%s```\nmsg me `help %s` for details",
+		"help-no-command":              "no command named `%s` - msg me `help` for a list of all commands\n",
+		"help-additional-links":        "*Additional Links*\n",
+		"help-faq":                     "Please check out our <https://github.com/openshift/ci-chat-bot/blob/master/docs/FAQ.md|Frequently Asked Questions> for more information.\n",
+		"help-forum":                   "You can also reach out to us in <https://redhat-internal.slack.com/archives/CNHC2DK2M|#forum-ocp-crt> for more information.\n",
+	},
+	"es": {
+		"unrecognized-command":         "comando no reconocido, envíame `help` para ver la lista de todos los comandos",
+		"unrecognized-command-suggest": "comando no reconocido `%s` - ¿quisiste decir `%s`? envíame `help` para ver la lista de todos los comandos",
+		"unparsed-command":             "No pude interpretar ese comando `%s`. Se esperaba uno de:\n```%s```\nenvíame `help %s` para más detalles",
+		"help-no-command":              "no existe un comando llamado `%s` - envíame `help` para ver la lista de todos los comandos\n",
+		"help-additional-links":        "*Enlaces adicionales*\n",
+		"help-faq":                     "Consulta nuestras <https://github.com/openshift/ci-chat-bot/blob/master/docs/FAQ.md|preguntas frecuentes> para más información.\n",
+		"help-forum":                   "También puedes contactarnos en <https://redhat-internal.slack.com/archives/CNHC2DK2M|#forum-ocp-crt> para más información.\n",
+	},
+	"fr": {
+		"unrecognized-command":         "commande non reconnue, envoyez-moi `help` pour la liste de toutes les commandes",
+		"unrecognized-command-suggest": "commande non reconnue `%s` - vouliez-vous dire `%s` ? envoyez-moi `help` pour la liste de toutes les commandes",
+		"unparsed-command":             "Je n'ai pas pu interpréter cette commande `%s`. Attendu l'une de :\n```%s```\nenvoyez-moi `help %s` pour plus de détails",
+		"help-no-command":              "aucune commande nommée `%s` - envoyez-moi `help` pour la liste de toutes les commandes\n",
+		"help-additional-links":        "*Liens supplémentaires*\n",
+		"help-faq":                     "Consultez notre <https://github.com/openshift/ci-chat-bot/blob/master/docs/FAQ.md|foire aux questions> pour plus d'informations.\n",
+		"help-forum":                   "Vous pouvez aussi nous joindre sur <https://redhat-internal.slack.com/archives/CNHC2DK2M|#forum-ocp-crt> pour plus d'informations.\n",
+	},
+}
+
+// T renders the message for key in the given locale, formatting args with
+// fmt.Sprintf. Unknown locales and untranslated keys fall back to English.
+func T(locale, key string, args ...interface{}) string {
+	format, ok := catalogs[locale][key]
+	if !ok {
+		format = catalogs[DefaultLocale][key]
+	}
+	if len(format) == 0 {
+		return key
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// SupportedLocales lists the locales a catalog exists for, sorted.
+func SupportedLocales() []string {
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
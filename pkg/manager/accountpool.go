@@ -0,0 +1,62 @@
+package manager
+
+import (
+	"fmt"
+)
+
+// poolAccount describes one cloud account a platform can launch into and the
+// lease quota slice that tracks its remaining capacity.
+type poolAccount struct {
+	name       string
+	leaseSlice string
+	secondary  bool
+}
+
+// accountPools lists the accounts available per platform, primary account
+// first. New jobs are spread across the pool by picking the least-loaded
+// account, and accounts with no free leases are excluded entirely.
+var accountPools = map[string][]poolAccount{
+	"aws": {
+		{name: "aws", leaseSlice: "aws-quota-slice"},
+		{name: "aws-2", leaseSlice: "aws-2-quota-slice", secondary: true},
+	},
+	"azure": {
+		{name: "azure4", leaseSlice: "azure4-quota-slice"},
+		{name: "azure-2", leaseSlice: "azure-2-quota-slice", secondary: true},
+	},
+	"gcp": {
+		{name: "gcp", leaseSlice: "gcp-quota-slice"},
+		{name: "gcp-openshift-gce-devel-ci-2", leaseSlice: "gcp-openshift-gce-devel-ci-2-quota-slice", secondary: true},
+	},
+}
+
+// selectAccount picks the account with the most free leases from the
+// platform's pool and reports whether that is the secondary account. An error
+// is returned when quota in every account is exhausted so the user hears about
+// it before provisioning starts rather than after.
+func (m *jobManager) selectAccount(platform string) (bool, error) {
+	pool, ok := accountPools[platform]
+	if !ok || m.lClient == nil {
+		return false, nil
+	}
+	best := -1
+	bestFree := 0
+	for i, account := range pool {
+		metrics, err := m.lClient.Metrics(account.leaseSlice)
+		if err != nil {
+			return false, fmt.Errorf("failed to get metrics for `%s` leases: %v", account.name, err)
+		}
+		if metrics.Free <= 0 {
+			// this account's quota is exhausted; exclude it
+			continue
+		}
+		if metrics.Free > bestFree {
+			best = i
+			bestFree = metrics.Free
+		}
+	}
+	if best == -1 {
+		return false, fmt.Errorf("all %s accounts are out of quota right now; try again once running clusters wind down", platform)
+	}
+	return pool[best].secondary, nil
+}
@@ -0,0 +1,90 @@
+package manager
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/openshift/ci-tools/pkg/lease"
+)
+
+type fakeLeaseClient map[string]lease.Metrics
+
+func (c fakeLeaseClient) Metrics(rtype string) (lease.Metrics, error) {
+	metrics, ok := c[rtype]
+	if !ok {
+		return lease.Metrics{}, fmt.Errorf("no such resource %s", rtype)
+	}
+	return metrics, nil
+}
+
+func TestSelectAccount(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name         string
+		platform     string
+		metrics      fakeLeaseClient
+		useSecondary bool
+		errorString  string
+	}{
+		{
+			name:     "PrimaryLeastLoaded",
+			platform: "aws",
+			metrics: fakeLeaseClient{
+				"aws-quota-slice":   {Free: 10},
+				"aws-2-quota-slice": {Free: 5},
+			},
+			useSecondary: false,
+		},
+		{
+			name:     "SecondaryLeastLoaded",
+			platform: "aws",
+			metrics: fakeLeaseClient{
+				"aws-quota-slice":   {Free: 2},
+				"aws-2-quota-slice": {Free: 8},
+			},
+			useSecondary: true,
+		},
+		{
+			name:     "PrimaryExhausted",
+			platform: "gcp",
+			metrics: fakeLeaseClient{
+				"gcp-quota-slice":                          {Free: 0},
+				"gcp-openshift-gce-devel-ci-2-quota-slice": {Free: 1},
+			},
+			useSecondary: true,
+		},
+		{
+			name:     "AllExhausted",
+			platform: "azure",
+			metrics: fakeLeaseClient{
+				"azure4-quota-slice":  {Free: 0},
+				"azure-2-quota-slice": {Free: 0},
+			},
+			errorString: "all azure accounts are out of quota right now; try again once running clusters wind down",
+		},
+		{
+			name:         "PlatformWithoutPool",
+			platform:     "vsphere",
+			metrics:      fakeLeaseClient{},
+			useSecondary: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &jobManager{lClient: tc.metrics}
+			useSecondary, err := m.selectAccount(tc.platform)
+			if err != nil {
+				if err.Error() != tc.errorString {
+					t.Fatalf("got error %q, expected %q", err.Error(), tc.errorString)
+				}
+				return
+			}
+			if tc.errorString != "" {
+				t.Fatalf("expected error %q, got none", tc.errorString)
+			}
+			if useSecondary != tc.useSecondary {
+				t.Errorf("got useSecondary=%t, expected %t", useSecondary, tc.useSecondary)
+			}
+		})
+	}
+}
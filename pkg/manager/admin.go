@@ -0,0 +1,210 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+
+	botapiv1 "github.com/openshift/ci-chat-bot/pkg/prow/apiv1"
+	"github.com/openshift/ci-chat-bot/pkg/utils"
+)
+
+// AdminListAll renders every cluster the bot manages with its owner, inputs,
+// and expiry, regardless of who is asking, for fleet-wide triage during
+// incidents.
+func (m *jobManager) AdminListAll(timezone *time.Location) string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var clusters []*Job
+	var otherJobs int
+	for _, job := range m.jobs {
+		if job.Mode == JobTypeLaunch || job.Mode == JobTypeWorkflowLaunch {
+			clusters = append(clusters, job)
+		} else {
+			otherJobs++
+		}
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		if !clusters[i].RequestedAt.Equal(clusters[j].RequestedAt) {
+			return clusters[i].RequestedAt.Before(clusters[j].RequestedAt)
+		}
+		return clusters[i].Name < clusters[j].Name
+	})
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "%d cluster(s) across all users:\n\n", len(clusters))
+	now := time.Now()
+	for _, job := range clusters {
+		var version string
+		if len(job.Inputs) > 0 {
+			version = job.Inputs[0].Version
+			if len(version) == 0 && len(job.Inputs[0].Image) > 0 {
+				version = "(image)"
+			}
+		}
+		var state string
+		switch {
+		case job.Complete:
+			state = "shutting down"
+		case len(job.Failure) > 0:
+			state = fmt.Sprintf("failed: %s", job.Failure)
+		case len(job.Credentials) > 0:
+			state = fmt.Sprintf("up, expires in %d minutes (%s)", int(job.ExpiresAt.Sub(now)/time.Minute), utils.LocalTimeString(job.ExpiresAt, timezone))
+		default:
+			state = fmt.Sprintf("starting, %d minutes elapsed", int(now.Sub(job.RequestedAt)/time.Minute))
+		}
+		fmt.Fprintf(buf, "• <@%s> `%s` %s/%s %s - %s\n", job.RequestedBy, job.Name, job.Platform, job.Architecture, version, state)
+	}
+	if otherJobs > 0 {
+		fmt.Fprintf(buf, "\nplus %d non-cluster job(s) in flight\n", otherJobs)
+	}
+	return buf.String()
+}
+
+// AdminTerminatePlatform tears down every running cluster on the given
+// platform, e.g. during a cloud provider outage.
+func (m *jobManager) AdminTerminatePlatform(platform string) (string, error) {
+	if !utils.Contains(SupportedPlatforms, platform) {
+		return "", fmt.Errorf("platform %s is not supported, choose from: %s", platform, strings.Join(SupportedPlatforms, ", "))
+	}
+	m.lock.Lock()
+	var owners []string
+	for _, job := range m.jobs {
+		if (job.Mode == JobTypeLaunch || job.Mode == JobTypeWorkflowLaunch) && !job.Complete && job.Platform == platform && len(job.RequestedBy) > 0 {
+			// spokes are torn down with their hub
+			if IsSpokeOwner(job.RequestedBy) {
+				continue
+			}
+			owners = append(owners, job.RequestedBy)
+		}
+	}
+	m.lock.Unlock()
+	if len(owners) == 0 {
+		return fmt.Sprintf("no running clusters on platform %s", platform), nil
+	}
+	sort.Strings(owners)
+	var failures []string
+	for _, owner := range owners {
+		if _, err := m.TerminateJobForUser(owner); err != nil {
+			klog.Warningf("Failed to terminate %s cluster for %s: %v", platform, owner, err)
+			failures = append(failures, fmt.Sprintf("<@%s>: %v", owner, err))
+		}
+	}
+	msg := fmt.Sprintf("requested teardown of %d cluster(s) on platform %s", len(owners)-len(failures), platform)
+	if len(failures) > 0 {
+		msg += fmt.Sprintf("; %d failed:\n• %s", len(failures), strings.Join(failures, "\n• "))
+	}
+	return msg, nil
+}
+
+// AdminExtendAll pushes back the expiry of every running cluster by the given
+// duration, persisted via the expires annotation on each ProwJob so the
+// extension survives a bot restart.
+func (m *jobManager) AdminExtendAll(extension time.Duration) (string, error) {
+	if extension <= 0 {
+		return "", fmt.Errorf("the extension must be a positive duration, e.g. `2h`")
+	}
+	if extension > 24*time.Hour {
+		return "", fmt.Errorf("the extension may not exceed 24h")
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var extended, failed int
+	for _, job := range m.jobs {
+		if job.Mode != JobTypeLaunch && job.Mode != JobTypeWorkflowLaunch {
+			continue
+		}
+		if job.Complete {
+			continue
+		}
+		newExpiry := job.ExpiresAt.Add(extension)
+		expires := int(newExpiry.Sub(job.RequestedAt).Seconds())
+		patch := []byte(fmt.Sprintf(`{"metadata":{"annotations":{"%s":"%d"}}}`, botapiv1.AnnotationExpires, expires))
+		if _, err := m.prowClient.ProwJobs(m.prowNamespace).Patch(context.TODO(), job.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			klog.Warningf("Failed to extend expiry of job %q: %v", job.Name, err)
+			failed++
+			continue
+		}
+		job.ExpiresAt = newExpiry
+		extended++
+	}
+	msg := fmt.Sprintf("extended %d cluster(s) by %s", extended, extension)
+	if failed > 0 {
+		msg += fmt.Sprintf("; %d could not be extended, see the bot logs", failed)
+	}
+	return msg, nil
+}
+
+// AdminExtendJob extends a single cluster (and any spokes in its group) on
+// behalf of an administrator, bypassing the ownership check users are subject
+// to in ExtendJobExpiry.
+func (m *jobManager) AdminExtendJob(name string, extension time.Duration) (string, error) {
+	m.lock.Lock()
+	job, ok := m.jobs[name]
+	var owner string
+	if ok {
+		owner = job.RequestedBy
+	}
+	m.lock.Unlock()
+	if !ok {
+		return "", fmt.Errorf("no job named `%s` was found", name)
+	}
+	return m.ExtendJobExpiry(owner, name, extension)
+}
+
+// AdminSetMaxClusters adjusts the global concurrent cluster quota at runtime,
+// for example to drain the bot ahead of maintenance or to absorb a busy day.
+// The change is not persisted and reverts to the flag value on restart.
+func (m *jobManager) AdminSetMaxClusters(limit int) (string, error) {
+	if limit < 1 {
+		return "", fmt.Errorf("the cluster limit must be at least 1")
+	}
+	m.lock.Lock()
+	previous := m.maxClusters
+	m.maxClusters = limit
+	m.lock.Unlock()
+	return fmt.Sprintf("the concurrent cluster limit is now %d (was %d)", limit, previous), nil
+}
+
+// ClusterOwners returns the owner and requested channel of every running
+// cluster, so admins can broadcast to the people affected by an incident.
+func (m *jobManager) ClusterOwners() map[string]string {
+	return m.BroadcastTargets("", "")
+}
+
+// BroadcastTargets returns the owner and requested channel of every active or
+// still-starting cluster, optionally narrowed to a platform and a version
+// prefix, so admins can announce outages to exactly the users affected.
+func (m *jobManager) BroadcastTargets(platform, version string) map[string]string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	owners := make(map[string]string)
+	for _, job := range m.jobs {
+		if job.Mode != JobTypeLaunch && job.Mode != JobTypeWorkflowLaunch {
+			continue
+		}
+		if job.Complete || len(job.RequestedBy) == 0 {
+			continue
+		}
+		// the hub's owner speaks for its spokes
+		if IsSpokeOwner(job.RequestedBy) {
+			continue
+		}
+		if len(platform) > 0 && job.Platform != platform {
+			continue
+		}
+		if len(version) > 0 && (len(job.Inputs) == 0 || !strings.HasPrefix(job.Inputs[0].Version, version)) {
+			continue
+		}
+		owners[job.RequestedBy] = job.RequestedChannel
+	}
+	return owners
+}
@@ -0,0 +1,138 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/ci-chat-bot/pkg/utils"
+)
+
+// AliasSecretName is the secret used to persist per-user command aliases. Like
+// the rosa cluster tracking secret, it lives in the bot's namespace and each
+// data key is a slack user ID.
+var AliasSecretName = "ci-chat-bot-user-aliases"
+
+// maxAliasesPerUser keeps a single user from growing the alias secret without bound
+const maxAliasesPerUser = 20
+
+func (m *jobManager) getUserAliases(user string) (map[string]string, error) {
+	secret, err := m.rosaSecretClient.Get(context.TODO(), AliasSecretName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get `%s` secret: %w", AliasSecretName, err)
+	}
+	aliases := map[string]string{}
+	if raw, ok := secret.Data[user]; ok {
+		if err := json.Unmarshal(raw, &aliases); err != nil {
+			return nil, fmt.Errorf("Failed to unmarshal aliases for user: %w", err)
+		}
+	}
+	return aliases, nil
+}
+
+func (m *jobManager) saveUserAliases(user string, aliases map[string]string) error {
+	raw, err := json.Marshal(aliases)
+	if err != nil {
+		return err
+	}
+	return utils.UpdateSecret(AliasSecretName, m.rosaSecretClient, func(secret *corev1.Secret) {
+		if len(aliases) == 0 {
+			delete(secret.Data, user)
+			return
+		}
+		secret.Data[user] = raw
+	})
+}
+
+// SetUserAlias stores a personal macro for the user that the message handler
+// expands before command matching.
+func (m *jobManager) SetUserAlias(user, name, expansion string) (string, error) {
+	name = strings.TrimSpace(name)
+	expansion = strings.TrimSpace(expansion)
+	if len(strings.Fields(name)) != 1 {
+		return "", fmt.Errorf("alias names must be a single word")
+	}
+	if name == "alias" || name == "help" {
+		return "", fmt.Errorf("`%s` cannot be redefined", name)
+	}
+	if len(expansion) == 0 {
+		return "", fmt.Errorf("you must provide a command for the alias to expand to")
+	}
+	aliases, err := m.getUserAliases(user)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := aliases[name]; !ok && len(aliases) >= maxAliasesPerUser {
+		return "", fmt.Errorf("you can't have more than %d aliases - remove one with `alias rm <name>` first", maxAliasesPerUser)
+	}
+	aliases[name] = expansion
+	if err := m.saveUserAliases(user, aliases); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("alias `%s` now expands to `%s`", name, expansion), nil
+}
+
+// RemoveUserAlias deletes one of the user's stored aliases.
+func (m *jobManager) RemoveUserAlias(user, name string) (string, error) {
+	aliases, err := m.getUserAliases(user)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := aliases[name]; !ok {
+		return "", fmt.Errorf("you have no alias named `%s`", name)
+	}
+	delete(aliases, name)
+	if err := m.saveUserAliases(user, aliases); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("alias `%s` removed", name), nil
+}
+
+// ListUserAliases renders the user's stored aliases.
+func (m *jobManager) ListUserAliases(user string) (string, error) {
+	aliases, err := m.getUserAliases(user)
+	if err != nil {
+		return "", err
+	}
+	if len(aliases) == 0 {
+		return "you have no aliases defined - create one with `alias <name> = <command>`", nil
+	}
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	buf := &strings.Builder{}
+	fmt.Fprintf(buf, "you have %d alias(es) defined:\n", len(aliases))
+	for _, name := range names {
+		fmt.Fprintf(buf, "• `%s` = `%s`\n", name, aliases[name])
+	}
+	return buf.String(), nil
+}
+
+// ExpandUserAlias replaces the leading word of text with the user's matching
+// alias expansion, if one exists. It returns false when no alias applies.
+func (m *jobManager) ExpandUserAlias(user, text string) (string, bool) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return "", false
+	}
+	aliases, err := m.getUserAliases(user)
+	if err != nil {
+		return "", false
+	}
+	expansion, ok := aliases[fields[0]]
+	if !ok {
+		return "", false
+	}
+	return strings.TrimSpace(strings.Join(append([]string{expansion}, fields[1:]...), " ")), true
+}
@@ -0,0 +1,223 @@
+package manager
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/klog"
+)
+
+// Compliance-sensitive deployments need to know who did what on a
+// bot-launched cluster. When the audit proxy is enabled, the kubeconfig
+// handed to users points at the bot instead of the cluster API server; the
+// bot authenticates the caller with a per-cluster token, records every API
+// call, and forwards the request using the real admin credentials. The
+// access log lives in memory until the bot restarts so administrators can
+// export it after the cluster is gone.
+
+// maxAuditEntries bounds the per-cluster access log; older entries are
+// dropped but counted.
+const maxAuditEntries = 10000
+
+// AuditEntry records a single proxied API call.
+type AuditEntry struct {
+	Time   time.Time
+	User   string
+	Method string
+	Path   string
+}
+
+type auditTarget struct {
+	user    string
+	token   string
+	proxy   *httputil.ReverseProxy
+	entries []AuditEntry
+	dropped int
+}
+
+// AuditProxy is an authenticating reverse proxy in front of launched
+// clusters' API servers that logs every call per user. It serves each
+// cluster under the path /<cluster-name>/.
+type AuditProxy struct {
+	// externalURL is the address users reach the proxy at; it becomes the
+	// server in the kubeconfigs the bot hands out, so it must terminate TLS
+	// (for example an OpenShift route in front of the listener).
+	externalURL string
+
+	lock    sync.Mutex
+	targets map[string]*auditTarget
+}
+
+// NewAuditProxy returns a proxy whose rewritten kubeconfigs point at
+// externalURL.
+func NewAuditProxy(externalURL string) *AuditProxy {
+	return &AuditProxy{
+		externalURL: strings.TrimSuffix(externalURL, "/"),
+		targets:     make(map[string]*auditTarget),
+	}
+}
+
+// SetAuditProxy routes the credentials of subsequently launched clusters
+// through the proxy.
+func (m *jobManager) SetAuditProxy(p *AuditProxy) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.auditProxy = p
+}
+
+// proxyCredentials rewrites a launched cluster's credentials to go through
+// the audit proxy. It is best-effort: on failure the user keeps direct
+// access and the failure is logged.
+func (m *jobManager) proxyCredentials(job *Job) {
+	m.lock.Lock()
+	p := m.auditProxy
+	m.lock.Unlock()
+	if p == nil || len(job.Credentials) == 0 {
+		return
+	}
+	kubeconfig, err := p.register(job)
+	if err != nil {
+		klog.Errorf("job %q: unable to route credentials through the audit proxy: %v", job.Name, err)
+		return
+	}
+	job.Credentials = kubeconfig
+	job.PasswordSnippet += "\nAPI access to this cluster goes through an audit proxy and every call is logged with your name"
+}
+
+// register builds the reverse proxy for a job's API server using the admin
+// credentials and returns a kubeconfig that sends the user through the
+// proxy.
+func (p *AuditProxy) register(job *Job) (string, error) {
+	cfg, err := loadKubeconfigContents(job.Credentials)
+	if err != nil {
+		return "", err
+	}
+	target, err := url.Parse(cfg.Host)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse API server URL: %v", err)
+	}
+	transport, err := rest.TransportFor(cfg)
+	if err != nil {
+		return "", fmt.Errorf("unable to build transport: %v", err)
+	}
+	data := make([]byte, 32)
+	if _, err := rand.Read(data); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(data)
+
+	proxy := &httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			req.URL.Scheme = target.Scheme
+			req.URL.Host = target.Host
+			req.Host = target.Host
+			// the admin credentials live in the transport; never forward the
+			// proxy token upstream
+			req.Header.Del("Authorization")
+		},
+		Transport: transport,
+	}
+
+	p.lock.Lock()
+	p.targets[job.Name] = &auditTarget{user: job.RequestedBy, token: token, proxy: proxy}
+	p.lock.Unlock()
+
+	klog.Infof("Audit proxy registered cluster %q for %s", job.Name, job.RequestedBy)
+	return fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: %s/%s
+  name: %s
+contexts:
+- context:
+    cluster: %s
+    user: admin
+  name: admin
+current-context: admin
+users:
+- name: admin
+  user:
+    token: %s
+`, p.externalURL, job.Name, job.Name, job.Name, token), nil
+}
+
+// ServeHTTP authenticates the caller against the per-cluster token, records
+// the call, and forwards it to the cluster's API server.
+func (p *AuditProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)
+	name := parts[0]
+
+	p.lock.Lock()
+	target, ok := p.targets[name]
+	p.lock.Unlock()
+	if !ok {
+		http.Error(w, "unknown cluster", http.StatusNotFound)
+		return
+	}
+	auth := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(auth), []byte(target.token)) != 1 {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	path := "/"
+	if len(parts) > 1 {
+		path += parts[1]
+	}
+
+	p.lock.Lock()
+	if len(target.entries) >= maxAuditEntries {
+		target.entries = target.entries[1:]
+		target.dropped++
+	}
+	target.entries = append(target.entries, AuditEntry{Time: time.Now(), User: target.user, Method: r.Method, Path: path})
+	p.lock.Unlock()
+
+	http.StripPrefix("/"+name, target.proxy).ServeHTTP(w, r)
+}
+
+// accessLog renders the recorded calls for a cluster.
+func (p *AuditProxy) accessLog(name string) (string, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	target, ok := p.targets[name]
+	if !ok {
+		names := make([]string, 0, len(p.targets))
+		for n := range p.targets {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return "", fmt.Errorf("no access log for cluster %q; known clusters: %s", name, strings.Join(names, ", "))
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "access log for %s (%d calls", name, len(target.entries))
+	if target.dropped > 0 {
+		fmt.Fprintf(&sb, ", oldest %d dropped", target.dropped)
+	}
+	sb.WriteString("):\n")
+	for _, entry := range target.entries {
+		fmt.Fprintf(&sb, "%s %s %s %s\n", entry.Time.UTC().Format(time.RFC3339), entry.User, entry.Method, entry.Path)
+	}
+	return sb.String(), nil
+}
+
+// AdminAuditLog exports the audit proxy's access log for the named cluster.
+func (m *jobManager) AdminAuditLog(name string) (string, error) {
+	m.lock.Lock()
+	p := m.auditProxy
+	m.lock.Unlock()
+	if p == nil {
+		return "", fmt.Errorf("the audit proxy is not enabled")
+	}
+	return p.accessLog(name)
+}
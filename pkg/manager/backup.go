@@ -0,0 +1,198 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog"
+	prowapiv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+// Right before a risky operation - a time skew, a chaos scenario, an upgrade -
+// users want the cluster's state preserved somewhere that survives the
+// cluster. `backup` runs the standard etcd cluster-backup script on a control
+// plane node, pulls the snapshot off the node, and lets the job's artifact
+// upload carry it to the artifact store, so the bot only has to hand back a
+// link. The job runs on the build cluster that hosts the launch, in the same
+// way conformance runs do, and reuses the conformance tests image since it
+// carries the `oc` client the script driving the backup needs.
+
+const (
+	// backupTimeout bounds how long a backup may take before it is reported
+	// as timed out; taking and streaming the snapshot normally takes minutes.
+	backupTimeout = 30 * time.Minute
+	// backupPollInterval is how often the backup job's state is re-checked.
+	backupPollInterval = 30 * time.Second
+)
+
+// backupScript drives the backup from inside the job pod: it runs the
+// cluster-backup script on the first control plane node via a debug pod, then
+// streams the resulting snapshot into the artifact directory so the job's
+// artifact upload publishes it.
+const backupScript = `printf '%s' "${CLUSTER_KUBECONFIG}" > /tmp/kubeconfig
+export KUBECONFIG=/tmp/kubeconfig
+set -euo pipefail
+node="$(oc get nodes -l node-role.kubernetes.io/master= -o name | head -n 1)"
+oc debug --quiet "${node}" -- chroot /host /usr/local/bin/cluster-backup.sh /home/core/assets/backup
+oc debug --quiet "${node}" -- tar -C /host/home/core/assets -cz backup > "${ARTIFACT_DIR:-/tmp/artifacts}/etcd-backup.tar.gz"
+oc debug --quiet "${node}" -- rm -rf /host/home/core/assets/backup`
+
+// SetBackupNotifier registers the callback that reports a backup's outcome
+// back to the caller.
+func (m *jobManager) SetBackupNotifier(fn BackupCallbackFunc) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.backupNotifierFn = fn
+}
+
+// RunEtcdBackup starts an etcd backup of the caller's launched cluster and
+// reports the artifact link asynchronously through the backup notifier.
+func (m *jobManager) RunEtcdBackup(user, channel string) (string, error) {
+	m.lock.Lock()
+	image := m.conformanceImage
+	m.lock.Unlock()
+	if len(image) == 0 {
+		return "", fmt.Errorf("the administrators have not configured a tests image (`--conformance-tests-image`), which the backup job needs for the `oc` client")
+	}
+	job, err := m.GetLaunchJob(user)
+	if err != nil {
+		return "", err
+	}
+	if len(job.Credentials) == 0 {
+		return "", fmt.Errorf("the cluster is not ready yet; try again once you have received your credentials")
+	}
+	if len(job.BuildCluster) == 0 {
+		return "", fmt.Errorf("unable to determine which build cluster hosts your job; try again in a few minutes")
+	}
+
+	name := fmt.Sprintf("%s-backup", job.Name)
+	m.lock.Lock()
+	if m.backupRuns == nil {
+		m.backupRuns = make(map[string]string)
+	}
+	if _, ok := m.backupRuns[job.Name]; ok {
+		m.lock.Unlock()
+		return "", fmt.Errorf("a backup of your cluster is already in progress; wait for its link before starting another")
+	}
+	m.backupRuns[job.Name] = name
+	m.lock.Unlock()
+
+	pj := backupProwJob(name, job, image)
+	url, err := m.executor.Submit(context.TODO(), pj)
+	if err != nil {
+		m.lock.Lock()
+		delete(m.backupRuns, job.Name)
+		m.lock.Unlock()
+		return "", fmt.Errorf("unable to start the backup: %v", err)
+	}
+
+	go m.waitForBackup(name, job.Name, job.BuildCluster, user, channel, url)
+	msg := fmt.Sprintf("taking an etcd backup of `%s` - I will post the download link here once the snapshot is uploaded, and give up after %d minutes", job.Name, int(backupTimeout.Minutes()))
+	if len(url) > 0 {
+		msg += fmt.Sprintf("; follow along at %s", url)
+	}
+	return msg, nil
+}
+
+// backupProwJob prepares the job that takes the backup. As with conformance
+// runs, the admin kubeconfig travels in the pod environment, and the job
+// deliberately does not carry the launch label so the sync loop never
+// mistakes it for a cluster.
+func backupProwJob(name string, job *Job, image string) *prowapiv1.ProwJob {
+	return &prowapiv1.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				"prow.k8s.io/type": string(prowapiv1.PeriodicJob),
+				"prow.k8s.io/job":  name,
+			},
+			Annotations: map[string]string{
+				"prow.k8s.io/job":                    name,
+				"ci-chat-bot.openshift.io/backupFor": job.Name,
+			},
+		},
+		Spec: prowapiv1.ProwJobSpec{
+			Type:    prowapiv1.PeriodicJob,
+			Agent:   prowapiv1.KubernetesAgent,
+			Job:     name,
+			Cluster: job.BuildCluster,
+			Report:  false,
+			PodSpec: &corev1.PodSpec{
+				RestartPolicy: corev1.RestartPolicyNever,
+				Containers: []corev1.Container{
+					{
+						Name:    "backup",
+						Image:   image,
+						Command: []string{"/bin/bash", "-c", backupScript},
+						Env: []corev1.EnvVar{
+							{Name: "CLUSTER_KUBECONFIG", Value: job.Credentials},
+						},
+					},
+				},
+			},
+		},
+		Status: prowapiv1.ProwJobStatus{
+			State:     prowapiv1.TriggeredState,
+			StartTime: metav1.Now(),
+		},
+	}
+}
+
+// waitForBackup polls the backup job to completion and delivers the outcome.
+func (m *jobManager) waitForBackup(name, jobName, cluster, user, channel, url string) {
+	defer func() {
+		m.lock.Lock()
+		delete(m.backupRuns, jobName)
+		m.lock.Unlock()
+	}()
+
+	started := time.Now()
+	var state prowapiv1.ProwJobState
+	err := wait.PollUntilContextTimeout(context.TODO(), backupPollInterval, backupTimeout, false, func(ctx context.Context) (bool, error) {
+		pj, err := m.executor.Get(ctx, name, cluster)
+		if err != nil {
+			klog.V(2).Infof("Backup run %s: unable to check state: %v", name, err)
+			return false, nil
+		}
+		state = pj.Status.State
+		if len(pj.Status.URL) > 0 {
+			url = pj.Status.URL
+		}
+		return pj.Status.State == prowapiv1.SuccessState || pj.Status.State == prowapiv1.FailureState || pj.Status.State == prowapiv1.AbortedState || pj.Status.State == prowapiv1.ErrorState, nil
+	})
+	duration := time.Since(started).Round(time.Second)
+
+	link := "the job page"
+	if len(url) > 0 {
+		link = fmt.Sprintf("<%s|the job page>", url)
+	}
+	var message string
+	switch {
+	case err != nil:
+		message = fmt.Sprintf("the etcd backup of `%s` did not finish within %d minutes; check %s", jobName, int(backupTimeout.Minutes()), link)
+	case state == prowapiv1.SuccessState:
+		message = fmt.Sprintf("the etcd backup of `%s` completed in %s - download `etcd-backup.tar.gz` from the artifacts on %s", jobName, duration, link)
+	case state == prowapiv1.FailureState:
+		message = fmt.Sprintf("the etcd backup of `%s` FAILED after %s - the error output is on %s", jobName, duration, link)
+	default:
+		message = fmt.Sprintf("the etcd backup of `%s` ended in state `%s` after %s; see %s", jobName, state, duration, link)
+	}
+	m.notifyBackupResult(user, channel, message)
+}
+
+// notifyBackupResult delivers a backup outcome to the requesting user, or
+// logs it when no notifier is wired up.
+func (m *jobManager) notifyBackupResult(user, channel, message string) {
+	m.lock.Lock()
+	notifier := m.backupNotifierFn
+	m.lock.Unlock()
+	if notifier == nil {
+		klog.Infof("no backup notifier configured, dropping message for %q: %s", user, message)
+		return
+	}
+	notifier(user, channel, message)
+}
@@ -0,0 +1,184 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+
+	"github.com/openshift/ci-chat-bot/pkg/utils"
+)
+
+// CostUsageSecretName is the secret that accumulates each group's estimated
+// spend for the current month.
+const CostUsageSecretName = "ci-chat-bot-cost-usage"
+
+// CostOverrideParam is the launch option an admin-provided token is passed
+// with to launch past an exhausted budget.
+const CostOverrideParam = "override"
+
+// budgetGroupForUser returns the budget group the user belongs to, or nil if
+// the user is not covered by any group.
+func (m *jobManager) budgetGroupForUser(user string) *CostBudgetGroup {
+	cfg := m.costBudgetConfig
+	if cfg == nil {
+		return nil
+	}
+	cfg.Mutex.RLock()
+	defer cfg.Mutex.RUnlock()
+	for i, group := range cfg.Groups {
+		for _, member := range group.Users {
+			if member == user {
+				copied := cfg.Groups[i]
+				return &copied
+			}
+		}
+	}
+	return nil
+}
+
+// costUsageKey scopes spend entries to a calendar month so budgets reset on
+// the first of each month.
+func costUsageKey(group string, now time.Time) string {
+	return fmt.Sprintf("%s/%s", now.UTC().Format("2006-01"), group)
+}
+
+// costUsage returns the group's accumulated spend for the current month.
+func (m *jobManager) costUsage(group string) (float64, error) {
+	if m.rosaSecretClient == nil {
+		return 0, fmt.Errorf("no secret client configured")
+	}
+	secret, err := m.rosaSecretClient.Get(context.TODO(), CostUsageSecretName, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	raw, ok := secret.Data[costUsageKey(group, time.Now())]
+	if !ok {
+		return 0, nil
+	}
+	var spent float64
+	if err := json.Unmarshal(raw, &spent); err != nil {
+		return 0, fmt.Errorf("corrupt cost usage entry for group %s: %w", group, err)
+	}
+	return spent, nil
+}
+
+// chargeCostForJob records the estimated cost of a finished cluster against
+// the owning user's budget group. Months other than the current one are
+// pruned as a side effect, so budgets reset without a separate cleanup.
+func (m *jobManager) chargeCostForJob(job *Job) {
+	cfg := m.costBudgetConfig
+	if cfg == nil {
+		return
+	}
+	cfg.Mutex.RLock()
+	rate := cfg.HourlyRate
+	cfg.Mutex.RUnlock()
+	if rate <= 0 {
+		return
+	}
+	group := m.budgetGroupForUser(job.RequestedBy)
+	if group == nil {
+		return
+	}
+	hours := time.Since(job.RequestedAt).Hours()
+	if hours <= 0 {
+		return
+	}
+	cost := hours * rate
+	key := costUsageKey(group.Name, time.Now())
+	prefix := time.Now().UTC().Format("2006-01") + "/"
+	err := utils.UpdateSecret(CostUsageSecretName, m.rosaSecretClient, func(secret *corev1.Secret) {
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		for existing := range secret.Data {
+			if !strings.HasPrefix(existing, prefix) {
+				delete(secret.Data, existing)
+			}
+		}
+		var spent float64
+		if raw, ok := secret.Data[key]; ok {
+			if err := json.Unmarshal(raw, &spent); err != nil {
+				spent = 0
+			}
+		}
+		raw, _ := json.Marshal(spent + cost)
+		secret.Data[key] = raw
+	})
+	if err != nil {
+		klog.Warningf("Failed to charge $%.2f to budget group %s: %v", cost, group.Name, err)
+		return
+	}
+	klog.Infof("charged $%.2f for cluster %s (%.1fh) to budget group %s", cost, job.Name, hours, group.Name)
+}
+
+// checkCostBudget rejects a launch when the requesting user's group has
+// exhausted its monthly budget, unless the request carries the admin override
+// token.
+func (m *jobManager) checkCostBudget(req *JobRequest) error {
+	cfg := m.costBudgetConfig
+	if cfg == nil {
+		return nil
+	}
+	group := m.budgetGroupForUser(req.User)
+	if group == nil || group.MonthlyBudget <= 0 {
+		return nil
+	}
+	spent, err := m.costUsage(group.Name)
+	if err != nil {
+		// the budget check is advisory; an unreadable ledger must not block
+		// all launches
+		klog.Warningf("Unable to read cost usage for group %s: %v", group.Name, err)
+		return nil
+	}
+	if spent < group.MonthlyBudget {
+		return nil
+	}
+	cfg.Mutex.RLock()
+	token := cfg.OverrideToken
+	cfg.Mutex.RUnlock()
+	if provided := req.JobParams[CostOverrideParam]; len(token) > 0 && provided == token {
+		klog.Infof("budget override accepted for user %s in group %s", req.User, group.Name)
+		if m.adminNotifierFn != nil {
+			m.adminNotifierFn(fmt.Sprintf("<@%s> used the budget override token to launch past group `%s`'s exhausted budget ($%.2f of $%.2f)", req.User, group.Name, spent, group.MonthlyBudget))
+		}
+		return nil
+	}
+	return fmt.Errorf("your group `%s` has spent $%.2f of its $%.2f monthly cluster budget; ask an administrator for an override token and relaunch with `override=<token>`", group.Name, spent, group.MonthlyBudget)
+}
+
+// CostReport renders the requesting user's group budget consumption for the
+// `cost team` command.
+func (m *jobManager) CostReport(user string) (string, error) {
+	if m.costBudgetConfig == nil {
+		return "", fmt.Errorf("cost budgets are not configured")
+	}
+	group := m.budgetGroupForUser(user)
+	if group == nil {
+		return "", fmt.Errorf("you are not a member of any budget group")
+	}
+	spent, err := m.costUsage(group.Name)
+	if err != nil {
+		return "", fmt.Errorf("unable to read cost usage: %w", err)
+	}
+	remaining := group.MonthlyBudget - spent
+	if remaining < 0 {
+		remaining = 0
+	}
+	message := fmt.Sprintf("budget group `%s` (%d members) has spent $%.2f of its $%.2f budget for %s; $%.2f remaining",
+		group.Name, len(group.Users), spent, group.MonthlyBudget, time.Now().UTC().Format("January 2006"), remaining)
+	if spent >= group.MonthlyBudget {
+		message += "\nthe budget is exhausted - further launches require an admin override token"
+	}
+	return message, nil
+}
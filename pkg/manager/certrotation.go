@@ -0,0 +1,256 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+)
+
+// Certificate-rotation bugs only show up when certs actually approach or
+// pass their validity window, which in real time means waiting months.
+// `time-skew` shifts the clock on every node of the caller's cluster by an
+// arbitrary offset using privileged debug pods, and `cert-rotation` applies
+// the canonical reproduction: jump far enough past the client certificate
+// lifetime to force a full rotation, then watch whether the cluster heals.
+// Both reuse the chaos machinery - one disruption per cluster at a time,
+// results through the chaos notifier - and `time-skew reset` re-enables NTP
+// so the nodes snap back to real time.
+
+const (
+	// timeSkewNamespace is where the privileged debug pods run; it is
+	// created for the operation and removed afterwards.
+	timeSkewNamespace = "ci-chat-bot-time-skew"
+	// timeSkewApplyTimeout bounds how long the debug pods may take to shift
+	// every node's clock.
+	timeSkewApplyTimeout = 10 * time.Minute
+	// maxTimeSkew caps the offset users may request in either direction.
+	maxTimeSkew = 400 * 24 * time.Hour
+	// certRotationSkew jumps past the one-year client certificate validity
+	// so the control plane must rotate everything at once.
+	certRotationSkew = 370 * 24 * time.Hour
+	// certRotationRecoveryTimeout is how long the cluster gets to finish the
+	// forced rotation and report all nodes Ready again.
+	certRotationRecoveryTimeout = 45 * time.Minute
+	// timeSkewDebugImage is the in-cluster tools imagestream `oc debug`
+	// uses; it is present on every launched cluster without an external pull.
+	timeSkewDebugImage = "image-registry.openshift-image-registry.svc:5000/openshift/tools:latest"
+)
+
+// parseTimeSkew reads a user-supplied clock offset such as `48h`, `-30m`, or
+// `370d`.
+func parseTimeSkew(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("the offset must be a duration such as `48h`, `-30m`, or `370d`")
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	skew, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("the offset must be a duration such as `48h`, `-30m`, or `370d`")
+	}
+	return skew, nil
+}
+
+// RunTimeSkew shifts the clock on every node of the caller's cluster by the
+// given offset, or re-enables NTP when the offset is `reset`.
+func (m *jobManager) RunTimeSkew(user, channel, offset string) (string, error) {
+	var command, what string
+	if offset == "reset" {
+		command = "timedatectl set-ntp true"
+		what = "re-enabling NTP so the nodes snap back to real time"
+	} else {
+		skew, err := parseTimeSkew(offset)
+		if err != nil {
+			return "", err
+		}
+		if skew == 0 {
+			return "", fmt.Errorf("the offset must be non-zero; use `time-skew reset` to undo an earlier skew")
+		}
+		if skew > maxTimeSkew || skew < -maxTimeSkew {
+			return "", fmt.Errorf("the offset may be at most `%dd` in either direction", int(maxTimeSkew.Hours()/24))
+		}
+		command = fmt.Sprintf("timedatectl set-ntp false && date -s '%+d seconds'", int(skew.Seconds()))
+		what = fmt.Sprintf("shifting every node's clock by `%s`", offset)
+	}
+
+	client, job, err := m.launchedClusterClient(user)
+	if err != nil {
+		return "", err
+	}
+	if err := m.claimChaosRun(job.Name, fmt.Sprintf("time-skew %s", offset)); err != nil {
+		return "", err
+	}
+
+	go func() {
+		defer m.releaseChaosRun(job.Name)
+		ctx, cancel := context.WithTimeout(context.Background(), timeSkewApplyTimeout)
+		defer cancel()
+		nodes, err := runOnEveryNode(ctx, client, command)
+		if err != nil {
+			m.notifyChaosResult(user, channel, fmt.Sprintf("the time skew against `%s` failed: %v", job.Name, err))
+			return
+		}
+		msg := fmt.Sprintf("applied the `%s` clock offset on all %d nodes of `%s`; use `time-skew reset` to re-enable NTP when you are done", offset, nodes, job.Name)
+		if offset == "reset" {
+			msg = fmt.Sprintf("re-enabled NTP on all %d nodes of `%s`; the clocks will converge back to real time", nodes, job.Name)
+		}
+		m.notifyChaosResult(user, channel, msg)
+	}()
+	return fmt.Sprintf("%s on `%s` using privileged debug pods - I will confirm here once it is applied", what, job.Name), nil
+}
+
+// RunCertRotation fast-forwards the caller's cluster past the client
+// certificate lifetime and reports whether the forced rotation healed.
+func (m *jobManager) RunCertRotation(user, channel string) (string, error) {
+	client, job, err := m.launchedClusterClient(user)
+	if err != nil {
+		return "", err
+	}
+	if err := m.claimChaosRun(job.Name, "cert-rotation"); err != nil {
+		return "", err
+	}
+
+	days := int(certRotationSkew.Hours() / 24)
+	go func() {
+		defer m.releaseChaosRun(job.Name)
+		ctx, cancel := context.WithTimeout(context.Background(), timeSkewApplyTimeout+certRotationRecoveryTimeout)
+		defer cancel()
+		command := fmt.Sprintf("timedatectl set-ntp false && date -s '+%d seconds'", int(certRotationSkew.Seconds()))
+		nodes, err := runOnEveryNode(ctx, client, command)
+		if err != nil {
+			m.notifyChaosResult(user, channel, fmt.Sprintf("the cert-rotation fast-forward against `%s` failed: %v", job.Name, err))
+			return
+		}
+		klog.Infof("Cert rotation forced on %s: clock moved %d days ahead on %d nodes", job.Name, days, nodes)
+		started := time.Now()
+		err = wait.PollUntilContextTimeout(ctx, chaosRecoveryPollInterval, certRotationRecoveryTimeout, false, func(ctx context.Context) (bool, error) {
+			// the API server is expected to be unreachable mid-rotation
+			healthy, err := nodesReady(ctx, client)
+			if err != nil {
+				return false, nil
+			}
+			return healthy, nil
+		})
+		if err != nil {
+			m.notifyChaosResult(user, channel, fmt.Sprintf("cert-rotation result for `%s`: moved the clock %d days ahead on %d nodes, but the cluster did NOT recover within %d minutes - you have likely reproduced a rotation bug; gather what you need and `done` the cluster, since `time-skew reset` cannot save it once certificates are wedged", job.Name, days, nodes, int(certRotationRecoveryTimeout.Minutes())))
+			return
+		}
+		m.notifyChaosResult(user, channel, fmt.Sprintf("cert-rotation result for `%s`: moved the clock %d days ahead on %d nodes and the cluster rotated its certificates and recovered in %s; run `time-skew reset` if you want the clocks back", job.Name, days, nodes, time.Since(started).Round(time.Second)))
+	}()
+	return fmt.Sprintf("fast-forwarding the clock on `%s` by %d days to force a full certificate rotation - I will report here whether the cluster recovers, giving up after %d minutes", job.Name, days, int(certRotationRecoveryTimeout.Minutes())), nil
+}
+
+// claimChaosRun marks a disruption as running against the named cluster so
+// only one runs at a time, sharing the registry with the chaos scenarios.
+func (m *jobManager) claimChaosRun(jobName, label string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.chaosRuns == nil {
+		m.chaosRuns = make(map[string]string)
+	}
+	if running, ok := m.chaosRuns[jobName]; ok {
+		return fmt.Errorf("`%s` is still running against your cluster; wait for its result before starting another disruption", running)
+	}
+	m.chaosRuns[jobName] = label
+	return nil
+}
+
+// releaseChaosRun clears the running-disruption mark for the named cluster.
+func (m *jobManager) releaseChaosRun(jobName string) {
+	m.lock.Lock()
+	delete(m.chaosRuns, jobName)
+	m.lock.Unlock()
+}
+
+// runOnEveryNode runs a host command on each node of the cluster through
+// privileged debug pods in a throwaway namespace, returning how many nodes
+// ran it. All pods must succeed; failures name the node so the user can
+// inspect it.
+func runOnEveryNode(ctx context.Context, client kubernetes.Interface, command string) (int, error) {
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: timeSkewNamespace,
+			Labels: map[string]string{
+				// the pods need host access, so the namespace must opt out
+				// of the restricted pod security profile
+				"pod-security.kubernetes.io/enforce": "privileged",
+			},
+		},
+	}
+	if _, err := client.CoreV1().Namespaces().Create(ctx, namespace, metav1.CreateOptions{}); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return 0, fmt.Errorf("unable to create the `%s` namespace: %v", timeSkewNamespace, err)
+	}
+	defer func() {
+		if err := client.CoreV1().Namespaces().Delete(context.Background(), timeSkewNamespace, metav1.DeleteOptions{}); err != nil {
+			klog.Warningf("Failed to clean up the %s namespace: %v", timeSkewNamespace, err)
+		}
+	}()
+
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("unable to list the cluster's nodes: %v", err)
+	}
+	if len(nodes.Items) == 0 {
+		return 0, fmt.Errorf("the cluster reports no nodes")
+	}
+	privileged := true
+	for i, node := range nodes.Items {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("debug-%d", i)},
+			Spec: corev1.PodSpec{
+				NodeName:      node.Name,
+				RestartPolicy: corev1.RestartPolicyNever,
+				HostPID:       true,
+				Tolerations:   []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+				Containers: []corev1.Container{
+					{
+						Name:            "debug",
+						Image:           timeSkewDebugImage,
+						Command:         []string{"chroot", "/host", "/bin/sh", "-c", command},
+						SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+						VolumeMounts:    []corev1.VolumeMount{{Name: "host", MountPath: "/host"}},
+					},
+				},
+				Volumes: []corev1.Volume{
+					{Name: "host", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/"}}},
+				},
+			},
+		}
+		if _, err := client.CoreV1().Pods(timeSkewNamespace).Create(ctx, pod, metav1.CreateOptions{}); err != nil {
+			return 0, fmt.Errorf("unable to create the debug pod for node `%s`: %v", node.Name, err)
+		}
+	}
+
+	err = wait.PollUntilContextTimeout(ctx, 10*time.Second, timeSkewApplyTimeout, false, func(ctx context.Context) (bool, error) {
+		pods, err := client.CoreV1().Pods(timeSkewNamespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return false, nil
+		}
+		for _, pod := range pods.Items {
+			switch pod.Status.Phase {
+			case corev1.PodFailed:
+				node := pod.Spec.NodeName
+				return false, fmt.Errorf("the debug pod on node `%s` failed; the node may be unreachable", node)
+			case corev1.PodSucceeded:
+			default:
+				return false, nil
+			}
+		}
+		return len(pods.Items) == len(nodes.Items), nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(nodes.Items), nil
+}
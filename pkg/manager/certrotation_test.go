@@ -0,0 +1,38 @@
+package manager
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimeSkew(t *testing.T) {
+	testCases := []struct {
+		value     string
+		expected  time.Duration
+		expectErr bool
+	}{
+		{value: "48h", expected: 48 * time.Hour},
+		{value: "-30m", expected: -30 * time.Minute},
+		{value: "370d", expected: 370 * 24 * time.Hour},
+		{value: "-2d", expected: -48 * time.Hour},
+		{value: "tomorrow", expectErr: true},
+		{value: "1.5d", expectErr: true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.value, func(t *testing.T) {
+			actual, err := parseTimeSkew(testCase.value)
+			if testCase.expectErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %s", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,318 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+)
+
+// Resilience QE used to run disruption scenarios against their clusters by
+// hand - finding an etcd pod to kill, draining a node, wiring up a network
+// partition - and eyeball whether the cluster came back. `chaos <scenario>`
+// runs a predefined disruption against the caller's launched cluster using
+// its admin credentials and reports how long recovery took, or that it did
+// not recover within the timeout. Scenarios only touch the caller's own
+// cluster, one runs at a time per cluster, and each one is chosen to be
+// recoverable by the platform without operator intervention.
+
+const (
+	// chaosRecoveryTimeout bounds how long a scenario waits for the cluster
+	// to recover before reporting a failure.
+	chaosRecoveryTimeout = 15 * time.Minute
+	// chaosRecoveryPollInterval is how often recovery checks re-run.
+	chaosRecoveryPollInterval = 15 * time.Second
+	// chaosPartitionDuration is how long the network-partition scenario
+	// leaves its deny-all policy in place before lifting it.
+	chaosPartitionDuration = 2 * time.Minute
+)
+
+// chaosScenario pairs a disruption with the recovery condition that decides
+// whether the cluster healed from it.
+type chaosScenario struct {
+	description string
+	// disrupt performs the disruption and returns a short summary of what
+	// was done, e.g. which pod or node was targeted.
+	disrupt func(ctx context.Context, client kubernetes.Interface) (string, error)
+	// recovered reports whether the cluster has healed; it is polled until
+	// chaosRecoveryTimeout.
+	recovered func(ctx context.Context, client kubernetes.Interface) (bool, error)
+}
+
+// chaosScenarios are the predefined disruptions `chaos` can run.
+var chaosScenarios = map[string]chaosScenario{
+	"etcd-member-kill": {
+		description: "delete one etcd pod and wait for the member to rejoin",
+		disrupt:     disruptEtcdMember,
+		recovered:   etcdMembersHealthy,
+	},
+	"node-reboot": {
+		description: "delete a worker node's API object so the kubelet re-registers it, simulating a reboot",
+		disrupt:     disruptWorkerNode,
+		recovered:   nodesReady,
+	},
+	"network-partition": {
+		description: fmt.Sprintf("isolate the console with a deny-all network policy for %d minutes, then lift it", int(chaosPartitionDuration.Minutes())),
+		disrupt:     disruptConsoleNetwork,
+		recovered:   consoleAvailable,
+	},
+}
+
+// SupportedChaosScenarios lists the scenario names for help text, in a stable
+// order.
+func SupportedChaosScenarios() []string {
+	names := make([]string, 0, len(chaosScenarios))
+	for name := range chaosScenarios {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ChaosScenarioDescriptions renders the scenario list with what each one
+// does.
+func ChaosScenarioDescriptions() string {
+	buf := &strings.Builder{}
+	for _, name := range SupportedChaosScenarios() {
+		fmt.Fprintf(buf, "• `%s` - %s\n", name, chaosScenarios[name].description)
+	}
+	return buf.String()
+}
+
+// SetChaosNotifier registers the callback that reports a chaos scenario's
+// recovery result back to the caller.
+func (m *jobManager) SetChaosNotifier(fn ChaosCallbackFunc) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.chaosNotifierFn = fn
+}
+
+// RunChaosScenario starts the named disruption against the caller's launched
+// cluster and reports the recovery result asynchronously through the chaos
+// notifier.
+func (m *jobManager) RunChaosScenario(user, channel, scenario string) (string, error) {
+	spec, ok := chaosScenarios[scenario]
+	if !ok {
+		return "", fmt.Errorf("unknown chaos scenario `%s`, choose from: %s", scenario, strings.Join(SupportedChaosScenarios(), ", "))
+	}
+	client, job, err := m.launchedClusterClient(user)
+	if err != nil {
+		return "", err
+	}
+
+	if err := m.claimChaosRun(job.Name, scenario); err != nil {
+		return "", err
+	}
+
+	go m.runChaosScenario(job.Name, user, channel, scenario, spec, client)
+	return fmt.Sprintf("running the `%s` scenario against `%s` - %s. I will report the recovery result here, and give up after %d minutes", scenario, job.Name, spec.description, int(chaosRecoveryTimeout.Minutes())), nil
+}
+
+// launchedClusterClient builds a client for the caller's launched cluster
+// from the credentials the bot delivered.
+func (m *jobManager) launchedClusterClient(user string) (kubernetes.Interface, *Job, error) {
+	job, err := m.GetLaunchJob(user)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(job.Credentials) == 0 {
+		return nil, nil, fmt.Errorf("the cluster is not ready yet; try again once you have received your credentials")
+	}
+	cfg, err := loadKubeconfigContents(job.Credentials)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to load the cluster's credentials: %v", err)
+	}
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to connect to the cluster: %v", err)
+	}
+	return client, job, nil
+}
+
+// runChaosScenario performs the disruption, polls for recovery, and delivers
+// the result.
+func (m *jobManager) runChaosScenario(jobName, user, channel, scenario string, spec chaosScenario, client kubernetes.Interface) {
+	defer m.releaseChaosRun(jobName)
+
+	ctx, cancel := context.WithTimeout(context.Background(), chaosRecoveryTimeout+chaosPartitionDuration)
+	defer cancel()
+
+	summary, err := spec.disrupt(ctx, client)
+	if err != nil {
+		klog.Warningf("Chaos scenario %s against %s failed to disrupt: %v", scenario, jobName, err)
+		m.notifyChaosResult(user, channel, fmt.Sprintf("the `%s` scenario could not disrupt your cluster: %v", scenario, err))
+		return
+	}
+	klog.Infof("Chaos scenario %s disrupted %s: %s", scenario, jobName, summary)
+
+	started := time.Now()
+	err = wait.PollUntilContextTimeout(ctx, chaosRecoveryPollInterval, chaosRecoveryTimeout, false, func(ctx context.Context) (bool, error) {
+		healthy, err := spec.recovered(ctx, client)
+		if err != nil {
+			// transient API errors are expected mid-disruption
+			klog.V(2).Infof("Chaos recovery check for %s on %s: %v", scenario, jobName, err)
+			return false, nil
+		}
+		return healthy, nil
+	})
+	if err != nil {
+		m.notifyChaosResult(user, channel, fmt.Sprintf("`%s` result for `%s`: %s - the cluster did NOT recover within %d minutes; it may need manual attention or a `done` and relaunch", scenario, jobName, summary, int(chaosRecoveryTimeout.Minutes())))
+		return
+	}
+	m.notifyChaosResult(user, channel, fmt.Sprintf("`%s` result for `%s`: %s - the cluster recovered in %s", scenario, jobName, summary, time.Since(started).Round(time.Second)))
+}
+
+// notifyChaosResult delivers a scenario result to the requesting user, or
+// logs it when no notifier is wired up.
+func (m *jobManager) notifyChaosResult(user, channel, message string) {
+	m.lock.Lock()
+	notifier := m.chaosNotifierFn
+	m.lock.Unlock()
+	if notifier == nil {
+		klog.Infof("no chaos notifier configured, dropping message for %q: %s", user, message)
+		return
+	}
+	notifier(user, channel, message)
+}
+
+// disruptEtcdMember deletes one etcd pod so the member restarts and has to
+// rejoin the cluster.
+func disruptEtcdMember(ctx context.Context, client kubernetes.Interface) (string, error) {
+	pods, err := client.CoreV1().Pods("openshift-etcd").List(ctx, metav1.ListOptions{LabelSelector: "app=etcd"})
+	if err != nil {
+		return "", fmt.Errorf("unable to list etcd pods: %v", err)
+	}
+	if len(pods.Items) == 0 {
+		return "", fmt.Errorf("no etcd pods found in `openshift-etcd`")
+	}
+	target := pods.Items[0].Name
+	if err := client.CoreV1().Pods("openshift-etcd").Delete(ctx, target, metav1.DeleteOptions{}); err != nil {
+		return "", fmt.Errorf("unable to delete etcd pod %s: %v", target, err)
+	}
+	return fmt.Sprintf("killed etcd member pod `%s`", target), nil
+}
+
+// etcdMembersHealthy reports whether every etcd pod is running and ready.
+func etcdMembersHealthy(ctx context.Context, client kubernetes.Interface) (bool, error) {
+	pods, err := client.CoreV1().Pods("openshift-etcd").List(ctx, metav1.ListOptions{LabelSelector: "app=etcd"})
+	if err != nil {
+		return false, err
+	}
+	if len(pods.Items) == 0 {
+		return false, nil
+	}
+	for _, pod := range pods.Items {
+		if !podReady(&pod) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// disruptWorkerNode deletes one worker node's API object; the kubelet's next
+// heartbeat re-registers it, which exercises the same re-admission path as a
+// reboot without touching the cloud provider.
+func disruptWorkerNode(ctx context.Context, client kubernetes.Interface) (string, error) {
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: "node-role.kubernetes.io/worker"})
+	if err != nil {
+		return "", fmt.Errorf("unable to list worker nodes: %v", err)
+	}
+	if len(nodes.Items) == 0 {
+		return "", fmt.Errorf("the cluster has no worker nodes to disrupt")
+	}
+	target := nodes.Items[0].Name
+	if err := client.CoreV1().Nodes().Delete(ctx, target, metav1.DeleteOptions{}); err != nil {
+		return "", fmt.Errorf("unable to delete node %s: %v", target, err)
+	}
+	return fmt.Sprintf("deleted worker node `%s` so its kubelet must re-register", target), nil
+}
+
+// nodesReady reports whether every node has re-registered and is Ready.
+func nodesReady(ctx context.Context, client kubernetes.Interface) (bool, error) {
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return false, err
+	}
+	for _, node := range nodes.Items {
+		ready := false
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+				ready = true
+				break
+			}
+		}
+		if !ready {
+			return false, nil
+		}
+	}
+	return len(nodes.Items) > 0, nil
+}
+
+// chaosPartitionPolicyName identifies the network policy the partition
+// scenario installs so it can be removed and recognized afterwards.
+const chaosPartitionPolicyName = "ci-chat-bot-chaos-partition"
+
+// disruptConsoleNetwork drops all ingress to the console pods with a
+// deny-all network policy, holds the partition, and then lifts it.
+func disruptConsoleNetwork(ctx context.Context, client kubernetes.Interface) (string, error) {
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: chaosPartitionPolicyName},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+	if _, err := client.NetworkingV1().NetworkPolicies("openshift-console").Create(ctx, policy, metav1.CreateOptions{}); err != nil {
+		return "", fmt.Errorf("unable to create the partition policy: %v", err)
+	}
+	select {
+	case <-time.After(chaosPartitionDuration):
+	case <-ctx.Done():
+	}
+	if err := client.NetworkingV1().NetworkPolicies("openshift-console").Delete(ctx, chaosPartitionPolicyName, metav1.DeleteOptions{}); err != nil {
+		return "", fmt.Errorf("unable to lift the partition policy; remove the `%s` network policy in `openshift-console` by hand: %v", chaosPartitionPolicyName, err)
+	}
+	return fmt.Sprintf("partitioned the console for %d minutes and lifted the policy", int(chaosPartitionDuration.Minutes())), nil
+}
+
+// consoleAvailable reports whether the console pods are ready again with the
+// partition policy gone.
+func consoleAvailable(ctx context.Context, client kubernetes.Interface) (bool, error) {
+	if _, err := client.NetworkingV1().NetworkPolicies("openshift-console").Get(ctx, chaosPartitionPolicyName, metav1.GetOptions{}); err == nil {
+		return false, nil
+	}
+	pods, err := client.CoreV1().Pods("openshift-console").List(ctx, metav1.ListOptions{LabelSelector: "app=console"})
+	if err != nil {
+		return false, err
+	}
+	if len(pods.Items) == 0 {
+		return false, nil
+	}
+	for _, pod := range pods.Items {
+		if !podReady(&pod) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// podReady reports whether the pod is running with a True Ready condition.
+func podReady(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,192 @@
+package manager
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog"
+)
+
+// reProwGCSPath extracts the bucket and object path from a deck spyglass link,
+// e.g. https://prow.ci.openshift.org/view/gs/<bucket>/<path>
+var reProwGCSPath = regexp.MustCompile(`/view/gs/([^/]+)/(.+)$`)
+
+// reJunitFile matches the junit output files produced by the e2e test steps
+var reJunitFile = regexp.MustCompile(`junit[^/]*\.xml$`)
+
+// junitTestSuite is the subset of the junit schema needed to classify test results
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name    string    `xml:"name,attr"`
+	Failure *struct{} `xml:"failure"`
+	Skipped *struct{} `xml:"skipped"`
+}
+
+// jobRunResults aggregates the junit results for a single job run. A test that
+// both failed and passed within the same run is considered a flake.
+type jobRunResults struct {
+	passed sets.Set[string]
+	failed sets.Set[string]
+}
+
+func (r *jobRunResults) flakes() sets.Set[string] {
+	return r.passed.Intersection(r.failed)
+}
+
+// CompareJobRuns diffs the junit results of two completed bot-triggered test
+// jobs and summarizes new failures, fixed tests, and flakes.
+func (m *jobManager) CompareJobRuns(jobA, jobB string) (string, error) {
+	urlA, err := m.urlForJobRun(jobA)
+	if err != nil {
+		return "", err
+	}
+	urlB, err := m.urlForJobRun(jobB)
+	if err != nil {
+		return "", err
+	}
+	resultsA, err := junitResultsForJobRun(urlA)
+	if err != nil {
+		return "", fmt.Errorf("unable to read junit results for %s: %v", jobA, err)
+	}
+	resultsB, err := junitResultsForJobRun(urlB)
+	if err != nil {
+		return "", fmt.Errorf("unable to read junit results for %s: %v", jobB, err)
+	}
+
+	newFailures := resultsB.failed.Difference(resultsB.passed).Intersection(resultsA.passed.Difference(resultsA.failed))
+	fixed := resultsA.failed.Difference(resultsA.passed).Intersection(resultsB.passed.Difference(resultsB.failed))
+	flakes := resultsA.flakes().Union(resultsB.flakes())
+
+	buf := &strings.Builder{}
+	fmt.Fprintf(buf, "compared <%s|%s> (%d tests) to <%s|%s> (%d tests):\n", urlA, jobA, resultsA.passed.Union(resultsA.failed).Len(), urlB, jobB, resultsB.passed.Union(resultsB.failed).Len())
+	writeTestSection(buf, "New failures (passed in the first run, failed in the second)", newFailures)
+	writeTestSection(buf, "Fixed (failed in the first run, passed in the second)", fixed)
+	writeTestSection(buf, "Flakes (both passed and failed within a single run)", flakes)
+	return buf.String(), nil
+}
+
+func writeTestSection(buf *strings.Builder, title string, tests sets.Set[string]) {
+	if tests.Len() == 0 {
+		fmt.Fprintf(buf, "\n*%s*: none\n", title)
+		return
+	}
+	fmt.Fprintf(buf, "\n*%s* (%d):\n", title, tests.Len())
+	names := sets.List(tests)
+	sort.Strings(names)
+	const maxListed = 20
+	for i, name := range names {
+		if i == maxListed {
+			fmt.Fprintf(buf, "• ... and %d more\n", len(names)-maxListed)
+			break
+		}
+		fmt.Fprintf(buf, "• %s\n", name)
+	}
+}
+
+// urlForJobRun finds the deck URL for a job the bot launched by prow job name
+func (m *jobManager) urlForJobRun(name string) (string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	job, ok := m.jobs[name]
+	if !ok {
+		return "", fmt.Errorf("no bot-triggered job named `%s` was found - use `list` to see the jobs the bot is tracking", name)
+	}
+	if len(job.URL) == 0 {
+		return "", fmt.Errorf("job `%s` has no results URL yet", name)
+	}
+	return job.URL, nil
+}
+
+// junitResultsForJobRun locates and parses all junit files in the GCS artifacts
+// of the job run behind the provided deck URL.
+func junitResultsForJobRun(jobURL string) (*jobRunResults, error) {
+	matches := reProwGCSPath.FindStringSubmatch(jobURL)
+	if matches == nil {
+		return nil, fmt.Errorf("job URL %s does not reference a GCS artifacts path", jobURL)
+	}
+	bucket, path := matches[1], matches[2]
+
+	listURL := fmt.Sprintf("https://storage.googleapis.com/storage/v1/b/%s/o?prefix=%s&fields=items(name)", bucket, url.QueryEscape(path+"/"))
+	var listing struct {
+		Items []struct {
+			Name string `json:"name"`
+		} `json:"items"`
+	}
+	if err := getJSON(listURL, &listing); err != nil {
+		return nil, fmt.Errorf("failed to list artifacts: %v", err)
+	}
+
+	results := &jobRunResults{passed: sets.New[string](), failed: sets.New[string]()}
+	var found bool
+	for _, item := range listing.Items {
+		if !reJunitFile.MatchString(item.Name) {
+			continue
+		}
+		found = true
+		fileURL := fmt.Sprintf("https://storage.googleapis.com/%s/%s", bucket, item.Name)
+		if err := mergeJunitFile(fileURL, results); err != nil {
+			klog.Warningf("Failed to read junit file %s: %v", fileURL, err)
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("no junit files were found in the job artifacts - has the job finished running its tests?")
+	}
+	return results, nil
+}
+
+func mergeJunitFile(fileURL string, results *jobRunResults) error {
+	resp, err := http.Get(fileURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	suite := &junitTestSuite{}
+	if err := xml.Unmarshal(data, suite); err != nil {
+		return err
+	}
+	for _, testCase := range suite.TestCases {
+		switch {
+		case testCase.Skipped != nil:
+		case testCase.Failure != nil:
+			results.failed.Insert(testCase.Name)
+		default:
+			results.passed.Insert(testCase.Name)
+		}
+	}
+	return nil
+}
+
+func getJSON(url string, into interface{}) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, into)
+}
@@ -0,0 +1,255 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog"
+	prowapiv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+// Before signing off on a change, users regularly want a conformance run
+// against the cluster they already have - with the exact PRs, feature gates,
+// and install-config tweaks it was launched with - rather than launching a
+// second cluster through `test`. `conformance` runs the suite as a job on the
+// build cluster that hosts the user's launch, pointed at the running cluster
+// through its admin kubeconfig, and reports the pass/fail outcome with a link
+// to the job page where the full output and the results tarball land.
+
+const (
+	// conformanceTimeout bounds how long a conformance run may take before
+	// it is reported as timed out; the full parallel suite normally finishes
+	// well inside this.
+	conformanceTimeout = 3 * time.Hour
+	// conformancePollInterval is how often the run's state is re-checked.
+	conformancePollInterval = time.Minute
+)
+
+// conformanceSuites maps the user-facing suite names to the openshift-tests
+// suite identifiers they run. The empty key is the default.
+var conformanceSuites = map[string]string{
+	"":           "openshift/conformance/parallel",
+	"openshift":  "openshift/conformance/parallel",
+	"kubernetes": "kubernetes/conformance",
+	"serial":     "openshift/conformance/serial",
+}
+
+// SupportedConformanceSuites lists the selectable suite names for help text.
+func SupportedConformanceSuites() []string {
+	names := make([]string, 0, len(conformanceSuites))
+	for name := range conformanceSuites {
+		if len(name) > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetConformanceNotifier registers the callback that reports a conformance
+// run's outcome back to the caller.
+func (m *jobManager) SetConformanceNotifier(fn ConformanceCallbackFunc) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.conformanceNotifierFn = fn
+}
+
+// SetConformanceImage configures the pull spec of the image holding the
+// openshift-tests binary; empty disables the conformance command.
+func (m *jobManager) SetConformanceImage(image string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.conformanceImage = image
+}
+
+// RunConformance starts the named conformance suite against the caller's
+// launched cluster and reports the outcome asynchronously through the
+// conformance notifier.
+func (m *jobManager) RunConformance(user, channel, suite string) (string, error) {
+	target, ok := conformanceSuites[suite]
+	if !ok {
+		return "", fmt.Errorf("unknown conformance suite `%s`, choose from: %s", suite, strings.Join(SupportedConformanceSuites(), ", "))
+	}
+	m.lock.Lock()
+	image := m.conformanceImage
+	m.lock.Unlock()
+	if len(image) == 0 {
+		return "", fmt.Errorf("the administrators have not configured a conformance tests image (`--conformance-tests-image`)")
+	}
+	job, err := m.GetLaunchJob(user)
+	if err != nil {
+		return "", err
+	}
+	if len(job.Credentials) == 0 {
+		return "", fmt.Errorf("the cluster is not ready yet; try again once you have received your credentials")
+	}
+	if len(job.BuildCluster) == 0 {
+		return "", fmt.Errorf("unable to determine which build cluster hosts your job; try again in a few minutes")
+	}
+
+	name := fmt.Sprintf("%s-conformance", job.Name)
+	m.lock.Lock()
+	if m.conformanceRuns == nil {
+		m.conformanceRuns = make(map[string]string)
+	}
+	if running, ok := m.conformanceRuns[job.Name]; ok {
+		m.lock.Unlock()
+		return "", fmt.Errorf("a `%s` conformance run is already in progress against your cluster; wait for its result before starting another", running)
+	}
+	m.conformanceRuns[job.Name] = target
+	m.lock.Unlock()
+
+	pj := conformanceProwJob(name, job, image, target)
+	url, err := m.executor.Submit(context.TODO(), pj)
+	if err != nil {
+		m.lock.Lock()
+		delete(m.conformanceRuns, job.Name)
+		m.lock.Unlock()
+		return "", fmt.Errorf("unable to start the conformance run: %v", err)
+	}
+
+	go m.waitForConformance(name, job.Name, job.BuildCluster, user, channel, target, url)
+	msg := fmt.Sprintf("running the `%s` suite against `%s` - I will report the pass/fail result here, and give up after %d hours", target, job.Name, int(conformanceTimeout.Hours()))
+	if len(url) > 0 {
+		msg += fmt.Sprintf("; follow along at %s", url)
+	}
+	return msg, nil
+}
+
+// conformanceProwJob prepares the job that runs openshift-tests against the
+// launched cluster. The admin kubeconfig travels in the pod environment and
+// is written to disk before the suite starts, which keeps the job independent
+// of any secret plumbing on the build cluster. The job deliberately does not
+// carry the launch label, so the sync loop never mistakes it for a cluster.
+func conformanceProwJob(name string, job *Job, image, target string) *prowapiv1.ProwJob {
+	script := fmt.Sprintf(`printf '%%s' "${CLUSTER_KUBECONFIG}" > /tmp/kubeconfig
+export KUBECONFIG=/tmp/kubeconfig
+openshift-tests run %s --junit-dir "${ARTIFACT_DIR:-/tmp/artifacts}"`, target)
+	return &prowapiv1.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				"prow.k8s.io/type": string(prowapiv1.PeriodicJob),
+				"prow.k8s.io/job":  name,
+			},
+			Annotations: map[string]string{
+				"prow.k8s.io/job":                         name,
+				"ci-chat-bot.openshift.io/conformanceFor": job.Name,
+			},
+		},
+		Spec: prowapiv1.ProwJobSpec{
+			Type:    prowapiv1.PeriodicJob,
+			Agent:   prowapiv1.KubernetesAgent,
+			Job:     name,
+			Cluster: job.BuildCluster,
+			Report:  false,
+			PodSpec: &corev1.PodSpec{
+				RestartPolicy: corev1.RestartPolicyNever,
+				Containers: []corev1.Container{
+					{
+						Name:    "conformance",
+						Image:   image,
+						Command: []string{"/bin/bash", "-c", script},
+						Env: []corev1.EnvVar{
+							{Name: "CLUSTER_KUBECONFIG", Value: job.Credentials},
+						},
+					},
+				},
+			},
+		},
+		Status: prowapiv1.ProwJobStatus{
+			State:     prowapiv1.TriggeredState,
+			StartTime: metav1.Now(),
+		},
+	}
+}
+
+// waitForConformance polls the run to completion and delivers the outcome.
+func (m *jobManager) waitForConformance(name, jobName, cluster, user, channel, target, url string) {
+	defer func() {
+		m.lock.Lock()
+		delete(m.conformanceRuns, jobName)
+		m.lock.Unlock()
+	}()
+
+	started := time.Now()
+	var state prowapiv1.ProwJobState
+	err := wait.PollUntilContextTimeout(context.TODO(), conformancePollInterval, conformanceTimeout, false, func(ctx context.Context) (bool, error) {
+		pj, err := m.executor.Get(ctx, name, cluster)
+		if err != nil {
+			klog.V(2).Infof("Conformance run %s: unable to check state: %v", name, err)
+			return false, nil
+		}
+		state = pj.Status.State
+		if len(pj.Status.URL) > 0 {
+			url = pj.Status.URL
+		}
+		return pj.Status.State == prowapiv1.SuccessState || pj.Status.State == prowapiv1.FailureState || pj.Status.State == prowapiv1.AbortedState || pj.Status.State == prowapiv1.ErrorState, nil
+	})
+	duration := time.Since(started).Round(time.Minute)
+
+	link := "the job page"
+	if len(url) > 0 {
+		link = fmt.Sprintf("<%s|the job page>", url)
+	}
+	var message string
+	switch {
+	case err != nil:
+		message = fmt.Sprintf("the `%s` run against `%s` did not finish within %d hours; check %s and abort or retry", target, jobName, int(conformanceTimeout.Hours()), link)
+	case state == prowapiv1.SuccessState:
+		message = fmt.Sprintf("the `%s` run against `%s` PASSED in %s - the full output and results tarball are on %s", target, jobName, duration, link)
+	case state == prowapiv1.FailureState:
+		message = fmt.Sprintf("the `%s` run against `%s` FAILED after %s - the failing tests, full output, and results tarball are on %s", target, jobName, duration, link)
+	default:
+		message = fmt.Sprintf("the `%s` run against `%s` ended in state `%s` after %s; see %s", target, jobName, state, duration, link)
+	}
+	if summary := m.conformanceLogSummary(name, cluster); len(summary) > 0 {
+		message += fmt.Sprintf("\n> %s", summary)
+	}
+	m.notifyConformanceResult(user, channel, message)
+}
+
+// conformanceLogSummary pulls the suite's closing pass/fail line out of the
+// pod log, best effort; an empty string means no summary could be read.
+func (m *jobManager) conformanceLogSummary(name, cluster string) string {
+	clusterClient, ok := m.clusterClients.Get(cluster)
+	if !ok {
+		return ""
+	}
+	pods, err := clusterClient.CoreClient.CoreV1().Pods(m.prowNamespace).List(context.TODO(), metav1.ListOptions{LabelSelector: fmt.Sprintf("prow.k8s.io/job=%s", name)})
+	if err != nil || len(pods.Items) == 0 {
+		return ""
+	}
+	tail := int64(30)
+	raw, err := clusterClient.CoreClient.CoreV1().Pods(m.prowNamespace).GetLogs(pods.Items[0].Name, &corev1.PodLogOptions{TailLines: &tail}).DoRaw(context.TODO())
+	if err != nil {
+		return ""
+	}
+	lines := strings.Split(strings.TrimSpace(string(raw)), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if strings.Contains(line, "pass") && (strings.Contains(line, "fail") || strings.Contains(line, "skip")) {
+			return line
+		}
+	}
+	return ""
+}
+
+// notifyConformanceResult delivers a run outcome to the requesting user, or
+// logs it when no notifier is wired up.
+func (m *jobManager) notifyConformanceResult(user, channel, message string) {
+	m.lock.Lock()
+	notifier := m.conformanceNotifierFn
+	m.lock.Unlock()
+	if notifier == nil {
+		klog.Infof("no conformance notifier configured, dropping message for %q: %s", user, message)
+		return
+	}
+	notifier(user, channel, message)
+}
@@ -0,0 +1,167 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+)
+
+// Pipelines that consume bot-launched clusters cannot scrape kubeconfigs out
+// of Slack messages, so `auth to <destination>` writes the credentials to a
+// machine-readable location instead: a Kubernetes Secret in a namespace the
+// administrators designated, or a Vault path under an allowed prefix. Both
+// backends are opt-in and configured at startup.
+
+// CredentialDeliveryConfig describes where DeliverCredentials may write.
+type CredentialDeliveryConfig struct {
+	// SecretNamespace is the namespace on the job's build cluster that
+	// `secret:<name>` destinations write into. Empty disables secret
+	// delivery.
+	SecretNamespace string
+	// VaultAddr is the base URL of the Vault server `vault:<path>`
+	// destinations write to. Empty disables vault delivery.
+	VaultAddr string
+	// VaultToken authenticates the bot against Vault.
+	VaultToken string
+	// VaultPathPrefix is the prefix every user-supplied path must carry, so
+	// users can only write where the token is meant to be used.
+	VaultPathPrefix string
+}
+
+var secretNameRe = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// SetCredentialDelivery configures the destinations `auth to` may write to.
+func (m *jobManager) SetCredentialDelivery(cfg CredentialDeliveryConfig) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.credentialDelivery = cfg
+}
+
+// DeliverCredentials writes the user's cluster kubeconfig to the requested
+// destination - `secret:<name>` or `vault:<path>` - instead of Slack.
+func (m *jobManager) DeliverCredentials(user, destination string) (string, error) {
+	m.lock.Lock()
+	cfg := m.credentialDelivery
+	m.lock.Unlock()
+
+	job, err := m.GetLaunchJob(user)
+	if err != nil {
+		return "", err
+	}
+	if len(job.Credentials) == 0 {
+		return "", fmt.Errorf("the cluster is not ready yet; try again once you have received your credentials")
+	}
+
+	switch {
+	case strings.HasPrefix(destination, "secret:"):
+		name := strings.TrimPrefix(destination, "secret:")
+		if len(cfg.SecretNamespace) == 0 {
+			return "", fmt.Errorf("the administrators have not configured secret delivery")
+		}
+		if !secretNameRe.MatchString(name) {
+			return "", fmt.Errorf("`%s` is not a valid secret name", name)
+		}
+		return m.deliverToSecret(job, cfg.SecretNamespace, name)
+	case strings.HasPrefix(destination, "vault:"):
+		path := strings.Trim(strings.TrimPrefix(destination, "vault:"), "/")
+		if len(cfg.VaultAddr) == 0 {
+			return "", fmt.Errorf("the administrators have not configured vault delivery")
+		}
+		if len(path) == 0 || !strings.HasPrefix(path, cfg.VaultPathPrefix) {
+			return "", fmt.Errorf("the vault path must start with `%s`", cfg.VaultPathPrefix)
+		}
+		return m.deliverToVault(job, cfg, path)
+	default:
+		return "", fmt.Errorf("the destination must be `secret:<name>` or `vault:<path>`")
+	}
+}
+
+// deliverToSecret writes the credentials as a Secret on the job's build
+// cluster, replacing any earlier delivery to the same name.
+func (m *jobManager) deliverToSecret(job *Job, namespace, name string) (string, error) {
+	clusterClient, err := getClusterClient(m, job)
+	if err != nil {
+		return "", err
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{"ci-chat-bot.openshift.io/credentials": "true"},
+			Annotations: map[string]string{
+				"ci-chat-bot.openshift.io/user": job.RequestedBy,
+				"ci-chat-bot.openshift.io/job":  job.Name,
+			},
+		},
+		StringData: map[string]string{
+			"kubeconfig": job.Credentials,
+			"console":    job.PasswordSnippet,
+		},
+	}
+	client := clusterClient.CoreClient.CoreV1().Secrets(namespace)
+	if _, err := client.Create(context.TODO(), secret, metav1.CreateOptions{}); err != nil {
+		if !k8serrors.IsAlreadyExists(err) {
+			return "", fmt.Errorf("unable to write secret %s/%s: %v", namespace, name, err)
+		}
+		existing, err := client.Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("unable to update secret %s/%s: %v", namespace, name, err)
+		}
+		if existing.Labels["ci-chat-bot.openshift.io/credentials"] != "true" {
+			return "", fmt.Errorf("secret %s/%s exists and was not created by the bot; pick another name", namespace, name)
+		}
+		existing.Annotations = secret.Annotations
+		existing.Data = nil
+		existing.StringData = secret.StringData
+		if _, err := client.Update(context.TODO(), existing, metav1.UpdateOptions{}); err != nil {
+			return "", fmt.Errorf("unable to update secret %s/%s: %v", namespace, name, err)
+		}
+	}
+	klog.Infof("Delivered credentials for job %q to secret %s/%s on %s", job.Name, namespace, name, job.BuildCluster)
+	return fmt.Sprintf("wrote your kubeconfig to secret `%s/%s` on build cluster `%s`; it is not deleted when your cluster expires", namespace, name, job.BuildCluster), nil
+}
+
+// deliverToVault writes the credentials to a Vault KV path using the bot's
+// token. The payload is wrapped in "data" so it works with KV v2 mounts.
+func (m *jobManager) deliverToVault(job *Job, cfg CredentialDeliveryConfig, path string) (string, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"data": map[string]string{
+			"kubeconfig": job.Credentials,
+			"console":    job.PasswordSnippet,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/v1/%s", strings.TrimSuffix(cfg.VaultAddr, "/"), path), bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", cfg.VaultToken)
+	req.Header.Set("Content-Type", "application/json")
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("unable to write to vault: %v", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("vault returned %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	klog.Infof("Delivered credentials for job %q to vault path %s", job.Name, path)
+	return fmt.Sprintf("wrote your kubeconfig to vault path `%s`; it is not deleted when your cluster expires", path), nil
+}
@@ -0,0 +1,120 @@
+package manager
+
+import (
+	"sort"
+	"time"
+)
+
+// The types below are a point-in-time snapshot of the manager's state rendered
+// by the operator dashboard in pkg/dashboard. They are plain data so the
+// dashboard never reaches into the manager's locked structures itself.
+
+// maxRecentFailures bounds the failure history kept for the dashboard.
+const maxRecentFailures = 20
+
+// DashboardQueued is a launch parked on platform quota.
+type DashboardQueued struct {
+	User        string
+	Platform    string
+	RequestedAt time.Time
+}
+
+// DashboardCluster is an active launched cluster.
+type DashboardCluster struct {
+	Name        string
+	Platform    string
+	Version     string
+	User        string
+	State       string
+	RequestedAt time.Time
+	ExpiresAt   time.Time
+}
+
+// DashboardFailure is a recently failed launch.
+type DashboardFailure struct {
+	Name     string
+	Platform string
+	User     string
+	Failure  string
+	At       time.Time
+}
+
+// DashboardUser is one user's share of the running clusters.
+type DashboardUser struct {
+	User    string
+	Running int
+}
+
+// DashboardData is everything the operator dashboard renders.
+type DashboardData struct {
+	Queued      []DashboardQueued
+	Clusters    []DashboardCluster
+	Failures    []DashboardFailure
+	Users       []DashboardUser
+	MaxClusters int
+}
+
+// DashboardSnapshot copies the live queue, active clusters, recent failures,
+// and per-user consumption out of the manager for the operator dashboard.
+func (m *jobManager) DashboardSnapshot() DashboardData {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	data := DashboardData{MaxClusters: m.maxClusters}
+	for _, req := range m.queuedLaunches {
+		data.Queued = append(data.Queued, DashboardQueued{
+			User:        req.User,
+			Platform:    req.Platform,
+			RequestedAt: req.RequestedAt,
+		})
+	}
+	running := make(map[string]int)
+	for _, job := range m.jobs {
+		if job == nil || (job.Mode != JobTypeLaunch && job.Mode != JobTypeWorkflowLaunch) || job.Complete || len(job.Failure) > 0 {
+			continue
+		}
+		version := ""
+		if len(job.Inputs) > 0 {
+			version = job.Inputs[0].Version
+		}
+		state := "starting"
+		if len(job.Credentials) > 0 {
+			state = "running"
+		}
+		data.Clusters = append(data.Clusters, DashboardCluster{
+			Name:        job.Name,
+			Platform:    job.Platform,
+			Version:     version,
+			User:        job.RequestedBy,
+			State:       state,
+			RequestedAt: job.RequestedAt,
+			ExpiresAt:   job.ExpiresAt,
+		})
+		running[job.RequestedBy]++
+	}
+	for user, count := range running {
+		data.Users = append(data.Users, DashboardUser{User: user, Running: count})
+	}
+	data.Failures = append(data.Failures, m.recentFailures...)
+
+	sort.Slice(data.Queued, func(i, j int) bool { return data.Queued[i].RequestedAt.Before(data.Queued[j].RequestedAt) })
+	sort.Slice(data.Clusters, func(i, j int) bool { return data.Clusters[i].Name < data.Clusters[j].Name })
+	sort.Slice(data.Users, func(i, j int) bool { return data.Users[i].User < data.Users[j].User })
+	sort.Slice(data.Failures, func(i, j int) bool { return data.Failures[i].At.After(data.Failures[j].At) })
+	return data
+}
+
+// recordFailure remembers a failed launch for the dashboard's recent-failures
+// table. Callers must hold m.lock.
+func (m *jobManager) recordFailure(job *Job) {
+	m.recentFailures = append(m.recentFailures, DashboardFailure{
+		Name:     job.Name,
+		Platform: job.Platform,
+		User:     job.RequestedBy,
+		Failure:  job.Failure,
+		At:       time.Now(),
+	})
+	if len(m.recentFailures) > maxRecentFailures {
+		m.recentFailures = m.recentFailures[len(m.recentFailures)-maxRecentFailures:]
+	}
+}
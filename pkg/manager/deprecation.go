@@ -0,0 +1,119 @@
+package manager
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+	"sigs.k8s.io/yaml"
+)
+
+// Old minors linger in the release streams long after they stop being worth
+// the infrastructure that serves them, and operators have no signal for when
+// a stream can finally be dropped. Administrators can list deprecated or
+// end-of-life versions in --deprecated-versions-config: launching one warns
+// the user and requires the `force` option, and every request against a
+// deprecated version is counted so `admin deprecation-report` shows which
+// streams still see demand.
+
+// DeprecatedVersion is one entry of the --deprecated-versions-config list.
+type DeprecatedVersion struct {
+	// Version is the major.minor (e.g. "4.8") or a longer version prefix the
+	// entry covers.
+	Version string `json:"version"`
+	// Note is appended to the warning, e.g. the planned removal date or a
+	// pointer to the supported alternative.
+	Note string `json:"note,omitempty"`
+}
+
+// LoadDeprecatedVersions reads a YAML list of deprecated version entries.
+func LoadDeprecatedVersions(path string) ([]DeprecatedVersion, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read deprecated versions: %w", err)
+	}
+	var entries []DeprecatedVersion
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("unable to parse deprecated versions: %w", err)
+	}
+	for i, entry := range entries {
+		if len(entry.Version) == 0 {
+			return nil, fmt.Errorf("deprecated version entry %d has no version", i)
+		}
+	}
+	return entries, nil
+}
+
+// SetDeprecatedVersions installs the configured deprecation list.
+func (m *jobManager) SetDeprecatedVersions(entries []DeprecatedVersion) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.deprecatedVersions = entries
+}
+
+// deprecatedVersionFor returns the entry covering the version, if any. A
+// version matches when its major.minor equals the entry's version or when the
+// entry's version is a literal prefix of it. Callers must hold m.lock.
+func (m *jobManager) deprecatedVersionFor(version string) *DeprecatedVersion {
+	if len(version) == 0 {
+		return nil
+	}
+	var majorMinor string
+	if v, err := semver.ParseTolerant(version); err == nil {
+		majorMinor = fmt.Sprintf("%d.%d", v.Major, v.Minor)
+	}
+	for i := range m.deprecatedVersions {
+		entry := &m.deprecatedVersions[i]
+		if entry.Version == majorMinor || strings.HasPrefix(version, entry.Version) {
+			return entry
+		}
+	}
+	return nil
+}
+
+// checkDeprecatedVersion counts a request against the deprecation list and,
+// unless the user already confirmed with the `force` option, blocks it with
+// the configured warning.
+func (m *jobManager) checkDeprecatedVersion(version string, forced bool) error {
+	m.lock.Lock()
+	entry := m.deprecatedVersionFor(version)
+	if entry != nil {
+		m.deprecatedLaunches[entry.Version]++
+	}
+	m.lock.Unlock()
+	if entry == nil || forced {
+		return nil
+	}
+	msg := fmt.Sprintf("version `%s` is deprecated and will be removed", version)
+	if len(entry.Note) > 0 {
+		msg = fmt.Sprintf("%s: %s", msg, entry.Note)
+	}
+	return fmt.Errorf("%s; add the `%s` option to launch it anyway", msg, ForceLaunchParam)
+}
+
+// AdminDeprecationReport renders how often each deprecated version has been
+// requested since the bot started, so operators can tell which streams have
+// gone quiet enough to drop.
+func (m *jobManager) AdminDeprecationReport() string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if len(m.deprecatedVersions) == 0 {
+		return "no deprecated versions are configured"
+	}
+	buf := &strings.Builder{}
+	fmt.Fprintf(buf, "requests per deprecated version since %s:\n", m.started.Format(time.RFC1123))
+	sorted := make([]DeprecatedVersion, len(m.deprecatedVersions))
+	copy(sorted, m.deprecatedVersions)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	for _, entry := range sorted {
+		fmt.Fprintf(buf, "• `%s`: %d request(s)", entry.Version, m.deprecatedLaunches[entry.Version])
+		if len(entry.Note) > 0 {
+			fmt.Fprintf(buf, " - %s", entry.Note)
+		}
+		fmt.Fprintf(buf, "\n")
+	}
+	return buf.String()
+}
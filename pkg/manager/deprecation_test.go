@@ -0,0 +1,61 @@
+package manager
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckDeprecatedVersion(t *testing.T) {
+	m := &jobManager{
+		deprecatedVersions: []DeprecatedVersion{
+			{Version: "4.8", Note: "EOL since 2023"},
+			{Version: "4.12.0-0.nightly"},
+		},
+		deprecatedLaunches: make(map[string]int),
+	}
+	testCases := []struct {
+		name        string
+		version     string
+		forced      bool
+		expectedErr string
+	}{{
+		name:    "supported version passes",
+		version: "4.18.0-0.nightly-2026-08-29-120000",
+	}, {
+		name:        "major.minor match blocks and carries the note",
+		version:     "4.8.57",
+		expectedErr: "EOL since 2023",
+	}, {
+		name:        "prefix match blocks",
+		version:     "4.12.0-0.nightly-2023-01-01-000000",
+		expectedErr: "is deprecated",
+	}, {
+		name:    "force bypasses the warning",
+		version: "4.8.57",
+		forced:  true,
+	}, {
+		name:    "empty version passes",
+		version: "",
+	}}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := m.checkDeprecatedVersion(testCase.version, testCase.forced)
+			if len(testCase.expectedErr) == 0 {
+				if err != nil {
+					t.Fatalf("expected no error, got: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), testCase.expectedErr) {
+				t.Fatalf("expected an error containing %q, got: %v", testCase.expectedErr, err)
+			}
+		})
+	}
+	// the forced and blocked 4.8 requests both count toward the stream
+	if count := m.deprecatedLaunches["4.8"]; count != 2 {
+		t.Errorf("expected 2 requests counted against 4.8, got %d", count)
+	}
+	if count := m.deprecatedLaunches["4.12.0-0.nightly"]; count != 1 {
+		t.Errorf("expected 1 request counted against 4.12.0-0.nightly, got %d", count)
+	}
+}
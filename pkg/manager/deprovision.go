@@ -0,0 +1,84 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+
+	botapiv1 "github.com/openshift/ci-chat-bot/pkg/prow/apiv1"
+)
+
+// When someone leaves the organization their Slack account is deactivated but
+// their clusters keep burning quota until the regular expiry, and a launch
+// parked in the quota queue would even start a new one. DeprovisionUser is
+// invoked from the user_change event handler when a deactivation is seen: it
+// revokes the user's queued launch and caps the expiry of their running
+// clusters to a short grace period, so the normal expiry machinery destroys
+// them while admins still have time to transfer anything worth keeping with
+// `admin extend` and a credential hand-off.
+
+// deprovisionGracePeriod is how long a deactivated user's clusters keep
+// running before the capped expiry destroys them.
+const deprovisionGracePeriod = time.Hour
+
+// DeprovisionUser revokes the deactivated user's queued launch and caps their
+// running clusters' expiry to the deprovision grace period, notifying admins
+// when the user owned anything. The returned summary is empty when there was
+// nothing to clean up.
+func (m *jobManager) DeprovisionUser(user string) (string, error) {
+	now := time.Now()
+	deadline := now.Add(deprovisionGracePeriod)
+	m.lock.Lock()
+	var revokedQueue bool
+	if _, ok := m.queuedLaunches[user]; ok {
+		delete(m.queuedLaunches, user)
+		revokedQueue = true
+	}
+	var capped []string
+	for _, job := range m.jobs {
+		if job.Mode != JobTypeLaunch && job.Mode != JobTypeWorkflowLaunch {
+			continue
+		}
+		if job.Complete || job.RequestedBy != user {
+			continue
+		}
+		if !job.ExpiresAt.IsZero() && job.ExpiresAt.Before(deadline) {
+			// already closer to expiry than the grace period
+			continue
+		}
+		// persist the capped expiry via the expires annotation so it
+		// survives a bot restart, mirroring AdminExtendAll
+		expires := int(deadline.Sub(job.RequestedAt).Seconds())
+		patch := []byte(fmt.Sprintf(`{"metadata":{"annotations":{"%s":"%d"}}}`, botapiv1.AnnotationExpires, expires))
+		if _, err := m.prowClient.ProwJobs(m.prowNamespace).Patch(context.TODO(), job.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			klog.Warningf("Failed to cap expiry of job %q for deactivated user %q: %v", job.Name, user, err)
+		}
+		job.ExpiresAt = deadline
+		capped = append(capped, fmt.Sprintf("`%s` (%s)", job.Name, job.Platform))
+	}
+	notifier := m.adminNotifierFn
+	m.lock.Unlock()
+
+	if !revokedQueue && len(capped) == 0 {
+		return "", nil
+	}
+	sort.Strings(capped)
+	var parts []string
+	if len(capped) > 0 {
+		parts = append(parts, fmt.Sprintf("%d cluster(s) will be destroyed in %d minutes: %s; use `admin extend` to keep one for a hand-off", len(capped), int(deprovisionGracePeriod.Minutes()), strings.Join(capped, ", ")))
+	}
+	if revokedQueue {
+		parts = append(parts, "their queued launch was revoked")
+	}
+	msg := fmt.Sprintf("slack user <@%s> was deactivated; %s", user, strings.Join(parts, "; "))
+	if notifier != nil {
+		notifier(msg)
+	}
+	return msg, nil
+}
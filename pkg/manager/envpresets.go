@@ -0,0 +1,48 @@
+package manager
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// reEnvPresetName restricts preset names to the characters used by step
+// registry entries so that user input can never escape the configured base URL
+var reEnvPresetName = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
+// ResolveEnvPreset fetches a named environment preset from the step registry
+// location the bot was configured with and returns its KEY: VALUE contents.
+func (m *jobManager) ResolveEnvPreset(name string) (map[string]string, error) {
+	if len(m.envPresetURL) == 0 {
+		return nil, fmt.Errorf("env presets are not configured for this bot instance")
+	}
+	if !reEnvPresetName.MatchString(name) {
+		return nil, fmt.Errorf("invalid env preset name `%s`", name)
+	}
+	presetURL := fmt.Sprintf("%s/%s.yaml", strings.TrimSuffix(m.envPresetURL, "/"), name)
+	resp, err := http.Get(presetURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch env preset: %v", err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("no env preset named `%s` exists in the step registry", name)
+	default:
+		return nil, fmt.Errorf("failed to fetch env preset %s: status code %d", name, resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read env preset %s: %v", name, err)
+	}
+	preset := map[string]string{}
+	if err := yaml.Unmarshal(data, &preset); err != nil {
+		return nil, fmt.Errorf("env preset %s is not a flat KEY: VALUE mapping: %v", name, err)
+	}
+	return preset, nil
+}
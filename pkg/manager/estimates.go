@@ -0,0 +1,59 @@
+package manager
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/blang/semver"
+)
+
+// Install durations vary widely between platforms and minor versions, so the
+// global median kept in recentStartEstimates is a poor fit for progress
+// updates. Finished launches additionally feed a per-(platform, minor version)
+// history here, which lets "still starting" messages show elapsed time against
+// what is typical for that exact combination.
+
+// maxStartHistorySamples bounds each bucket; older samples age out so the
+// estimates follow installer speed changes across releases.
+const maxStartHistorySamples = 25
+
+// startHistoryKey buckets a job for duration tracking. Payload names parse as
+// semver, so "4.16.0-0.nightly-..." on aws becomes "aws/4.16"; jobs whose
+// input has no parseable version share a platform-wide bucket.
+func startHistoryKey(job *Job) string {
+	version := ""
+	if len(job.Inputs) > 0 && len(job.Inputs[0].Version) > 0 {
+		if v, err := semver.ParseTolerant(job.Inputs[0].Version); err == nil {
+			version = fmt.Sprintf("%d.%d", v.Major, v.Minor)
+		}
+	}
+	return job.Platform + "/" + version
+}
+
+// recordStartDuration adds a finished launch's setup time to the history for
+// its platform/version bucket. Callers must hold m.lock.
+func (m *jobManager) recordStartDuration(job *Job) {
+	key := startHistoryKey(job)
+	samples := append(m.startHistory[key], job.StartDuration)
+	if len(samples) > maxStartHistorySamples {
+		samples = samples[len(samples)-maxStartHistorySamples:]
+	}
+	m.startHistory[key] = samples
+}
+
+// typicalStartDuration returns the median historical setup time for the job's
+// platform/version bucket, or zero when there are too few samples to be more
+// useful than the global estimate.
+func (m *jobManager) typicalStartDuration(job *Job) time.Duration {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	samples := m.startHistory[startHistoryKey(job)]
+	if len(samples) < 3 {
+		return 0
+	}
+	sorted := make([]time.Duration, len(samples))
+	copy(sorted, samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[len(sorted)/2].Truncate(time.Minute)
+}
@@ -0,0 +1,120 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog"
+	prowapiv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	prowjobClient "sigs.k8s.io/prow/pkg/client/clientset/versioned/typed/prowjobs/v1"
+
+	"github.com/openshift/ci-chat-bot/pkg/utils"
+)
+
+// Executor abstracts how the bot runs the jobs it prepares. The ProwJob type
+// stays the shared in-memory representation; each backend translates it to
+// its own workload. The default backend submits real ProwJobs; deployments
+// without a full prow installation can install an alternative such as
+// NewKubeExecutor before Start is called. Annotation bookkeeping (expiry
+// extension, notification deduplication) still goes through the prow client
+// and degrades to log warnings on other backends.
+type Executor interface {
+	// Submit starts the prepared job and returns the URL where its progress
+	// can be followed. Errors prefixed with "did not retrieve job url due to
+	// an error:" indicate the job started but no URL could be determined, so
+	// callers must not clean up the job's state.
+	Submit(ctx context.Context, pj *prowapiv1.ProwJob) (string, error)
+	// Get returns the current state of a submitted job, translated into the
+	// ProwJob form.
+	Get(ctx context.Context, name, cluster string) (*prowapiv1.ProwJob, error)
+	// Abort stops a submitted job. Jobs that already finished or never
+	// started a pod are treated as successfully aborted.
+	Abort(ctx context.Context, name, cluster string) error
+}
+
+// SetExecutor replaces the backend that runs jobs. It is intended to be
+// called before Start, while no jobs are in flight.
+func (m *jobManager) SetExecutor(executor Executor) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.executor = executor
+}
+
+// prowExecutor runs jobs as ProwJobs, the default.
+type prowExecutor struct {
+	client         prowjobClient.ProwV1Interface
+	namespace      string
+	clusterClients utils.BuildClusterClientConfigMap
+}
+
+func newProwExecutor(client prowjobClient.ProwV1Interface, namespace string, clusterClients utils.BuildClusterClientConfigMap) *prowExecutor {
+	return &prowExecutor{client: client, namespace: namespace, clusterClients: clusterClients}
+}
+
+func (e *prowExecutor) Submit(ctx context.Context, pj *prowapiv1.ProwJob) (string, error) {
+	_, err := e.client.ProwJobs(e.namespace).Create(ctx, pj, metav1.CreateOptions{})
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return "", err
+	}
+
+	var prowJobURL string
+	// Wait for ProwJob URL to be assigned
+	err = wait.PollUntilContextTimeout(ctx, 10*time.Second, 5*time.Minute, true, func(ctx context.Context) (bool, error) {
+		latestPJ, err := e.client.ProwJobs(e.namespace).Get(ctx, pj.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		if len(latestPJ.Status.URL) > 0 {
+			prowJobURL = latestPJ.Status.URL
+			return true, nil
+		}
+		return false, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("did not retrieve job url due to an error: %v", err)
+	}
+
+	return prowJobURL, nil
+}
+
+func (e *prowExecutor) Get(ctx context.Context, name, cluster string) (*prowapiv1.ProwJob, error) {
+	return e.client.ProwJobs(e.namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+func (e *prowExecutor) Abort(ctx context.Context, name, cluster string) error {
+	pj, err := e.client.ProwJobs(e.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// There may have been an issue creating the prowjob; treat as success
+			return nil
+		}
+		return err
+	}
+
+	clusterClient, ok := e.clusterClients.Get(cluster)
+	if !ok {
+		return fmt.Errorf("cluster %s not found in build cluster clients", cluster)
+	}
+	_, err = clusterClient.CoreClient.CoreV1().Pods(e.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			if pj.Status.State == prowapiv1.TriggeredState {
+				return fmt.Errorf("original request is still initializing -- please try again in a few minutes")
+			}
+			// Since prowjob State != Triggered, pod creation should have been attempted.
+			// If it is not here, there's nothing to stop
+			return nil
+		}
+		return err
+	}
+
+	klog.Infof("ProwJob pod for job %q will be aborted", name)
+	pj.Status.State = prowapiv1.AbortedState
+	_, err = e.client.ProwJobs(e.namespace).Update(ctx, pj, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,187 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+	prowapiv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+)
+
+// gangwayExecutor submits jobs through prow's external-facing gangway REST
+// API instead of creating ProwJob CRs directly, so the bot can run outside
+// the prow cluster with only an API endpoint and a bearer token. The bot's
+// job customization is carried as environment variable overrides, which is
+// how launches are parameterized (UNRESOLVED_CONFIG et al.); modes that
+// rewrite pod spec arguments instead, build and catalog, cannot be expressed
+// through gangway and are rejected at submit time. Gangway has no abort
+// endpoint, so Abort only logs; an aborted run stops at its configured
+// timeout. The mapping from job names to gangway execution IDs is in memory
+// only and does not survive a restart.
+type gangwayExecutor struct {
+	endpoint string
+	deckURL  string
+	token    string
+	client   *http.Client
+
+	lock       sync.Mutex
+	executions map[string]gangwayExecution
+}
+
+type gangwayExecution struct {
+	id        string
+	submitted time.Time
+}
+
+// NewGangwayExecutor returns an Executor that submits jobs to the gangway
+// API at endpoint, authenticating with the bearer token. deckURL, when set,
+// is used to build the job URLs shown to users; otherwise the gangway
+// execution URL is shown.
+func NewGangwayExecutor(endpoint, deckURL, token string) (Executor, error) {
+	if len(endpoint) == 0 {
+		return nil, fmt.Errorf("a gangway endpoint is required")
+	}
+	return &gangwayExecutor{
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		deckURL:    strings.TrimSuffix(deckURL, "/"),
+		token:      token,
+		client:     &http.Client{Timeout: 30 * time.Second},
+		executions: make(map[string]gangwayExecution),
+	}, nil
+}
+
+func (e *gangwayExecutor) do(ctx context.Context, method, url string, body, into interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(e.token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+e.token)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("gangway returned %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if into != nil {
+		return json.Unmarshal(data, into)
+	}
+	return nil
+}
+
+func (e *gangwayExecutor) jobURL(id string) string {
+	if len(e.deckURL) > 0 {
+		return fmt.Sprintf("%s/prowjob?prowjob=%s", e.deckURL, id)
+	}
+	return fmt.Sprintf("%s/v1/executions/%s", e.endpoint, id)
+}
+
+func (e *gangwayExecutor) Submit(ctx context.Context, pj *prowapiv1.ProwJob) (string, error) {
+	if mode := pj.Annotations["ci-chat-bot.openshift.io/mode"]; mode == string(JobTypeBuild) || mode == string(JobTypeCatalog) {
+		return "", fmt.Errorf("%s jobs rewrite their pod spec arguments, which cannot be expressed through gangway", mode)
+	}
+	envs := make(map[string]string)
+	if pj.Spec.PodSpec != nil && len(pj.Spec.PodSpec.Containers) > 0 {
+		for _, env := range pj.Spec.PodSpec.Containers[0].Env {
+			if env.ValueFrom == nil {
+				envs[env.Name] = env.Value
+			}
+		}
+	}
+	request := map[string]interface{}{
+		"job_name":           pj.Spec.Job,
+		"job_execution_type": strings.ToUpper(string(pj.Spec.Type)),
+		"pod_spec_options": map[string]interface{}{
+			"envs":        envs,
+			"labels":      pj.Labels,
+			"annotations": pj.Annotations,
+		},
+	}
+	var response struct {
+		ID string `json:"id"`
+	}
+	if err := e.do(ctx, http.MethodPost, e.endpoint+"/v1/executions", request, &response); err != nil {
+		return "", err
+	}
+	if len(response.ID) == 0 {
+		return "", fmt.Errorf("gangway did not return an execution id for job %s", pj.Spec.Job)
+	}
+	e.lock.Lock()
+	e.executions[pj.Name] = gangwayExecution{id: response.ID, submitted: time.Now()}
+	e.lock.Unlock()
+	klog.Infof("Job %q submitted to gangway as execution %s", pj.Name, response.ID)
+	return e.jobURL(response.ID), nil
+}
+
+func (e *gangwayExecutor) Get(ctx context.Context, name, cluster string) (*prowapiv1.ProwJob, error) {
+	e.lock.Lock()
+	execution, ok := e.executions[name]
+	e.lock.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("no gangway execution is tracked for job %s; executions are not recoverable after a restart", name)
+	}
+	var response struct {
+		ID        string `json:"id"`
+		JobStatus string `json:"job_status"`
+	}
+	if err := e.do(ctx, http.MethodGet, fmt.Sprintf("%s/v1/executions/%s", e.endpoint, execution.id), nil, &response); err != nil {
+		return nil, err
+	}
+	var state prowapiv1.ProwJobState
+	switch response.JobStatus {
+	case "SUCCESS":
+		state = prowapiv1.SuccessState
+	case "FAILURE":
+		state = prowapiv1.FailureState
+	case "ABORTED":
+		state = prowapiv1.AbortedState
+	case "PENDING":
+		state = prowapiv1.PendingState
+	default:
+		state = prowapiv1.TriggeredState
+	}
+	return &prowapiv1.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: prowapiv1.ProwJobStatus{
+			State:     state,
+			URL:       e.jobURL(execution.id),
+			StartTime: metav1.NewTime(execution.submitted),
+		},
+	}, nil
+}
+
+func (e *gangwayExecutor) Abort(ctx context.Context, name, cluster string) error {
+	e.lock.Lock()
+	execution, ok := e.executions[name]
+	e.lock.Unlock()
+	if !ok {
+		// never submitted or the bot restarted; nothing we can reach
+		return nil
+	}
+	klog.Warningf("Job %q (gangway execution %s) cannot be aborted through gangway; the run will stop at its configured timeout", name, execution.id)
+	return nil
+}
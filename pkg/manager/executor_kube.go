@@ -0,0 +1,136 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	prowapiv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+
+	"github.com/openshift/ci-chat-bot/pkg/utils"
+)
+
+// kubeExecutor runs the pod spec the bot prepared as a plain Kubernetes Job
+// on the target build cluster, for deployments that do not run a full prow.
+// The ProwJob's metadata is copied onto the Job so annotation-driven state
+// recovery keeps working, and Get translates the Job's status back into the
+// ProwJob form the manager expects.
+type kubeExecutor struct {
+	clusterClients utils.BuildClusterClientConfigMap
+	namespace      string
+	logURLTemplate string
+}
+
+// NewKubeExecutor returns an Executor that runs jobs as Kubernetes Jobs in
+// the given namespace. logURLTemplate renders the URL users follow for logs
+// and must contain two %s verbs, filled with the namespace and the job name,
+// e.g. "https://console.example.com/k8s/ns/%s/jobs/%s".
+func NewKubeExecutor(clusterClients utils.BuildClusterClientConfigMap, namespace, logURLTemplate string) (Executor, error) {
+	if len(logURLTemplate) == 0 {
+		return nil, fmt.Errorf("a log URL template is required, e.g. %q", "https://console.example.com/k8s/ns/%s/jobs/%s")
+	}
+	return &kubeExecutor{clusterClients: clusterClients, namespace: namespace, logURLTemplate: logURLTemplate}, nil
+}
+
+func (e *kubeExecutor) clientFor(cluster string) (*utils.BuildClusterClientConfig, error) {
+	clusterClient, ok := e.clusterClients.Get(cluster)
+	if !ok {
+		return nil, fmt.Errorf("cluster %s not found in build cluster clients", cluster)
+	}
+	return clusterClient, nil
+}
+
+func (e *kubeExecutor) logURL(name string) string {
+	return fmt.Sprintf(e.logURLTemplate, e.namespace, name)
+}
+
+func (e *kubeExecutor) Submit(ctx context.Context, pj *prowapiv1.ProwJob) (string, error) {
+	if pj.Spec.PodSpec == nil {
+		return "", fmt.Errorf("job %s has no pod spec to run", pj.Name)
+	}
+	clusterClient, err := e.clientFor(pj.Spec.Cluster)
+	if err != nil {
+		return "", err
+	}
+	podSpec := pj.Spec.PodSpec.DeepCopy()
+	podSpec.RestartPolicy = corev1.RestartPolicyNever
+	var backoffLimit int32
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        pj.Name,
+			Namespace:   e.namespace,
+			Labels:      pj.Labels,
+			Annotations: pj.Annotations,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: pj.Labels},
+				Spec:       *podSpec,
+			},
+		},
+	}
+	if _, err := clusterClient.CoreClient.BatchV1().Jobs(e.namespace).Create(ctx, job, metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+		return "", err
+	}
+	return e.logURL(pj.Name), nil
+}
+
+func (e *kubeExecutor) Get(ctx context.Context, name, cluster string) (*prowapiv1.ProwJob, error) {
+	clusterClient, err := e.clientFor(cluster)
+	if err != nil {
+		return nil, err
+	}
+	job, err := clusterClient.CoreClient.BatchV1().Jobs(e.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	state := prowapiv1.TriggeredState
+	switch {
+	case job.Status.Failed > 0:
+		state = prowapiv1.FailureState
+	case job.Status.Succeeded > 0:
+		state = prowapiv1.SuccessState
+	case job.Status.Active > 0:
+		state = prowapiv1.PendingState
+	}
+	started := job.CreationTimestamp
+	if job.Status.StartTime != nil {
+		started = *job.Status.StartTime
+	}
+	podSpec := job.Spec.Template.Spec
+	return &prowapiv1.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        job.Name,
+			Namespace:   job.Namespace,
+			Labels:      job.Labels,
+			Annotations: job.Annotations,
+		},
+		Spec: prowapiv1.ProwJobSpec{
+			Cluster: cluster,
+			PodSpec: &podSpec,
+		},
+		Status: prowapiv1.ProwJobStatus{
+			State:     state,
+			URL:       e.logURL(name),
+			StartTime: started,
+		},
+	}, nil
+}
+
+func (e *kubeExecutor) Abort(ctx context.Context, name, cluster string) error {
+	clusterClient, err := e.clientFor(cluster)
+	if err != nil {
+		return err
+	}
+	policy := metav1.DeletePropagationForeground
+	err = clusterClient.CoreClient.BatchV1().Jobs(e.namespace).Delete(ctx, name, metav1.DeleteOptions{PropagationPolicy: &policy})
+	if errors.IsNotFound(err) {
+		// the job already finished or was never created; nothing to stop
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,176 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog"
+	"knative.dev/pkg/apis"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	prowapiv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+
+	"github.com/openshift/ci-chat-bot/pkg/utils"
+)
+
+// tektonExecutor runs jobs whose prow config declares the Tekton agent as
+// PipelineRuns on the target build cluster and passes every other job through
+// to its delegate, so the backend is selected per job by the existing agent
+// field rather than globally. Aborting a run cancels the PipelineRun, which
+// lets its finally tasks perform teardown. The launch credential flow expects
+// the pipeline to create the same ci-ln-* namespace and shared-dir secret
+// layout ci-operator produces.
+type tektonExecutor struct {
+	delegate       Executor
+	clusterClients utils.BuildClusterClientConfigMap
+	namespace      string
+	logURLTemplate string
+
+	lock    sync.Mutex
+	clients map[string]ctrlruntimeclient.Client
+}
+
+// NewTektonExecutor returns an Executor that runs jobs declaring the
+// tekton-pipeline agent as PipelineRuns in the given namespace and defers all
+// other jobs to delegate. logURLTemplate renders the URL users follow for a
+// run's logs and must contain two %s verbs, filled with the namespace and the
+// run name.
+func NewTektonExecutor(delegate Executor, clusterClients utils.BuildClusterClientConfigMap, namespace, logURLTemplate string) (Executor, error) {
+	if len(logURLTemplate) == 0 {
+		return nil, fmt.Errorf("a log URL template is required, e.g. %q", "https://console.example.com/k8s/ns/%s/tekton.dev~v1~PipelineRun/%s")
+	}
+	return &tektonExecutor{
+		delegate:       delegate,
+		clusterClients: clusterClients,
+		namespace:      namespace,
+		logURLTemplate: logURLTemplate,
+		clients:        make(map[string]ctrlruntimeclient.Client),
+	}, nil
+}
+
+func (e *tektonExecutor) clientFor(cluster string) (ctrlruntimeclient.Client, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if client, ok := e.clients[cluster]; ok {
+		return client, nil
+	}
+	clusterClient, ok := e.clusterClients.Get(cluster)
+	if !ok {
+		return nil, fmt.Errorf("cluster %s not found in build cluster clients", cluster)
+	}
+	scheme := runtime.NewScheme()
+	if err := tektonv1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	client, err := ctrlruntimeclient.New(clusterClient.CoreConfig, ctrlruntimeclient.Options{Scheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+	e.clients[cluster] = client
+	return client, nil
+}
+
+func (e *tektonExecutor) logURL(name string) string {
+	return fmt.Sprintf(e.logURLTemplate, e.namespace, name)
+}
+
+func (e *tektonExecutor) Submit(ctx context.Context, pj *prowapiv1.ProwJob) (string, error) {
+	if pj.Spec.Agent != prowapiv1.TektonAgent {
+		return e.delegate.Submit(ctx, pj)
+	}
+	// GetPipelineRunSpec also validates that the job config carries a run
+	// spec in one of the supported fields
+	spec, err := pj.Spec.GetPipelineRunSpec()
+	if err != nil {
+		return "", fmt.Errorf("job %s declares the %s agent but has no pipeline run spec: %v", pj.Spec.Job, prowapiv1.TektonAgent, err)
+	}
+	client, err := e.clientFor(pj.Spec.Cluster)
+	if err != nil {
+		return "", err
+	}
+	run := &tektonv1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        pj.Name,
+			Namespace:   e.namespace,
+			Labels:      pj.Labels,
+			Annotations: pj.Annotations,
+		},
+		Spec: *spec.DeepCopy(),
+	}
+	if err := client.Create(ctx, run); err != nil && !errors.IsAlreadyExists(err) {
+		return "", err
+	}
+	return e.logURL(pj.Name), nil
+}
+
+func (e *tektonExecutor) Get(ctx context.Context, name, cluster string) (*prowapiv1.ProwJob, error) {
+	client, err := e.clientFor(cluster)
+	if err != nil {
+		return nil, err
+	}
+	run := &tektonv1.PipelineRun{}
+	if err := client.Get(ctx, ctrlruntimeclient.ObjectKey{Namespace: e.namespace, Name: name}, run); err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			// not a Tekton job on this cluster; ask the delegate
+			return e.delegate.Get(ctx, name, cluster)
+		}
+		return nil, err
+	}
+	state := prowapiv1.TriggeredState
+	if condition := run.Status.GetCondition(apis.ConditionSucceeded); condition != nil {
+		switch {
+		case condition.IsTrue():
+			state = prowapiv1.SuccessState
+		case condition.IsFalse():
+			state = prowapiv1.FailureState
+		default:
+			state = prowapiv1.PendingState
+		}
+	}
+	started := run.CreationTimestamp
+	if run.Status.StartTime != nil {
+		started = *run.Status.StartTime
+	}
+	return &prowapiv1.ProwJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        run.Name,
+			Namespace:   run.Namespace,
+			Labels:      run.Labels,
+			Annotations: run.Annotations,
+		},
+		Spec: prowapiv1.ProwJobSpec{
+			Agent:   prowapiv1.TektonAgent,
+			Cluster: cluster,
+		},
+		Status: prowapiv1.ProwJobStatus{
+			State:     state,
+			URL:       e.logURL(name),
+			StartTime: started,
+		},
+	}, nil
+}
+
+func (e *tektonExecutor) Abort(ctx context.Context, name, cluster string) error {
+	client, err := e.clientFor(cluster)
+	if err != nil {
+		return err
+	}
+	run := &tektonv1.PipelineRun{}
+	if err := client.Get(ctx, ctrlruntimeclient.ObjectKey{Namespace: e.namespace, Name: name}, run); err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return e.delegate.Abort(ctx, name, cluster)
+		}
+		return err
+	}
+	if run.IsDone() || run.IsCancelled() {
+		return nil
+	}
+	klog.Infof("PipelineRun for job %q will be cancelled", name)
+	run.Spec.Status = tektonv1.PipelineRunSpecStatusCancelled
+	return client.Update(ctx, run)
+}
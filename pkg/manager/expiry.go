@@ -0,0 +1,117 @@
+package manager
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/klog"
+)
+
+// Clusters used to vanish the moment their expiry passed, which regularly
+// cost users the must-gather or log they were about to collect. Destruction
+// is now two-phase: when a cluster's expiry is reached the owner gets a
+// notice with a grace window (15 minutes by default, configurable with
+// --expiry-grace-window) during which they can archive the cluster's
+// artifacts or extend it, and only when the window closes does the teardown
+// proceed. Explicit teardowns - `done`, admin commands, the office-hours
+// reaper - skip the window and destroy immediately, as they always have.
+
+// defaultExpiryGraceWindow is how long destruction is deferred after expiry
+// when no --expiry-grace-window is configured.
+const defaultExpiryGraceWindow = 15 * time.Minute
+
+// SetExpiryGraceWindow adjusts the pause between a cluster expiring and its
+// destruction; zero destroys expired clusters immediately.
+func (m *jobManager) SetExpiryGraceWindow(window time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.graceWindow = window
+}
+
+// SetExpiryNotifier registers the callback that warns a cluster's owner when
+// the grace window opens.
+func (m *jobManager) SetExpiryNotifier(fn ExpiryCallbackFunc) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.expiryNotifierFn = fn
+}
+
+// expirySync opens grace windows for newly expired clusters and destroys the
+// ones whose window has closed.
+func (m *jobManager) expirySync() {
+	now := time.Now()
+	m.lock.Lock()
+	grace := m.graceWindow
+	notifier := m.expiryNotifierFn
+	var notify []Job
+	var deadlines []time.Time
+	var destroy []string
+	for name, job := range m.jobs {
+		if job.Mode != JobTypeLaunch && job.Mode != JobTypeWorkflowLaunch {
+			continue
+		}
+		if job.Complete || len(job.RequestedBy) == 0 {
+			continue
+		}
+		// spokes are destroyed with their hub
+		if IsSpokeOwner(job.RequestedBy) {
+			continue
+		}
+		if job.ExpiresAt.IsZero() || job.ExpiresAt.After(now) {
+			// not expired, or extended during its grace window
+			delete(m.pendingDestroys, name)
+			continue
+		}
+		deadline, pending := m.pendingDestroys[name]
+		if !pending {
+			if grace <= 0 {
+				destroy = append(destroy, job.RequestedBy)
+				continue
+			}
+			deadline = now.Add(grace)
+			m.pendingDestroys[name] = deadline
+			notify = append(notify, *job)
+			deadlines = append(deadlines, deadline)
+			continue
+		}
+		if now.After(deadline) {
+			destroy = append(destroy, job.RequestedBy)
+			delete(m.pendingDestroys, name)
+		}
+	}
+	m.lock.Unlock()
+	for i, job := range notify {
+		klog.Infof("Job %q expired; destruction deferred until %s", job.Name, deadlines[i].Format(time.RFC3339))
+		if notifier != nil {
+			notifier(job, deadlines[i])
+		}
+	}
+	for _, owner := range destroy {
+		if _, err := m.terminateLaunchedJob(owner); err != nil {
+			klog.Warningf("Failed to destroy expired cluster for %s: %v", owner, err)
+		}
+	}
+}
+
+// KeepArtifacts archives the named cluster's metadata on the owner's behalf,
+// typically from the grace-window notice, so the record and the pointer to
+// the job's artifacts survive the teardown.
+func (m *jobManager) KeepArtifacts(requester, name string) (string, error) {
+	m.lock.Lock()
+	job, ok := m.jobs[name]
+	m.lock.Unlock()
+	if !ok {
+		return "", fmt.Errorf("no job named `%s` was found", name)
+	}
+	if job.RequestedBy != requester && job.RequesterUserID != requester {
+		return "", fmt.Errorf("only the user who launched `%s` may keep its artifacts", name)
+	}
+	if err := m.archiveJob(job); err != nil {
+		return "", fmt.Errorf("unable to archive the cluster's metadata: %v", err)
+	}
+	msg := fmt.Sprintf("archived the metadata for `%s`; the job's artifacts remain available after the teardown", name)
+	if len(job.URL) > 0 {
+		msg += fmt.Sprintf(" via <%s|the job page>", job.URL)
+	}
+	return msg, nil
+}
@@ -0,0 +1,59 @@
+package manager
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ExplainJobRequest describes in plain language what a launch request would do
+// without starting anything. It is powered by the same resolution code as
+// LaunchJobForUser but is read-only.
+func (m *jobManager) ExplainJobRequest(req *JobRequest) (string, error) {
+	job, err := m.resolveToJob(req)
+	if err != nil {
+		return "", err
+	}
+	prowJob, err := m.findMatchingProwJob(req, job)
+	if err != nil {
+		return "", err
+	}
+	buildCluster, err := m.schedule(prowJob)
+	if err != nil {
+		buildCluster = prowJob.Spec.Cluster
+	}
+
+	buf := &strings.Builder{}
+	fmt.Fprintf(buf, "this `%s` request would:\n", job.Mode)
+	for _, input := range job.Inputs {
+		switch {
+		case len(input.Version) > 0:
+			fmt.Fprintf(buf, "• install version `%s`\n", input.Version)
+		case len(input.Image) > 0:
+			fmt.Fprintf(buf, "• install the release image `%s`\n", input.Image)
+		}
+		for _, ref := range input.Refs {
+			for _, pull := range ref.Pulls {
+				fmt.Fprintf(buf, "• build and include <https://github.com/%s/%s/pull/%d|%s/%s#%d>\n", ref.Org, ref.Repo, pull.Number, ref.Org, ref.Repo, pull.Number)
+			}
+		}
+	}
+	fmt.Fprintf(buf, "• run prow job `%s` for the `%s` platform on `%s` architecture\n", prowJob.Spec.Job, job.Platform, job.Architecture)
+	fmt.Fprintf(buf, "• schedule onto build cluster `%s`\n", buildCluster)
+	if len(job.WorkflowName) > 0 {
+		fmt.Fprintf(buf, "• use the `%s` workflow\n", job.WorkflowName)
+	}
+	if s := paramsToString(job.JobParams); len(s) > 0 {
+		fmt.Fprintf(buf, "• apply job parameters `%s`\n", s)
+	}
+	if job.Mode == JobTypeLaunch || job.Mode == JobTypeWorkflowLaunch {
+		// prefer the history for this platform and version over the global median
+		estimate := m.typicalStartDuration(job)
+		if estimate == 0 {
+			estimate = m.estimateCompletion(time.Time{})
+		}
+		fmt.Fprintf(buf, "• deliver credentials in approximately %d minutes and shut the cluster down after %d hours\n", estimate/time.Minute, int(m.maxAge.Hours()))
+	}
+	fmt.Fprintf(buf, "\nnothing has been started - rerun this as a real command to launch it")
+	return buf.String(), nil
+}
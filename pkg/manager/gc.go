@@ -0,0 +1,76 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+
+	botapiv1 "github.com/openshift/ci-chat-bot/pkg/prow/apiv1"
+	"github.com/openshift/ci-chat-bot/pkg/utils"
+)
+
+// The sync loop only tracks ProwJobs carrying the launch label, so a job
+// whose label was stripped by a manual edit - or left behind by an older
+// bot version - keeps running cloud resources with no owner, no expiry
+// enforcement, and no way for its requester to act on it. The GC loop
+// finds jobs that carry the bot's annotations but no manager record:
+// still-valid ones are re-adopted by restoring the label, expired ones are
+// torn down, and every action is reported to the admin channel.
+
+// gcOrphanedJobs reconciles bot-annotated ProwJobs the sync loop cannot see.
+func (m *jobManager) gcOrphanedJobs() error {
+	prowjobs, err := m.prowLister.ProwJobs(m.prowNamespace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, pj := range prowjobs {
+		if pj.Labels[utils.LaunchLabel] == "true" {
+			// tracked by the regular sync
+			continue
+		}
+		trace := botapiv1.FromObject(&pj.ObjectMeta)
+		if len(trace.Requester) == 0 {
+			// not a bot job
+			continue
+		}
+		if pj.Status.CompletionTime != nil {
+			// already finished; there is nothing left to release
+			continue
+		}
+		if trace.ExpiresSeconds > 0 && now.After(pj.CreationTimestamp.Add(time.Duration(trace.ExpiresSeconds)*time.Second)) {
+			if err := m.stopJob(pj.Name, pj.Spec.Cluster); err != nil {
+				klog.Warningf("Failed to tear down orphaned job %s: %v", pj.Name, err)
+				continue
+			}
+			klog.Infof("Garbage collected orphaned job %s (requested by %s): past its expiry with no manager record", pj.Name, trace.Requester)
+			m.notifyAdmin(fmt.Sprintf("garbage collected orphaned job `%s` (requested by <@%s>): it was past its expiry but carried no manager record", pj.Name, trace.Requester))
+			continue
+		}
+		// the job is still within its lifetime; restore the launch label so
+		// the next sync re-adopts it and its owner can use it again
+		patch := []byte(fmt.Sprintf(`{"metadata":{"labels":{"%s":"true"}}}`, utils.LaunchLabel))
+		if _, err := m.prowClient.ProwJobs(m.prowNamespace).Patch(context.TODO(), pj.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			klog.Warningf("Failed to re-adopt orphaned job %s: %v", pj.Name, err)
+			continue
+		}
+		klog.Infof("Re-adopted orphaned job %s (requested by %s)", pj.Name, trace.Requester)
+		m.notifyAdmin(fmt.Sprintf("re-adopted orphaned job `%s` (requested by <@%s>): it carried the bot's annotations but no manager record", pj.Name, trace.Requester))
+	}
+	return nil
+}
+
+// notifyAdmin posts to the admin channel when one is configured.
+func (m *jobManager) notifyAdmin(message string) {
+	m.lock.Lock()
+	notifier := m.adminNotifierFn
+	m.lock.Unlock()
+	if notifier != nil {
+		notifier(message)
+	}
+}
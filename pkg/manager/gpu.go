@@ -0,0 +1,192 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AI and GPU enablement teams hand-roll the same setup on every bot cluster:
+// a machine set with a GPU-carrying instance type, then the NFD and NVIDIA
+// GPU operator subscriptions. The `gpu=1xA10G` launch option (and the same
+// spec through `add machinepool`) does both - the bot resolves the GPU model
+// to the right instance type for the cluster's platform, clones a worker
+// machine set into a GPU pool, and subscribes the operators so the nodes
+// come up ready for enablement.
+
+// GpuParam is the launch option requesting a GPU machine pool, as
+// `<count>x<model>`, e.g. `gpu=1xA10G`.
+const GpuParam = "gpu"
+
+// maxGpuPoolSize caps how many GPU machines a single pool may request, since
+// GPU instances are the most expensive shapes the quota covers.
+const maxGpuPoolSize = 4
+
+// gpuInstanceTypes maps a GPU model to the instance type that carries it on
+// each platform the model is offered on.
+var gpuInstanceTypes = map[string]map[string]string{
+	"a10g": {"aws": "g5.2xlarge"},
+	"a10":  {"azure": "Standard_NV36ads_A10_v5"},
+	"t4":   {"aws": "g4dn.xlarge", "azure": "Standard_NC4as_T4_v3"},
+	"v100": {"aws": "p3.2xlarge"},
+	"a100": {"aws": "p4d.24xlarge", "gcp": "a2-highgpu-1g"},
+}
+
+// SupportedGpuModels lists the selectable GPU models for help and error text.
+func SupportedGpuModels() []string {
+	models := make([]string, 0, len(gpuInstanceTypes))
+	for model := range gpuInstanceTypes {
+		models = append(models, model)
+	}
+	sort.Strings(models)
+	return models
+}
+
+// parseGpuSpec reads a `<count>x<model>` GPU pool spec such as `1xA10G`.
+func parseGpuSpec(spec string) (int32, string, error) {
+	parts := strings.SplitN(strings.ToLower(spec), "x", 2)
+	if len(parts) != 2 {
+		return 0, "", fmt.Errorf("the GPU pool must be specified as `<count>x<model>`, e.g. `1xA10G`")
+	}
+	count, err := strconv.Atoi(parts[0])
+	if err != nil || count < 1 || count > maxGpuPoolSize {
+		return 0, "", fmt.Errorf("the GPU machine count must be between 1 and %d", maxGpuPoolSize)
+	}
+	model := parts[1]
+	if _, ok := gpuInstanceTypes[model]; !ok {
+		return 0, "", fmt.Errorf("unknown GPU model `%s`, choose from: %s", model, strings.Join(SupportedGpuModels(), ", "))
+	}
+	return int32(count), model, nil
+}
+
+// ValidateGpuSpec reports whether a `gpu` option value is well-formed; it is
+// checked at parse time so a bad spec fails the launch rather than a cluster
+// coming up without its GPUs.
+func ValidateGpuSpec(spec string) error {
+	_, _, err := parseGpuSpec(spec)
+	return err
+}
+
+// AddGpuPool creates a GPU machine pool on the caller's running cluster and
+// subscribes the NVIDIA enablement operators.
+func (m *jobManager) AddGpuPool(user, spec string) (string, error) {
+	count, model, err := parseGpuSpec(spec)
+	if err != nil {
+		return "", err
+	}
+	client, job, err := m.launchedClusterConfigClient(user)
+	if err != nil {
+		return "", err
+	}
+	return createGpuPool(context.TODO(), client, job, count, model)
+}
+
+// createGpuPool resolves the model to the platform's instance type, clones a
+// worker machine set into the pool, and best-effort subscribes the NFD and
+// NVIDIA GPU operators.
+func createGpuPool(ctx context.Context, client ctrlruntimeclient.Client, job *Job, count int32, model string) (string, error) {
+	instanceType, ok := gpuInstanceTypes[model][job.Platform]
+	if !ok {
+		var platforms []string
+		for platform := range gpuInstanceTypes[model] {
+			platforms = append(platforms, platform)
+		}
+		sort.Strings(platforms)
+		return "", fmt.Errorf("`%s` GPUs are not offered on `%s`; launch on one of: %s", model, job.Platform, strings.Join(platforms, ", "))
+	}
+	name := fmt.Sprintf("chat-bot-gpu-%s", model)
+	if err := createMachinePool(ctx, client, job.Platform, name, instanceType, count); err != nil {
+		return "", err
+	}
+	msg := fmt.Sprintf("created GPU machine pool `%s` with %d `%s` machine(s) on `%s` - GPU instances take several minutes to provision and join", name, count, instanceType, job.Name)
+	if err := subscribeNvidiaOperators(ctx, client); err != nil {
+		klog.Warningf("Failed to subscribe the NVIDIA operators on %s: %v", job.Name, err)
+		msg += fmt.Sprintf("; I could not subscribe the NFD and NVIDIA GPU operators (%v), so install them from OperatorHub yourself", err)
+	} else {
+		msg += "; the NFD and NVIDIA GPU operators are subscribing - once they install, create their default `NodeFeatureDiscovery` and `ClusterPolicy` resources to finish enablement"
+	}
+	return msg, nil
+}
+
+// subscribeNvidiaOperators creates the OLM subscriptions for the Node Feature
+// Discovery operator and the NVIDIA GPU operator, the two halves of GPU
+// enablement. The operators' custom resources are left to the user, since
+// their CRDs only exist once the installs finish.
+func subscribeNvidiaOperators(ctx context.Context, client ctrlruntimeclient.Client) error {
+	if err := subscribeOperator(ctx, client, "openshift-nfd", "nfd", "redhat-operators", "stable"); err != nil {
+		return err
+	}
+	return subscribeOperator(ctx, client, "nvidia-gpu-operator", "gpu-operator-certified", "certified-operators", "stable")
+}
+
+// subscribeOperator creates the namespace, operator group, and OLM
+// subscription that install an operator from a marketplace catalog. The
+// objects go in as unstructured so the bot does not carry the OLM API types.
+func subscribeOperator(ctx context.Context, client ctrlruntimeclient.Client, namespace, name, catalog, channel string) error {
+	for _, object := range []*unstructured.Unstructured{
+		{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Namespace",
+			"metadata":   map[string]interface{}{"name": namespace},
+		}},
+		{Object: map[string]interface{}{
+			"apiVersion": "operators.coreos.com/v1",
+			"kind":       "OperatorGroup",
+			"metadata":   map[string]interface{}{"name": name, "namespace": namespace},
+			"spec": map[string]interface{}{
+				"targetNamespaces": []interface{}{namespace},
+			},
+		}},
+		{Object: map[string]interface{}{
+			"apiVersion": "operators.coreos.com/v1alpha1",
+			"kind":       "Subscription",
+			"metadata":   map[string]interface{}{"name": name, "namespace": namespace},
+			"spec": map[string]interface{}{
+				"name":            name,
+				"channel":         channel,
+				"source":          catalog,
+				"sourceNamespace": "openshift-marketplace",
+			},
+		}},
+	} {
+		if err := client.Create(ctx, object); err != nil && !k8serrors.IsAlreadyExists(err) {
+			return fmt.Errorf("unable to create the %s `%s`: %v", strings.ToLower(object.GetKind()), name, err)
+		}
+	}
+	return nil
+}
+
+// configureClusterGPU creates the GPU machine pool a `gpu=` launch option
+// asked for once the cluster is up. Like the OIDC setup it is best-effort -
+// the cluster is still usable without its GPUs - so errors are surfaced in
+// the credential notice rather than failing the launch.
+func (m *jobManager) configureClusterGPU(job *Job) {
+	if len(job.GpuPool) == 0 {
+		return
+	}
+	count, model, err := parseGpuSpec(job.GpuPool)
+	if err != nil {
+		klog.Errorf("job %q: invalid GPU pool spec %q: %v", job.Name, job.GpuPool, err)
+		return
+	}
+	client, err := clusterConfigClient(job)
+	if err != nil {
+		klog.Errorf("job %q: unable to connect for GPU pool setup: %v", job.Name, err)
+		return
+	}
+	msg, err := createGpuPool(context.TODO(), client, job, count, model)
+	if err != nil {
+		klog.Errorf("job %q: unable to create the GPU pool: %v", job.Name, err)
+		job.PasswordSnippet += fmt.Sprintf("\nI could not create the `%s` GPU pool you asked for: %v", job.GpuPool, err)
+		return
+	}
+	klog.Infof("Created GPU pool %s on cluster for job %q", job.GpuPool, job.Name)
+	job.PasswordSnippet += fmt.Sprintf("\n%s", msg)
+}
@@ -0,0 +1,37 @@
+package manager
+
+import "testing"
+
+func TestParseGpuSpec(t *testing.T) {
+	testCases := []struct {
+		spec          string
+		expectedCount int32
+		expectedModel string
+		expectErr     bool
+	}{
+		{spec: "1xA10G", expectedCount: 1, expectedModel: "a10g"},
+		{spec: "4xt4", expectedCount: 4, expectedModel: "t4"},
+		{spec: "2xA100", expectedCount: 2, expectedModel: "a100"},
+		{spec: "0xA10G", expectErr: true},
+		{spec: "5xA10G", expectErr: true},
+		{spec: "1xRTX4090", expectErr: true},
+		{spec: "A10G", expectErr: true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.spec, func(t *testing.T) {
+			count, model, err := parseGpuSpec(testCase.spec)
+			if testCase.expectErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %dx%s", count, model)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if count != testCase.expectedCount || model != testCase.expectedModel {
+				t.Errorf("expected %dx%s, got %dx%s", testCase.expectedCount, testCase.expectedModel, count, model)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+
+	botapiv1 "github.com/openshift/ci-chat-bot/pkg/prow/apiv1"
+)
+
+// SpokesParam is the launch option that provisions N additional spoke
+// clusters alongside the hub cluster in one request. The hub and its spokes
+// run on the same build cluster so workflows that join them (e.g. importing
+// spokes into ACM on the hub) can reach each other's services, and they are
+// tracked as one group: `done` on the hub tears down the spokes too.
+const SpokesParam = "spokes"
+
+// maxSpokes bounds how many spoke clusters one request may provision.
+const maxSpokes = 3
+
+// spokeOwnerPrefix marks owner identities that track spoke members of a
+// hub/spoke group rather than users.
+const spokeOwnerPrefix = "spoke:"
+
+// spokeOwner returns the owner identity used to track the i-th spoke of the
+// group anchored by the named hub job.
+func spokeOwner(hubName string, i int) string {
+	return fmt.Sprintf("%s%s:%d", spokeOwnerPrefix, hubName, i)
+}
+
+// IsSpokeOwner reports whether the owner identity tracks a spoke cluster.
+func IsSpokeOwner(owner string) bool {
+	return strings.HasPrefix(owner, spokeOwnerPrefix)
+}
+
+// parseSpokeCount validates the value of the spokes option.
+func parseSpokeCount(value string) (int, error) {
+	if len(value) == 0 {
+		return 0, nil
+	}
+	count, err := strconv.Atoi(value)
+	if err != nil || count < 1 || count > maxSpokes {
+		return 0, fmt.Errorf("%s must be a number between 1 and %d", SpokesParam, maxSpokes)
+	}
+	return count, nil
+}
+
+// launchSpokes provisions count spoke clusters pinned to the hub's build
+// cluster and tracked under the hub's group. Joining the spokes to the hub is
+// left to the chosen workflow; the bot guarantees co-location, delivers each
+// kubeconfig as it becomes ready, and ties the lifecycles together. On error
+// the spokes launched so far keep running and are still torn down with the
+// hub. Returns the names of the spokes launched.
+func (m *jobManager) launchSpokes(req *JobRequest, hub *Job, count int) ([]string, error) {
+	var names []string
+	for i := 1; i <= count; i++ {
+		params := make(map[string]string, len(req.JobParams))
+		for k, v := range req.JobParams {
+			params[k] = v
+		}
+		spokeReq := &JobRequest{
+			OriginalMessage: req.OriginalMessage,
+			User:            spokeOwner(hub.Name, i),
+			UserName:        req.UserName,
+			Inputs:          req.Inputs,
+			Type:            req.Type,
+			Platform:        req.Platform,
+			WorkflowName:    req.WorkflowName,
+			Channel:         req.Channel,
+			CorrelationID:   req.CorrelationID,
+			JobParams:       params,
+			Architecture:    req.Architecture,
+		}
+		spoke, err := m.resolveToJob(spokeReq)
+		if err != nil {
+			return names, err
+		}
+		prowJob, err := m.findMatchingProwJob(spokeReq, spoke)
+		if err != nil {
+			return names, err
+		}
+		spoke.JobName = prowJob.Spec.Job
+		spoke.BuildCluster = hub.BuildCluster
+		spoke.UseSecondaryAccount = hub.UseSecondaryAccount
+		spoke.GroupID = hub.Name
+		spoke.ExpiresAt = hub.ExpiresAt
+
+		m.lock.Lock()
+		m.requests[spokeReq.User] = spokeReq
+		m.jobs[spoke.Name] = spoke
+		m.lock.Unlock()
+
+		if _, err := m.newJob(spoke); err != nil {
+			m.lock.Lock()
+			delete(m.requests, spokeReq.User)
+			delete(m.jobs, spoke.Name)
+			m.lock.Unlock()
+			return names, fmt.Errorf("spoke %d cannot be started: %v", i, err)
+		}
+		klog.Infof("Job %q starting spoke cluster %d of %d for hub %q", spoke.Name, i, count, hub.Name)
+		go m.handleJobStartup(*spoke, "start")
+		names = append(names, spoke.Name)
+	}
+	return names, nil
+}
+
+// ExtendJobExpiry pushes back the expiry of the named cluster by the given
+// duration, persisted via the expires annotation on its ProwJob so the
+// extension survives a bot restart. When name is a group ID (the hub job's
+// name) every cluster in the group is extended together.
+func (m *jobManager) ExtendJobExpiry(requester, name string, extension time.Duration) (string, error) {
+	if extension <= 0 {
+		return "", fmt.Errorf("the extension must be a positive duration, e.g. `2h`")
+	}
+	if extension > 12*time.Hour {
+		return "", fmt.Errorf("the extension may not exceed 12h")
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	job, ok := m.jobs[name]
+	if !ok {
+		return "", fmt.Errorf("no job or group named `%s` was found", name)
+	}
+	if job.Mode != JobTypeLaunch && job.Mode != JobTypeWorkflowLaunch {
+		return "", fmt.Errorf("only cluster launches can be extended")
+	}
+	if job.Complete {
+		return "", fmt.Errorf("cluster `%s` is already shutting down", name)
+	}
+	if job.RequestedBy != requester && job.RequesterUserID != requester {
+		return "", fmt.Errorf("only the user who launched `%s` may extend it", name)
+	}
+	targets := []*Job{job}
+	for _, other := range m.jobs {
+		if other != nil && other.Name != name && other.GroupID == name && !other.Complete {
+			targets = append(targets, other)
+		}
+	}
+	var extended int
+	var newExpiry time.Time
+	for _, target := range targets {
+		expiry := target.ExpiresAt.Add(extension)
+		expires := int(expiry.Sub(target.RequestedAt).Seconds())
+		patch := []byte(fmt.Sprintf(`{"metadata":{"annotations":{"%s":"%d"}}}`, botapiv1.AnnotationExpires, expires))
+		if _, err := m.prowClient.ProwJobs(m.prowNamespace).Patch(context.TODO(), target.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			klog.Warningf("Failed to extend expiry of job %q: %v", target.Name, err)
+			continue
+		}
+		target.ExpiresAt = expiry
+		if target.Name == name {
+			newExpiry = expiry
+		}
+		extended++
+	}
+	if extended == 0 {
+		return "", fmt.Errorf("the expiry could not be extended, see the bot logs")
+	}
+	if len(targets) > 1 {
+		return fmt.Sprintf("extended %d cluster(s) in the group by %s; the group will now be torn down in %d minutes", extended, extension, int(time.Until(newExpiry)/time.Minute)), nil
+	}
+	return fmt.Sprintf("extended cluster `%s` by %s; it will now be torn down in %d minutes", name, extension, int(time.Until(newExpiry)/time.Minute)), nil
+}
+
+// groupMember identifies a spoke tracked under a hub job.
+type groupMember struct {
+	name    string
+	cluster string
+	owner   string
+}
+
+// groupMembers returns the spokes launched alongside the named hub job.
+func (m *jobManager) groupMembers(hubName string) []groupMember {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var members []groupMember
+	for _, job := range m.jobs {
+		if job != nil && job.GroupID == hubName && job.Name != hubName {
+			members = append(members, groupMember{name: job.Name, cluster: job.BuildCluster, owner: job.RequestedBy})
+		}
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].name < members[j].name })
+	return members
+}
@@ -0,0 +1,205 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/ci-chat-bot/pkg/utils"
+)
+
+// JobHistorySecretName is the secret used to persist metadata of recently
+// destroyed clusters so they can be relaunched. Like the rosa cluster tracking
+// secret, it lives in the bot's namespace and each data key is a job name.
+var JobHistorySecretName = "ci-chat-bot-job-history"
+
+// jobHistoryRetention is how long metadata of a destroyed cluster is kept
+// before it can no longer be relaunched.
+const jobHistoryRetention = 7 * 24 * time.Hour
+
+// archivedJob captures everything needed to re-create an identical cluster
+// after the original job has been torn down.
+type archivedJob struct {
+	User            string            `json:"user"`
+	UserName        string            `json:"userName,omitempty"`
+	OriginalMessage string            `json:"originalMessage,omitempty"`
+	Platform        string            `json:"platform"`
+	Mode            string            `json:"mode"`
+	Inputs          [][]string        `json:"inputs,omitempty"`
+	JobParams       map[string]string `json:"jobParams,omitempty"`
+	Architecture    string            `json:"architecture,omitempty"`
+	WorkflowName    string            `json:"workflowName,omitempty"`
+	URL             string            `json:"url,omitempty"`
+	Failure         string            `json:"failure,omitempty"`
+	RequestedAt     time.Time         `json:"requestedAt,omitempty"`
+	ArchivedAt      time.Time         `json:"archivedAt"`
+}
+
+// jobInputStrings converts resolved job inputs back into the raw input strings
+// a user would have typed, so the archived request can be fed back through
+// LaunchJobForUser.
+func jobInputStrings(inputs []JobInput) [][]string {
+	var inputStrings [][]string
+	for _, input := range inputs {
+		var current []string
+		switch {
+		case len(input.Version) > 0:
+			current = append(current, input.Version)
+		case len(input.Image) > 0:
+			current = append(current, input.Image)
+		}
+		for _, ref := range input.Refs {
+			for _, pull := range ref.Pulls {
+				current = append(current, fmt.Sprintf("%s/%s#%d", ref.Org, ref.Repo, pull.Number))
+			}
+		}
+		if len(current) > 0 {
+			inputStrings = append(inputStrings, current)
+		}
+	}
+	return inputStrings
+}
+
+// archiveJob stores the metadata of a torn-down cluster so the user can
+// relaunch an identical one. Entries past the retention window are pruned on
+// every write.
+func (m *jobManager) archiveJob(job *Job) error {
+	entry := archivedJob{
+		User:            job.RequestedBy,
+		UserName:        job.RequesterUserID,
+		OriginalMessage: job.OriginalMessage,
+		Platform:        job.Platform,
+		Mode:            job.Mode,
+		Inputs:          jobInputStrings(job.Inputs),
+		JobParams:       job.JobParams,
+		Architecture:    job.Architecture,
+		WorkflowName:    job.WorkflowName,
+		URL:             job.URL,
+		Failure:         job.Failure,
+		RequestedAt:     job.RequestedAt,
+		ArchivedAt:      time.Now(),
+	}
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return utils.UpdateSecret(JobHistorySecretName, m.rosaSecretClient, func(secret *corev1.Secret) {
+		for name, data := range secret.Data {
+			var existing archivedJob
+			if err := json.Unmarshal(data, &existing); err != nil || time.Since(existing.ArchivedAt) > jobHistoryRetention {
+				delete(secret.Data, name)
+			}
+		}
+		secret.Data[job.Name] = raw
+	})
+}
+
+// jobHistoryListLimit caps how many past launches the history command renders.
+const jobHistoryListLimit = 10
+
+// JobHistory renders the user's most recent launches from the archived job
+// store, newest first.
+func (m *jobManager) JobHistory(user string) (string, error) {
+	secret, err := m.rosaSecretClient.Get(context.TODO(), JobHistorySecretName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return "you have no recorded launches yet", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("Failed to get `%s` secret: %w", JobHistorySecretName, err)
+	}
+	type namedEntry struct {
+		name  string
+		entry archivedJob
+	}
+	var entries []namedEntry
+	for name, raw := range secret.Data {
+		var entry archivedJob
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			continue
+		}
+		if entry.User != user || time.Since(entry.ArchivedAt) > jobHistoryRetention {
+			continue
+		}
+		entries = append(entries, namedEntry{name: name, entry: entry})
+	}
+	if len(entries) == 0 {
+		return "you have no recorded launches yet", nil
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].entry.ArchivedAt.After(entries[j].entry.ArchivedAt)
+	})
+	if len(entries) > jobHistoryListLimit {
+		entries = entries[:jobHistoryListLimit]
+	}
+	buf := &strings.Builder{}
+	fmt.Fprintf(buf, "your last %d launch(es), newest first - relaunch one with `relaunch <job_id>`:\n", len(entries))
+	for _, item := range entries {
+		entry := item.entry
+		var inputs []string
+		for _, input := range entry.Inputs {
+			inputs = append(inputs, strings.Join(input, ","))
+		}
+		description := strings.Join(inputs, " to ")
+		if len(description) == 0 {
+			description = "default version"
+		}
+		outcome := "torn down normally"
+		if len(entry.Failure) > 0 && entry.Failure != "deletion requested" {
+			outcome = entry.Failure
+		}
+		fmt.Fprintf(buf, "• `%s` - %s on %s/%s", item.name, description, entry.Platform, entry.Architecture)
+		if !entry.RequestedAt.IsZero() {
+			fmt.Fprintf(buf, ", ran for %d minutes", int(entry.ArchivedAt.Sub(entry.RequestedAt)/time.Minute))
+		}
+		fmt.Fprintf(buf, ", %s", outcome)
+		if len(entry.URL) > 0 {
+			fmt.Fprintf(buf, " (<%s|artifacts>)", entry.URL)
+		}
+		fmt.Fprintf(buf, "\n")
+	}
+	return buf.String(), nil
+}
+
+// RelaunchJob re-creates a cluster identical to a recently destroyed one by
+// replaying its archived request through the normal launch path.
+func (m *jobManager) RelaunchJob(user, channel, jobName string) (string, error) {
+	secret, err := m.rosaSecretClient.Get(context.TODO(), JobHistorySecretName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return "", fmt.Errorf("no recently destroyed cluster named `%s` was found", jobName)
+	}
+	if err != nil {
+		return "", fmt.Errorf("Failed to get `%s` secret: %w", JobHistorySecretName, err)
+	}
+	raw, ok := secret.Data[jobName]
+	if !ok {
+		return "", fmt.Errorf("no recently destroyed cluster named `%s` was found - metadata is only kept for %d days", jobName, int(jobHistoryRetention.Hours()/24))
+	}
+	var entry archivedJob
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return "", fmt.Errorf("Failed to unmarshal archived job metadata: %w", err)
+	}
+	if entry.User != user {
+		return "", fmt.Errorf("only the user who requested `%s` can relaunch it", jobName)
+	}
+	req := &JobRequest{
+		OriginalMessage: entry.OriginalMessage,
+		User:            user,
+		UserName:        entry.UserName,
+		Inputs:          entry.Inputs,
+		Type:            JobType(entry.Mode),
+		Platform:        entry.Platform,
+		WorkflowName:    entry.WorkflowName,
+		Channel:         channel,
+		RequestedAt:     time.Now(),
+		JobParams:       entry.JobParams,
+		Architecture:    entry.Architecture,
+	}
+	return m.LaunchJobForUser(req)
+}
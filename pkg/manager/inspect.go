@@ -0,0 +1,208 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	imagev1 "github.com/openshift/api/image/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog"
+
+	"github.com/openshift/ci-chat-bot/pkg/catalog/registry"
+)
+
+// defaultInspectComponents is shown when the user does not name specific
+// components; the full payload has well over a hundred tags and would flood
+// the channel.
+var defaultInspectComponents = []string{
+	"cluster-version-operator",
+	"installer",
+	"machine-config-operator",
+	"kube-apiserver",
+	"etcd",
+	"cluster-network-operator",
+}
+
+// InspectPayload resolves the argument to a release payload, extracts its
+// image-references manifest, and reports the commits the selected component
+// images were built from - the same data `oc adm release info` shows - so a
+// user can confirm their PR's commit made it into the payload before
+// launching it.
+func (m *jobManager) InspectPayload(imageOrVersion string, components []string) (string, error) {
+	refs, image, version, err := m.releaseImageReferences(imageOrVersion)
+	if err != nil {
+		return "", err
+	}
+
+	selected := sets.New[string](components...)
+	if selected.Len() == 0 {
+		selected = sets.New[string](defaultInspectComponents...)
+	}
+	if len(version) == 0 {
+		version = refs.Name
+	}
+	lines := []string{fmt.Sprintf("release payload `%s` (`%s`) was built from:", version, image)}
+	found := sets.New[string]()
+	for _, tag := range refs.Spec.Tags {
+		if !selected.Has(tag.Name) {
+			continue
+		}
+		found.Insert(tag.Name)
+		commit := tag.Annotations["io.openshift.build.commit.id"]
+		source := tag.Annotations["io.openshift.build.source-location"]
+		switch {
+		case len(commit) > 0 && len(source) > 0:
+			lines = append(lines, fmt.Sprintf("• `%s`: <%s/commit/%s|%s> from %s", tag.Name, source, commit, shortCommit(commit), source))
+		case len(commit) > 0:
+			lines = append(lines, fmt.Sprintf("• `%s`: commit %s", tag.Name, shortCommit(commit)))
+		default:
+			lines = append(lines, fmt.Sprintf("• `%s`: no build commit recorded", tag.Name))
+		}
+	}
+	if missing := selected.Difference(found); missing.Len() > 0 {
+		lines = append(lines, fmt.Sprintf("no component named %s in this payload (%d components total)", strings.Join(sets.List(missing), ", "), len(refs.Spec.Tags)))
+	}
+	if len(components) == 0 {
+		lines = append(lines, "_showing a default selection; name components explicitly to inspect others, e.g. `inspect nightly installer,oauth-server`_")
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// releaseImageReferences resolves the argument to a release payload and
+// returns its parsed image-references manifest along with the payload
+// pullspec and version.
+func (m *jobManager) releaseImageReferences(imageOrVersion string) (*imagev1.ImageStream, string, string, error) {
+	image, version, _, err := m.ResolveImageOrVersion(imageOrVersion, "nightly", "amd64")
+	if err != nil {
+		return nil, "", "", err
+	}
+	if len(image) == 0 {
+		return nil, "", "", fmt.Errorf("unable to resolve %q to a release payload", imageOrVersion)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	// the payload image only carries the release manifests, so extracting it
+	// is cheap compared to pulling component images
+	dir, err := registry.ExtractBundleImage(ctx, nil, image, false, false, false)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("unable to extract release payload %s: %v", image, err)
+	}
+	defer os.RemoveAll(dir)
+
+	raw, err := os.ReadFile(filepath.Join(dir, "release-manifests", "image-references"))
+	if err != nil {
+		return nil, "", "", fmt.Errorf("%s does not look like a release payload, no image-references manifest found: %v", image, err)
+	}
+	var refs imagev1.ImageStream
+	if err := json.Unmarshal(raw, &refs); err != nil {
+		return nil, "", "", fmt.Errorf("unable to parse the image-references manifest of %s: %v", image, err)
+	}
+	return &refs, image, version, nil
+}
+
+// pullRequestNumbers matches the PR references GitHub records in merge and
+// squash-merge commit subjects.
+var pullRequestNumbers = regexp.MustCompile(`(?:Merge pull request |\()#(\d+)`)
+
+// ImageInfo reports the source repository, commit, and associated pull
+// requests of one component image of a release payload, answering "does this
+// nightly contain my fix?" without launching anything.
+func (m *jobManager) ImageInfo(imageOrVersion, component string) (string, error) {
+	refs, _, version, err := m.releaseImageReferences(imageOrVersion)
+	if err != nil {
+		return "", err
+	}
+	var tag *imagev1.TagReference
+	for i := range refs.Spec.Tags {
+		if refs.Spec.Tags[i].Name == component {
+			tag = &refs.Spec.Tags[i]
+			break
+		}
+	}
+	if tag == nil {
+		return "", fmt.Errorf("no component named `%s` in this payload (%d components total); use `inspect` to list components", component, len(refs.Spec.Tags))
+	}
+
+	commit := tag.Annotations["io.openshift.build.commit.id"]
+	source := tag.Annotations["io.openshift.build.source-location"]
+	var pullspec string
+	if tag.From != nil {
+		pullspec = tag.From.Name
+	}
+	// the manifest annotations are derived from the image labels at build
+	// time; read the labels directly when possible so the answer reflects the
+	// image itself
+	if len(pullspec) > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		labels, err := registry.GetImageLabels(ctx, nil, pullspec, false)
+		cancel()
+		if err != nil {
+			klog.Warningf("Unable to read labels of %s, falling back to payload annotations: %v", pullspec, err)
+		} else {
+			if value := labels["io.openshift.build.commit.id"]; len(value) > 0 {
+				commit = value
+			}
+			if value := labels["io.openshift.build.source-location"]; len(value) > 0 {
+				source = value
+			}
+		}
+	}
+
+	lines := []string{fmt.Sprintf("component `%s` of payload `%s`:", component, version)}
+	if len(pullspec) > 0 {
+		lines = append(lines, fmt.Sprintf("• image: `%s`", pullspec))
+	}
+	if len(source) > 0 {
+		lines = append(lines, fmt.Sprintf("• source: %s", source))
+	}
+	if len(commit) > 0 {
+		if len(source) > 0 {
+			lines = append(lines, fmt.Sprintf("• commit: <%s/commit/%s|%s>", source, commit, shortCommit(commit)))
+		} else {
+			lines = append(lines, fmt.Sprintf("• commit: %s", shortCommit(commit)))
+		}
+	} else {
+		lines = append(lines, "• no build commit recorded for this image")
+	}
+
+	if prs := m.pullRequestsForCommit(source, commit); len(prs) > 0 {
+		lines = append(lines, fmt.Sprintf("• merged via: %s", strings.Join(prs, ", ")))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// pullRequestsForCommit extracts the PR references from the commit's message,
+// which for payload components is a GitHub merge or squash-merge commit.
+func (m *jobManager) pullRequestsForCommit(source, commit string) []string {
+	if m.githubClient == nil || len(commit) == 0 {
+		return nil
+	}
+	parts := strings.Split(strings.TrimPrefix(source, "https://github.com/"), "/")
+	if len(parts) != 2 || parts[0] == source {
+		return nil
+	}
+	repoCommit, err := m.githubClient.GetSingleCommit(parts[0], parts[1], commit)
+	if err != nil {
+		klog.Warningf("Unable to look up commit %s in %s: %v", commit, source, err)
+		return nil
+	}
+	var prs []string
+	for _, match := range pullRequestNumbers.FindAllStringSubmatch(repoCommit.Commit.Message, -1) {
+		prs = append(prs, fmt.Sprintf("<%s/pull/%s|%s/%s#%s>", source, match[1], parts[0], parts[1], match[1]))
+	}
+	return prs
+}
+
+func shortCommit(commit string) string {
+	if len(commit) > 8 {
+		return commit[:8]
+	}
+	return commit
+}
@@ -0,0 +1,187 @@
+package manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver"
+	configv1 "github.com/openshift/api/config/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// The install steps render install-config.yaml from the workflow's defaults,
+// so settings the bot has no dedicated option for - custom CIDRs, etcd
+// encryption, capability trimming - are applied as a user-supplied patch: a
+// YAML mapping is handed to the workflow as a strategic-merge patch via
+// INSTALL_CONFIG_OVERRIDE, and a YAML list of {op, path, value} operations
+// as a JSON6902 patch via INSTALL_CONFIG_JSON_PATCH. Users attach the patch
+// as an install-config file or paste it as a code block; see
+// ExtractInstallConfigPatch in pkg/slack. The patch is validated here
+// against the fields the target version's installer accepts, so a typo
+// fails the launch command rather than the install half an hour in.
+
+// InstallConfigParam carries the user's install-config patch; it is set from
+// an attachment or code block rather than an inline option value.
+const InstallConfigParam = "install-config"
+
+// installConfigFields maps the top-level install-config fields to the first
+// minor release whose installer accepts them; an empty value means every
+// version the bot can launch.
+var installConfigFields = map[string]string{
+	"apiVersion":                  "",
+	"metadata":                    "",
+	"additionalTrustBundle":       "",
+	"additionalTrustBundlePolicy": "4.10",
+	"baseDomain":                  "",
+	"bootstrapInPlace":            "",
+	"capabilities":                "4.11",
+	"compute":                     "",
+	"controlPlane":                "",
+	"cpuPartitioningMode":         "4.13",
+	"credentialsMode":             "",
+	"featureGates":                "4.14",
+	"featureSet":                  "4.12",
+	"fips":                        "",
+	"imageContentSources":         "",
+	"imageDigestSources":          "4.13",
+	"networking":                  "",
+	"platform":                    "",
+	"proxy":                       "",
+	"publish":                     "",
+	"pullSecret":                  "",
+	"sshKey":                      "",
+}
+
+// json6902Ops are the operations RFC 6902 defines.
+var json6902Ops = map[string]bool{"add": true, "remove": true, "replace": true, "move": true, "copy": true, "test": true}
+
+// installConfigPatchEnv validates a user-supplied install-config patch
+// against the target version and returns the environment variable and value
+// that hand it to the install workflow.
+func installConfigPatchEnv(patch, version string) (string, string, error) {
+	var ops []struct {
+		Op    string          `json:"op"`
+		Path  string          `json:"path"`
+		Value json.RawMessage `json:"value,omitempty"`
+		From  string          `json:"from,omitempty"`
+	}
+	if err := yaml.Unmarshal([]byte(patch), &ops); err == nil {
+		if len(ops) == 0 {
+			return "", "", fmt.Errorf("the install-config patch is empty")
+		}
+		for i, op := range ops {
+			if !json6902Ops[op.Op] {
+				return "", "", fmt.Errorf("install-config patch operation %d has unknown op %q", i+1, op.Op)
+			}
+			if !strings.HasPrefix(op.Path, "/") {
+				return "", "", fmt.Errorf("install-config patch operation %d has invalid path %q", i+1, op.Path)
+			}
+			field := strings.SplitN(strings.TrimPrefix(op.Path, "/"), "/", 2)[0]
+			if err := checkInstallConfigField(field, version); err != nil {
+				return "", "", err
+			}
+		}
+		encoded, err := json.Marshal(ops)
+		if err != nil {
+			return "", "", err
+		}
+		return "INSTALL_CONFIG_JSON_PATCH", string(encoded), nil
+	}
+
+	var merge map[string]interface{}
+	if err := yaml.Unmarshal([]byte(patch), &merge); err != nil {
+		return "", "", fmt.Errorf("the install-config patch must be a YAML mapping to merge or a list of JSON6902 operations: %v", err)
+	}
+	if len(merge) == 0 {
+		return "", "", fmt.Errorf("the install-config patch is empty")
+	}
+	for field := range merge {
+		if err := checkInstallConfigField(field, version); err != nil {
+			return "", "", err
+		}
+	}
+	encoded, err := json.Marshal(merge)
+	if err != nil {
+		return "", "", err
+	}
+	return "INSTALL_CONFIG_OVERRIDE", string(encoded), nil
+}
+
+// CapabilitiesParam launches a cluster with a trimmed capability set for
+// minimal-footprint testing, e.g. `capabilities=None+marketplace;console`:
+// the part before `+` becomes the install-config baselineCapabilitySet and
+// the rest (semicolon-separated since commas delimit options) the
+// additionalEnabledCapabilities.
+const CapabilitiesParam = "capabilities"
+
+// parseCapabilitiesParam validates a capabilities option against the sets
+// and capability names the openshift/api packages know, matching capability
+// names case-insensitively, and returns the canonical baseline set and
+// additional capabilities.
+func parseCapabilitiesParam(value string) (string, []string, error) {
+	baseline, rest, _ := strings.Cut(value, "+")
+	if _, ok := configv1.ClusterVersionCapabilitySets[configv1.ClusterVersionCapabilitySet(baseline)]; !ok {
+		sets := make([]string, 0, len(configv1.ClusterVersionCapabilitySets))
+		for set := range configv1.ClusterVersionCapabilitySets {
+			sets = append(sets, string(set))
+		}
+		sort.Strings(sets)
+		return "", nil, fmt.Errorf("unknown capability set %q, choose from: %s", baseline, strings.Join(sets, ", "))
+	}
+	var additional []string
+	for _, name := range strings.Split(rest, ";") {
+		name = strings.TrimSpace(name)
+		if len(name) == 0 {
+			continue
+		}
+		var canonical string
+		for _, capability := range configv1.KnownClusterVersionCapabilities {
+			if strings.EqualFold(name, string(capability)) {
+				canonical = string(capability)
+				break
+			}
+		}
+		if len(canonical) == 0 {
+			capabilities := make([]string, 0, len(configv1.KnownClusterVersionCapabilities))
+			for _, capability := range configv1.KnownClusterVersionCapabilities {
+				capabilities = append(capabilities, string(capability))
+			}
+			sort.Strings(capabilities)
+			return "", nil, fmt.Errorf("unknown capability %q, choose from: %s", name, strings.Join(capabilities, ", "))
+		}
+		additional = append(additional, canonical)
+	}
+	sort.Strings(additional)
+	return baseline, additional, nil
+}
+
+// checkInstallConfigField rejects fields the target version's install-config
+// schema does not have.
+func checkInstallConfigField(field, version string) error {
+	minimum, known := installConfigFields[field]
+	if !known {
+		fields := make([]string, 0, len(installConfigFields))
+		for name := range installConfigFields {
+			fields = append(fields, name)
+		}
+		sort.Strings(fields)
+		return fmt.Errorf("install-config has no field %q; valid fields: %s", field, strings.Join(fields, ", "))
+	}
+	if len(minimum) == 0 || len(version) == 0 {
+		return nil
+	}
+	target, err := semver.ParseTolerant(version)
+	if err != nil {
+		return nil
+	}
+	required, err := semver.ParseTolerant(minimum)
+	if err != nil {
+		return nil
+	}
+	if target.Major < required.Major || (target.Major == required.Major && target.Minor < required.Minor) {
+		return fmt.Errorf("install-config field %q requires %s or newer, the requested version is %s", field, minimum, version)
+	}
+	return nil
+}
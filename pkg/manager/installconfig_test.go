@@ -0,0 +1,89 @@
+package manager
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCapabilitiesParam(t *testing.T) {
+	baseline, additional, err := parseCapabilitiesParam("None+marketplace;console")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if baseline != "None" {
+		t.Errorf("expected baseline None, got %s", baseline)
+	}
+	if len(additional) != 2 || additional[0] != "Console" || additional[1] != "marketplace" {
+		t.Errorf("expected canonical [Console marketplace], got %v", additional)
+	}
+	if _, _, err := parseCapabilitiesParam("Minimal"); err == nil || !strings.Contains(err.Error(), "unknown capability set") {
+		t.Errorf("expected unknown capability set error, got: %v", err)
+	}
+	if _, _, err := parseCapabilitiesParam("None+consle"); err == nil || !strings.Contains(err.Error(), "unknown capability") {
+		t.Errorf("expected unknown capability error, got: %v", err)
+	}
+}
+
+func TestInstallConfigPatchEnv(t *testing.T) {
+	testCases := []struct {
+		name        string
+		patch       string
+		version     string
+		expectedEnv string
+		expectedErr string
+	}{
+		{
+			name:        "merge patch",
+			patch:       "networking:\n  machineNetwork:\n  - cidr: 192.168.0.0/16\n",
+			version:     "4.16.0-0.nightly-2026-08-01-000000",
+			expectedEnv: "INSTALL_CONFIG_OVERRIDE",
+		},
+		{
+			name:        "json6902 patch",
+			patch:       "- op: replace\n  path: /capabilities/baselineCapabilitySet\n  value: None\n",
+			version:     "4.16.0",
+			expectedEnv: "INSTALL_CONFIG_JSON_PATCH",
+		},
+		{
+			name:        "unknown field",
+			patch:       "networing:\n  foo: bar\n",
+			version:     "4.16.0",
+			expectedErr: `install-config has no field "networing"`,
+		},
+		{
+			name:        "field newer than target version",
+			patch:       "cpuPartitioningMode: AllNodes\n",
+			version:     "4.12.5",
+			expectedErr: `requires 4.13 or newer`,
+		},
+		{
+			name:        "unknown operation",
+			patch:       "- op: merge\n  path: /networking\n",
+			version:     "4.16.0",
+			expectedErr: `unknown op "merge"`,
+		},
+		{
+			name:        "empty patch",
+			patch:       "{}",
+			version:     "4.16.0",
+			expectedErr: "the install-config patch is empty",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			env, _, err := installConfigPatchEnv(tc.patch, tc.version)
+			if len(tc.expectedErr) > 0 {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("expected error containing %q, got: %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if env != tc.expectedEnv {
+				t.Errorf("expected env %s, got %s", tc.expectedEnv, env)
+			}
+		})
+	}
+}
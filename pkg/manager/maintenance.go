@@ -0,0 +1,116 @@
+package manager
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// weekdaysByName resolves the weekday names used in MaintenanceWindow.Day.
+var weekdaysByName = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// maintenanceOccurrences returns the start and end of the window occurrence
+// nearest to now: the one that started most recently (which may still be
+// open) and the next one to come.
+func maintenanceOccurrences(window MaintenanceWindow, now time.Time, loc *time.Location) (previous, next [2]time.Time, err error) {
+	weekday, ok := weekdaysByName[strings.ToLower(window.Day)]
+	if !ok {
+		return previous, next, fmt.Errorf("unknown weekday %q in maintenance window", window.Day)
+	}
+	start, err := time.ParseInLocation("15:04", window.Start, loc)
+	if err != nil {
+		return previous, next, fmt.Errorf("invalid maintenance window start %q: %w", window.Start, err)
+	}
+	duration, err := time.ParseDuration(window.Duration)
+	if err != nil || duration <= 0 {
+		return previous, next, fmt.Errorf("invalid maintenance window duration %q", window.Duration)
+	}
+	local := now.In(loc)
+	daysUntil := (int(weekday) - int(local.Weekday()) + 7) % 7
+	upcoming := time.Date(local.Year(), local.Month(), local.Day()+daysUntil, start.Hour(), start.Minute(), 0, 0, loc)
+	if upcoming.Before(local) {
+		upcoming = upcoming.AddDate(0, 0, 7)
+	}
+	last := upcoming.AddDate(0, 0, -7)
+	previous = [2]time.Time{last, last.Add(duration)}
+	next = [2]time.Time{upcoming, upcoming.Add(duration)}
+	return previous, next, nil
+}
+
+// maintenanceConfigSnapshot copies the hot-reloaded config under its lock.
+func (m *jobManager) maintenanceConfigSnapshot() (*time.Location, []MaintenanceWindow) {
+	if m.maintenanceConfig == nil {
+		return nil, nil
+	}
+	m.maintenanceConfig.Mutex.RLock()
+	defer m.maintenanceConfig.Mutex.RUnlock()
+	if len(m.maintenanceConfig.Windows) == 0 {
+		return nil, nil
+	}
+	timezone := m.maintenanceConfig.Timezone
+	if len(timezone) == 0 {
+		timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	windows := make([]MaintenanceWindow, len(m.maintenanceConfig.Windows))
+	copy(windows, m.maintenanceConfig.Windows)
+	return loc, windows
+}
+
+// checkMaintenanceWindow refuses new launches while a maintenance window is
+// open. Windows that cannot be parsed are skipped rather than blocking
+// launches.
+func (m *jobManager) checkMaintenanceWindow(now time.Time) error {
+	loc, windows := m.maintenanceConfigSnapshot()
+	if loc == nil {
+		return nil
+	}
+	for _, window := range windows {
+		previous, _, err := maintenanceOccurrences(window, now, loc)
+		if err != nil {
+			continue
+		}
+		if !now.Before(previous[0]) && now.Before(previous[1]) {
+			msg := fmt.Sprintf("new launches are paused for maintenance until %s", previous[1].Format("15:04 MST on Monday"))
+			if len(window.Message) > 0 {
+				msg += ": " + window.Message
+			}
+			return fmt.Errorf("%s", msg)
+		}
+	}
+	return nil
+}
+
+// maintenanceWarning returns a note when a cluster expiring at expiresAt will
+// still be up when a maintenance window opens.
+func (m *jobManager) maintenanceWarning(now, expiresAt time.Time) string {
+	loc, windows := m.maintenanceConfigSnapshot()
+	if loc == nil {
+		return ""
+	}
+	for _, window := range windows {
+		_, next, err := maintenanceOccurrences(window, now, loc)
+		if err != nil {
+			continue
+		}
+		if next[0].Before(expiresAt) {
+			msg := fmt.Sprintf("note: a maintenance window opens at %s, before your cluster expires, and it may be disrupted or torn down", next[0].Format("15:04 MST on Monday"))
+			if len(window.Message) > 0 {
+				msg += " (" + window.Message + ")"
+			}
+			return msg
+		}
+	}
+	return ""
+}
@@ -20,8 +20,11 @@ import (
 	"sigs.k8s.io/prow/pkg/scheduler/strategy"
 
 	"github.com/openshift/ci-chat-bot/pkg/prow"
+	botapiv1 "github.com/openshift/ci-chat-bot/pkg/prow/apiv1"
+	"github.com/openshift/ci-chat-bot/pkg/tracing"
 	"github.com/openshift/ci-chat-bot/pkg/utils"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -65,6 +68,7 @@ func init() {
 	prometheus.MustRegister(rosaReadyToConsoleTimeMetric)
 	prometheus.MustRegister(rosaSyncTimeMetric)
 	prometheus.MustRegister(rosaClustersMetric)
+	prometheus.MustRegister(jobPodRestartsMetric)
 }
 
 const (
@@ -140,25 +144,42 @@ func NewJobManager(
 	dpcrHiveClient crclient.Client,
 	dpcrNamespaceClient typedcorev1.NamespaceInterface,
 	dpcrCoreClient *typedcorev1.CoreV1Client,
+	envPresetURL string,
+	officeHoursConfig *OfficeHoursConfig,
+	costBudgetConfig *CostBudgetConfig,
+	maintenanceConfig *MaintenanceConfig,
+	buildClusterArchitectures map[string]sets.Set[string],
 ) *jobManager {
 	m := &jobManager{
-		requests:         make(map[string]*JobRequest),
-		jobs:             make(map[string]*Job),
-		clusterPrefix:    "chat-bot-",
-		maxClusters:      maxTotalClusters,
-		maxAge:           3 * time.Hour,
-		githubClient:     githubClient,
-		prowConfigLoader: prowConfigLoader,
-		prowClient:       prowClient,
-		prowScheduler:    strategy.Get(prowConfigLoader.Config(), logrus.WithField("interface", "scheduler")),
-		prowLister:       prowInformer.Lister(),
-		imageClient:      imageClient,
-		clusterClients:   buildClusterClientConfigMap,
-		prowNamespace:    "ci",
-		forcePROwner:     forcePROwner,
-
-		configResolver: configResolver,
-		workflowConfig: workflowConfig,
+		requests:           make(map[string]*JobRequest),
+		jobs:               make(map[string]*Job),
+		startHistory:       make(map[string][]time.Duration),
+		queuedLaunches:     make(map[string]*JobRequest),
+		onboardedUsers:     make(map[string]bool),
+		deprecatedLaunches: make(map[string]int),
+		pendingDestroys:    make(map[string]time.Time),
+		graceWindow:        defaultExpiryGraceWindow,
+		clusterPrefix:      "chat-bot-",
+		maxClusters:        maxTotalClusters,
+		maxAge:             3 * time.Hour,
+		githubClient:       githubClient,
+		prowConfigLoader:   prowConfigLoader,
+		prowClient:         prowClient,
+		prowScheduler:      strategy.Get(prowConfigLoader.Config(), logrus.WithField("interface", "scheduler")),
+		prowLister:         prowInformer.Lister(),
+		imageClient:        imageClient,
+		clusterClients:     buildClusterClientConfigMap,
+		prowNamespace:      "ci",
+		forcePROwner:       forcePROwner,
+
+		configResolver:    configResolver,
+		workflowConfig:    workflowConfig,
+		envPresetURL:      envPresetURL,
+		officeHoursConfig: officeHoursConfig,
+		costBudgetConfig:  costBudgetConfig,
+		maintenanceConfig: maintenanceConfig,
+
+		buildClusterArchitectures: buildClusterArchitectures,
 
 		lClient: lClient,
 
@@ -178,7 +199,10 @@ func NewJobManager(
 		dpcrHiveClient:           dpcrHiveClient,
 		dpcrNamespaceClient:      dpcrNamespaceClient,
 	}
+	m.executor = newProwExecutor(prowClient, m.prowNamespace, m.clusterClients)
 	m.muJob.running = make(map[string]struct{})
+	m.officeHoursState.exemptions = make(map[string]string)
+	m.versionWatches.entries = make(map[string]map[string]*versionWatch)
 	initializeErrorMetrics(m.errorMetric)
 	return m
 }
@@ -294,11 +318,32 @@ func (m *jobManager) Start() error {
 			klog.Warningf("error during updateRosaVersions: %v", err)
 		}
 	}, time.Minute*5)
+	go wait.Forever(func() {
+		if err := m.enforceOfficeHours(); err != nil {
+			klog.Warningf("error during office hours enforcement: %v", err)
+		}
+	}, time.Minute)
+	go wait.Forever(func() {
+		if err := m.checkVersionWatches(); err != nil {
+			klog.Warningf("error during checkVersionWatches: %v", err)
+		}
+	}, time.Minute*5)
 	go wait.Forever(func() {
 		if err := m.updateImageSetList(); err != nil {
 			klog.Warningf("error during updateImageSetList: %v", err)
 		}
 	}, time.Minute*5)
+	go wait.Forever(func() {
+		if err := m.reapLeakedResources(); err != nil {
+			klog.Warningf("error during reapLeakedResources: %v", err)
+		}
+	}, time.Hour)
+	go wait.Forever(func() {
+		if err := m.gcOrphanedJobs(); err != nil {
+			klog.Warningf("error during gcOrphanedJobs: %v", err)
+		}
+	}, time.Minute*10)
+	go wait.Forever(m.expirySync, time.Minute)
 	return nil
 }
 
@@ -588,6 +633,8 @@ func (m *jobManager) rosaSync() error {
 	m.rosaClusters.lock.Unlock()
 	m.rosaClusters.lock.RLock()
 
+	m.verifyRosaTeardowns(clustersByID)
+
 	var deletedEntries []string
 	var awsCleanupErrors []error
 	for _, id := range toDelete {
@@ -662,17 +709,22 @@ func (m *jobManager) sync() error {
 		if job.Annotations["ci-chat-bot.openshift.io/HasIndex"] == "true" {
 			hasIndex = true
 		}
+		trace := botapiv1.FromObject(&job.ObjectMeta)
 		j := &Job{
 			Name:             job.Name,
 			State:            job.Status.State,
 			URL:              job.Status.URL,
 			OriginalMessage:  job.Annotations["ci-chat-bot.openshift.io/originalMessage"],
 			Mode:             job.Annotations["ci-chat-bot.openshift.io/mode"],
+			Priority:         job.Annotations["ci-chat-bot.openshift.io/priority"],
+			GpuPool:          job.Annotations["ci-chat-bot.openshift.io/gpu"],
+			WindowsWorkers:   job.Annotations["ci-chat-bot.openshift.io/windows"] == "true",
 			JobName:          job.Spec.Job,
 			Platform:         job.Annotations["ci-chat-bot.openshift.io/platform"],
 			Inputs:           inputs,
-			RequestedBy:      job.Annotations["ci-chat-bot.openshift.io/user"],
-			RequestedChannel: job.Annotations["ci-chat-bot.openshift.io/channel"],
+			RequestedBy:      trace.Requester,
+			RequestedChannel: trace.Channel,
+			CorrelationID:    trace.CorrelationID,
 			RequestedAt:      job.CreationTimestamp.Time,
 			Architecture:     architecture,
 			BuildCluster:     buildCluster,
@@ -690,10 +742,8 @@ func (m *jobManager) sync() error {
 			continue
 		}
 
-		if expirationString := job.Annotations["ci-chat-bot.openshift.io/expires"]; len(expirationString) > 0 {
-			if maxSeconds, err := strconv.Atoi(expirationString); err == nil && maxSeconds > 0 {
-				j.ExpiresAt = job.CreationTimestamp.Add(time.Duration(maxSeconds) * time.Second)
-			}
+		if trace.ExpiresSeconds > 0 {
+			j.ExpiresAt = job.CreationTimestamp.Add(time.Duration(trace.ExpiresSeconds) * time.Second)
 		}
 		if j.ExpiresAt.IsZero() {
 			j.ExpiresAt = job.CreationTimestamp.Time.Add(m.maxAge)
@@ -701,8 +751,18 @@ func (m *jobManager) sync() error {
 		if job.Status.CompletionTime != nil {
 			j.Complete = true
 			j.ExpiresAt = job.Status.CompletionTime.Add(15 * time.Minute)
-		}
-		if j.ExpiresAt.Before(now) {
+			// the sync loop only learns about completion after the fact, so
+			// the span is recorded retroactively over the job's real lifetime
+			if previous == nil || !previous.IsComplete() {
+				tracing.RecordSpan("job-run", job.CreationTimestamp.Time, job.Status.CompletionTime.Time,
+					attribute.String("job", j.Name),
+					attribute.String("state", string(job.Status.State)),
+				)
+			}
+		}
+		// the grace window padding keeps clusters visible while their
+		// two-phase destruction plays out; see expiry.go
+		if j.ExpiresAt.Add(m.graceWindow).Before(now) {
 			continue
 		}
 
@@ -764,6 +824,7 @@ func (m *jobManager) sync() error {
 
 							m.requests[user] = &JobRequest{
 								OriginalMessage: job.Annotations["ci-chat-bot.openshift.io/originalMessage"],
+								CorrelationID:   trace.CorrelationID,
 
 								User:         user,
 								Name:         job.Name,
@@ -772,7 +833,7 @@ func (m *jobManager) sync() error {
 								JobParams:    params,
 								Inputs:       inputStrings,
 								RequestedAt:  job.CreationTimestamp.Time,
-								Channel:      job.Annotations["ci-chat-bot.openshift.io/channel"],
+								Channel:      trace.Channel,
 								Architecture: architecture,
 							}
 						}
@@ -789,8 +850,18 @@ func (m *jobManager) sync() error {
 
 	// forget everything that is too old
 	for _, job := range m.jobs {
-		if job.ExpiresAt.Before(now) {
+		if _, pending := m.pendingDestroys[job.Name]; pending {
+			// still inside its expiry grace window; see expiry.go
+			continue
+		}
+		if job.ExpiresAt.Add(m.graceWindow).Before(now) {
 			klog.Infof("job %q is expired", job.Name)
+			if job.Mode == JobTypeLaunch || job.Mode == JobTypeWorkflowLaunch {
+				if err := m.archiveJob(job); err != nil {
+					klog.Warningf("Failed to archive metadata for job %s: %v", job.Name, err)
+				}
+				m.chargeCostForJob(job)
+			}
 			delete(m.jobs, job.Name)
 		}
 	}
@@ -823,6 +894,30 @@ func (m *jobManager) SetMceNotifier(fn MCECallbackFunc) {
 	m.mceNotifierFn = fn
 }
 
+func (m *jobManager) SetReaperNotifier(fn ReaperCallbackFunc) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.reaperNotifierFn = fn
+}
+
+func (m *jobManager) SetAdminNotifier(fn AdminCallbackFunc) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.adminNotifierFn = fn
+}
+
+func (m *jobManager) SetTeardownNotifier(fn TeardownCallbackFunc) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.teardownNotifierFn = fn
+}
+
+func (m *jobManager) SetQueueNotifier(fn QueueCallbackFunc) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.queueNotifierFn = fn
+}
+
 func (m *jobManager) estimateCompletion(requestedAt time.Time) time.Duration {
 	// find the median, or default to 30m
 	var median time.Duration
@@ -844,7 +939,17 @@ func (m *jobManager) estimateCompletion(requestedAt time.Time) time.Duration {
 	return lastEstimate.Truncate(time.Second)
 }
 
-func (m *jobManager) ListJobs(user string, filters ListFilters) string {
+// jobHasTag reports whether one of the job's semicolon-separated tags matches.
+func jobHasTag(job *Job, tag string) bool {
+	for _, t := range strings.Split(job.JobParams[TagParam], ";") {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *jobManager) ListJobs(user string, timezone *time.Location, filters ListFilters) string {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
@@ -884,6 +989,14 @@ func (m *jobManager) ListJobs(user string, filters ListFilters) string {
 		return false
 	})
 
+	// group spokes under their hub so the list shows each group as a unit
+	spokesByHub := make(map[string][]*Job)
+	for _, job := range clusters {
+		if len(job.GroupID) > 0 && job.GroupID != job.Name {
+			spokesByHub[job.GroupID] = append(spokesByHub[job.GroupID], job)
+		}
+	}
+
 	buf := &bytes.Buffer{}
 	now := time.Now()
 	if len(clusters) == 0 {
@@ -891,11 +1004,14 @@ func (m *jobManager) ListJobs(user string, filters ListFilters) string {
 	} else {
 		fmt.Fprintf(buf, "%d/%d clusters up (start time is approximately %d minutes):\n\n", runningClusters, m.maxClusters, m.estimateCompletion(time.Time{})/time.Minute)
 		for _, job := range clusters {
+			if len(job.GroupID) > 0 && job.GroupID != job.Name {
+				continue
+			}
 			var jobInput JobInput
 			if len(job.Inputs) > 0 {
 				jobInput = job.Inputs[0]
 			}
-			if !((filters.Requestor == "" || filters.Requestor == job.RequestedBy) && (filters.Platform == "" || filters.Platform == job.Platform) && (filters.Version == "" || strings.Contains(jobInput.Version, filters.Version))) {
+			if !((filters.Requestor == "" || filters.Requestor == job.RequestedBy) && (filters.Platform == "" || filters.Platform == job.Platform) && (filters.Version == "" || strings.Contains(jobInput.Version, filters.Version)) && (filters.Tag == "" || jobHasTag(job, filters.Tag))) {
 				continue
 			}
 			var details string
@@ -938,12 +1054,30 @@ func (m *jobManager) ListJobs(user string, filters ListFilters) string {
 			case job.Complete:
 				fmt.Fprintf(buf, "• <@%s>%s%s - cluster has requested shut down%s\n", job.RequestedBy, imageOrVersion, options, details)
 			case len(job.Credentials) > 0:
-				fmt.Fprintf(buf, "• <@%s>%s%s - available and will be torn down in %d minutes%s\n", job.RequestedBy, imageOrVersion, options, int(job.ExpiresAt.Sub(now)/time.Minute), details)
+				fmt.Fprintf(buf, "• <@%s>%s%s - available and will be torn down in %d minutes (%s)%s\n", job.RequestedBy, imageOrVersion, options, int(job.ExpiresAt.Sub(now)/time.Minute), utils.LocalTimeString(job.ExpiresAt, timezone), details)
 			case len(job.Failure) > 0:
 				fmt.Fprintf(buf, "• <@%s>%s%s - failure: %s%s\n", job.RequestedBy, imageOrVersion, options, job.Failure, details)
 			default:
 				fmt.Fprintf(buf, "• <@%s>%s%s - starting, %d minutes elapsed%s\n", job.RequestedBy, imageOrVersion, options, int(now.Sub(job.RequestedAt)/time.Minute), details)
 			}
+			for _, spoke := range spokesByHub[job.Name] {
+				var spokeDetails string
+				if len(spoke.URL) > 0 {
+					spokeDetails = fmt.Sprintf(", <%s|view logs>", spoke.URL)
+				}
+				var state string
+				switch {
+				case spoke.Complete:
+					state = "has requested shut down"
+				case len(spoke.Failure) > 0:
+					state = fmt.Sprintf("failure: %s", spoke.Failure)
+				case len(spoke.Credentials) > 0:
+					state = "available"
+				default:
+					state = fmt.Sprintf("starting, %d minutes elapsed", int(now.Sub(spoke.RequestedAt)/time.Minute))
+				}
+				fmt.Fprintf(buf, "    ◦ spoke `%s` - %s%s\n", spoke.Name, state, spokeDetails)
+			}
 		}
 		fmt.Fprintf(buf, "\n")
 	}
@@ -998,7 +1132,7 @@ func (m *jobManager) ListJobs(user string, filters ListFilters) string {
 					klog.Errorf("Failed to parse expiry time: %v", err)
 					fmt.Fprintf(buf, "\n<@%s> - ROSA Cluster `%s` is ready\n", clusterUser, cluster.Name())
 				} else {
-					fmt.Fprintf(buf, "\n<@%s> - ROSA Cluster `%s` is ready and will be torn down in %d minutes\n", clusterUser, cluster.Name(), int(parsedExpiryTime.Sub(now)/time.Minute))
+					fmt.Fprintf(buf, "\n<@%s> - ROSA Cluster `%s` is ready and will be torn down in %d minutes (%s)\n", clusterUser, cluster.Name(), int(parsedExpiryTime.Sub(now)/time.Minute), utils.LocalTimeString(parsedExpiryTime, timezone))
 				}
 			case clustermgmtv1.ClusterStateInstalling:
 				fmt.Fprintf(buf, "\n<@%s> - ROSA Cluster `%s` is starting; %d minutes have elapsed\n", clusterUser, cluster.Name(), int(time.Since(cluster.CreationTimestamp())/time.Minute))
@@ -1024,6 +1158,38 @@ func (m *jobManager) DescribeROSACluster(name string) (string, error) {
 	return m.describeROSACluster(name)
 }
 
+// TeamParam is the launch option that assigns ownership of the cluster to a
+// Slack channel instead of the requesting user.
+const TeamParam = "team"
+
+// teamOwnerPrefix marks owner identities that are channels rather than users.
+const teamOwnerPrefix = "team:"
+
+// TeamOwner returns the owner identity used to track a cluster owned by a
+// channel.
+func TeamOwner(channel string) string {
+	return teamOwnerPrefix + channel
+}
+
+// TeamChannel returns the channel behind a team cluster owner identity.
+func TeamChannel(owner string) (string, bool) {
+	return strings.CutPrefix(owner, teamOwnerPrefix)
+}
+
+// TeamClusterChannels returns the channels that currently own a team cluster.
+func (m *jobManager) TeamClusterChannels() []string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var channels []string
+	for owner := range m.requests {
+		if channel, ok := TeamChannel(owner); ok {
+			channels = append(channels, channel)
+		}
+	}
+	sort.Strings(channels)
+	return channels
+}
+
 func (m *jobManager) GetLaunchJob(user string) (*Job, error) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -1080,6 +1246,16 @@ func (m *jobManager) ResolveImageOrVersion(imageOrVersion, defaultImageOrVersion
 		return unresolved, "", "", nil
 	}
 
+	if installSpec, name, runSpec, handled, err := m.resolveByPrefix(unresolved, architecture); handled {
+		return installSpec, name, runSpec, err
+	}
+
+	// built-in OKD support unless a configured resolver claimed the prefix
+	// above; see okd.go
+	if isOKDVersion(unresolved) {
+		return m.resolveOKDVersion(imageOrVersion, strings.TrimPrefix(strings.TrimPrefix(unresolved, okdVersionPrefix), "-"), architecture)
+	}
+
 	type namespaceAndStream struct {
 		Namespace   string
 		Imagestream string
@@ -1128,6 +1304,33 @@ func (m *jobManager) ResolveImageOrVersion(imageOrVersion, defaultImageOrVersion
 		}
 
 		currentReleasePrefix := fmt.Sprintf("%d.%d", CurrentRelease.Major, CurrentRelease.Minor)
+
+		// `accepted` and `X.Y-accepted` pick the most recent nightly that
+		// passed the release controller's blocking jobs rather than the
+		// newest build
+		acceptedPrefix := ""
+		if unresolved == "accepted" {
+			acceptedPrefix = currentReleasePrefix
+		} else if version, found := strings.CutSuffix(unresolved, "-accepted"); found && reMajorMinorVersion.MatchString(version) {
+			acceptedPrefix = version
+		}
+		if len(acceptedPrefix) > 0 {
+			tag := findNewestImageSpecTagWithStream(is, fmt.Sprintf("%s.0-0.nightly%s", acceptedPrefix, archSuffix))
+			if tag == nil {
+				return "", "", "", fmt.Errorf("no accepted nightly payload found for %s", acceptedPrefix)
+			}
+			klog.Infof("Resolved %s to accepted nightly tag %s", imageOrVersion, tag.Name)
+			installSpec := buildPullSpec(ns, tag.Name, isName)
+			runSpec := ""
+			if architecture == "amd64" || architecture == "multi" {
+				runSpec = installSpec
+			} else {
+				runTag := findNewestImageSpecTagWithStream(amd64IS, fmt.Sprintf("%s.0-0.nightly", acceptedPrefix))
+				runSpec = buildPullSpec("ocp", runTag.Name, "release")
+			}
+			return installSpec, tag.Name, runSpec, nil
+		}
+
 		if m := reMajorMinorVersion.FindStringSubmatch(unresolved); m != nil {
 			if tag := findNewestImageSpecTagWithStream(is, fmt.Sprintf("%s.0-0.nightly%s", unresolved, archSuffix)); tag != nil {
 				klog.Infof("Resolved major.minor %s to nightly tag %s", imageOrVersion, tag.Name)
@@ -1490,6 +1693,7 @@ func (m *jobManager) resolveToJob(req *JobRequest) (*Job, error) {
 		RequestedBy:      user,
 		RequesterUserID:  req.UserName,
 		RequestedChannel: req.Channel,
+		CorrelationID:    req.CorrelationID,
 		RequestedAt:      req.RequestedAt,
 
 		ExpiresAt: req.RequestedAt.Add(m.maxAge),
@@ -1675,7 +1879,7 @@ func multistageNameFromParams(params map[string]string, platform, jobType string
 	platformParams := multistageParamsForPlatform(platform)
 	variants := sets.New[string]()
 	for k := range params {
-		if utils.Contains(SupportedParameters, k) && !platformParams.Has(k) && k != "test" && k != "bundle" && k != "no-spot" { // we only need parameters that are not configured via multistage env vars
+		if utils.Contains(SupportedParameters, k) && !platformParams.Has(k) && k != "test" && k != "bundle" && k != "no-spot" && k != FeatureSetParam && k != FeatureGatesParam && k != NetworkTypeParam && k != DevscriptsConfigParam && k != IDPParam && k != TagParam && k != InstallConfigParam && k != CapabilitiesParam && k != EncryptionParam && k != AuditProfileParam { // we only need parameters that are not configured via multistage env vars
 			variants.Insert(k)
 		}
 	}
@@ -1721,20 +1925,23 @@ func configContainsVariant(params map[string]string, platform, unresolvedConfig,
 }
 
 // TODO remove duplicated code
-func (m *jobManager) CheckValidJobConfiguration(req *JobRequest) error {
-	job, err := m.resolveToJob(req)
-	if err != nil {
-		return err
-	}
-	// try to pick a job that matches the install version, if we can, otherwise use the first that
-	// matches us (we can do better)
+// findMatchingProwJob tries to pick a prow job that matches the install version of the
+// resolved request, if it can, otherwise it uses the first job that matches us (we can
+// do better)
+func (m *jobManager) findMatchingProwJob(req *JobRequest, job *Job) (*prowapiv1.ProwJob, error) {
 	var prowJob *prowapiv1.ProwJob
 	jobType := JobTypeLaunch
 	if req.Type == JobTypeWorkflowUpgrade {
 		jobType = JobTypeUpgrade
 	}
 	selector := labels.Set{"job-env": req.Platform, "job-type": jobType, "job-architecture": req.Architecture} // TODO: handle versioned variants better
-	if len(job.Inputs[0].Version) > 0 {
+	if isOKDPayload(job.Inputs[0].Version) {
+		// OKD payloads need install workflows without OCP-only assumptions;
+		// prefer jobs labeled for OKD and fall back to the regular jobs
+		withPayload := labels.Merge(selector, labels.Set{"job-payload": "okd"})
+		prowJob, _ = prow.JobForLabels(m.prowConfigLoader, labels.SelectorFromSet(withPayload))
+	}
+	if prowJob == nil && len(job.Inputs[0].Version) > 0 {
 		if v, err := semver.ParseTolerant(job.Inputs[0].Version); err == nil {
 			withRelease := labels.Merge(selector, labels.Set{"job-release": fmt.Sprintf("%d.%d", v.Major, v.Minor)})
 			prowJob, _ = prow.JobForLabels(m.prowConfigLoader, labels.SelectorFromSet(withRelease))
@@ -1752,7 +1959,7 @@ func (m *jobManager) CheckValidJobConfiguration(req *JobRequest) error {
 			if sourceEnv, _, ok := firstEnvVar(prowJob.Spec.PodSpec, "UNRESOLVED_CONFIG"); ok { // all multistage configs will be unresolved
 				configHasVariant, _, err := configContainsVariant(req.JobParams, req.Platform, sourceEnv.Value, job.Mode)
 				if err != nil {
-					return err
+					return nil, err
 				}
 				// if the config does not contain the wanted variant, reset prowjob to cause configuration error
 				if !configHasVariant {
@@ -1762,59 +1969,129 @@ func (m *jobManager) CheckValidJobConfiguration(req *JobRequest) error {
 		}
 	}
 	if prowJob == nil {
-		return fmt.Errorf("configuration error, unable to find prow job matching %s with parameters=%v", selector, paramsToString(job.JobParams))
+		return nil, fmt.Errorf("configuration error, unable to find prow job matching %s with parameters=%v", selector, paramsToString(job.JobParams))
 	}
-	return nil
+	return prowJob, nil
+}
+
+func (m *jobManager) CheckValidJobConfiguration(req *JobRequest) error {
+	job, err := m.resolveToJob(req)
+	if err != nil {
+		return err
+	}
+	// fail fast on combinations the configured matrix rules out before the
+	// opaque prow job lookup; see matrix.go
+	if err := m.checkJobSupportMatrix(job); err != nil {
+		return err
+	}
+	_, err = m.findMatchingProwJob(req, job)
+	return err
 }
 
+// SameClusterParam is the launch option that pins a job to the build cluster
+// an existing job runs on, so related clusters (e.g. hub and spoke) can reach
+// each other's services.
+const SameClusterParam = "same-cluster-as"
+
 func (m *jobManager) LaunchJobForUser(req *JobRequest) (string, error) {
+	// the onboarding notice must be acknowledged before the first launch;
+	// see onboarding.go
+	if !m.HasAcknowledgedTerms(req.User) {
+		return "", fmt.Errorf("before your first launch you need to accept the usage terms - send me any message and press the button on the notice")
+	}
 	if cluster, _ := m.getROSAClusterForUser(req.User); cluster != nil {
 		return "", fmt.Errorf("you have already requested a cluster via the `rosa create` command; %d minutes have elapsed", int(time.Since(cluster.CreationTimestamp())/time.Minute))
 	}
 
+	endResolveSpan := tracing.StartSpan("resolve-inputs", attribute.String("user", req.User))
 	job, err := m.resolveToJob(req)
+	endResolveSpan(err)
 	if err != nil {
 		return "", err
 	}
 
-	// try to pick a job that matches the install version, if we can, otherwise use the first that
-	// matches us (we can do better)
-	var prowJob *prowapiv1.ProwJob
-	jobType := JobTypeLaunch
-	if req.Type == JobTypeWorkflowUpgrade {
-		jobType = JobTypeUpgrade
+	if err := m.checkJobSupportMatrix(job); err != nil {
+		return "", err
 	}
-	selector := labels.Set{"job-env": req.Platform, "job-type": jobType, "job-architecture": req.Architecture} // TODO: handle versioned variants better
-	if len(job.Inputs[0].Version) > 0 {
-		if v, err := semver.ParseTolerant(job.Inputs[0].Version); err == nil {
-			withRelease := labels.Merge(selector, labels.Set{"job-release": fmt.Sprintf("%d.%d", v.Major, v.Minor)})
-			prowJob, _ = prow.JobForLabels(m.prowConfigLoader, labels.SelectorFromSet(withRelease))
+
+	_, force := req.JobParams[ForceLaunchParam]
+	if !force {
+		for _, input := range job.Inputs {
+			if err := m.checkNightlyPayloadHealth(input.Version, job.Architecture); err != nil {
+				return "", err
+			}
+		}
+	}
+	for _, input := range job.Inputs {
+		if err := m.checkDeprecatedVersion(input.Version, force); err != nil {
+			return "", err
 		}
 	}
+	delete(job.JobParams, ForceLaunchParam)
+	delete(req.JobParams, ForceLaunchParam)
 
-	if prowJob == nil {
-		architectureLabel := req.Architecture
-		// multiarch image launches use amd64 jobs
-		if architectureLabel == "multi" {
-			architectureLabel = "amd64"
+	if err := m.checkCostBudget(req); err != nil {
+		return "", err
+	}
+	delete(job.JobParams, CostOverrideParam)
+	delete(req.JobParams, CostOverrideParam)
+
+	// the priority class only orders the quota queue (see priority.go); the
+	// request keeps it so a parked launch re-submits with the same class, and
+	// the job records it for preemption decisions but must not carry it as a
+	// variant
+	if err := ValidatePriority(req.JobParams[PriorityParam]); err != nil {
+		return "", err
+	}
+	job.Priority = req.JobParams[PriorityParam]
+	delete(job.JobParams, PriorityParam)
+
+	// a GPU pool is created by the bot after the cluster is up (see gpu.go),
+	// so the spec rides on the job rather than becoming a variant
+	if spec, ok := req.JobParams[GpuParam]; ok {
+		if err := ValidateGpuSpec(spec); err != nil {
+			return "", err
 		}
-		selector := labels.Set{"job-env": req.Platform, "job-type": JobTypeLaunch, "config-type": "modern", "job-architecture": architectureLabel} // these jobs will only contain configs using non-deprecated features
-		prowJob, _ = prow.JobForLabels(m.prowConfigLoader, labels.SelectorFromSet(selector))
-		if prowJob != nil {
-			if sourceEnv, _, ok := firstEnvVar(prowJob.Spec.PodSpec, "UNRESOLVED_CONFIG"); ok { // all multistage configs will be unresolved
-				configHasVariant, _, err := configContainsVariant(req.JobParams, req.Platform, sourceEnv.Value, job.Mode)
-				if err != nil {
-					return "", err
-				}
-				// if the config does not contain the wanted variant, reset prowjob to cause configuration error
-				if !configHasVariant {
-					prowJob = nil
-				}
-			}
+		job.GpuPool = spec
+		delete(job.JobParams, GpuParam)
+	}
+
+	// Windows workers are likewise set up by the bot after the cluster is up
+	// (see windows.go)
+	if _, ok := req.JobParams[WindowsParam]; ok {
+		if err := ValidateWindowsPlatform(job.Platform); err != nil {
+			return "", err
 		}
+		job.WindowsWorkers = true
+		delete(job.JobParams, WindowsParam)
 	}
-	if prowJob == nil {
-		return "", fmt.Errorf("configuration error, unable to find prow job matching %s with parameters=%v", selector, paramsToString(job.JobParams))
+
+	if job.Mode == JobTypeLaunch || job.Mode == JobTypeWorkflowLaunch {
+		if err := m.checkMaintenanceWindow(time.Now()); err != nil {
+			return "", err
+		}
+	}
+
+	coLocateWith := req.JobParams[SameClusterParam]
+	delete(job.JobParams, SameClusterParam)
+	delete(req.JobParams, SameClusterParam)
+
+	spokes, err := parseSpokeCount(req.JobParams[SpokesParam])
+	if err != nil {
+		return "", err
+	}
+	delete(job.JobParams, SpokesParam)
+	delete(req.JobParams, SpokesParam)
+	if spokes > 0 {
+		if job.Mode != JobTypeLaunch && job.Mode != JobTypeWorkflowLaunch {
+			return "", fmt.Errorf("the `%s` option is only supported for cluster launches", SpokesParam)
+		}
+		job.GroupID = job.Name
+	}
+
+	prowJob, err := m.findMatchingProwJob(req, job)
+	if err != nil {
+		return "", err
 	}
 	job.JobName = prowJob.Spec.Job
 	job.BuildCluster, err = m.schedule(prowJob)
@@ -1822,49 +2099,39 @@ func (m *jobManager) LaunchJobForUser(req *JobRequest) (string, error) {
 		klog.Error(err.Error())
 		job.BuildCluster = prowJob.Spec.Cluster
 	}
+	job.BuildCluster, err = m.routeForArchitecture(job.BuildCluster, job.Architecture)
+	if err != nil {
+		return "", err
+	}
+	if len(coLocateWith) > 0 {
+		pinned, err := m.buildClusterForJob(coLocateWith)
+		if err != nil {
+			return "", err
+		}
+		if routed, err := m.routeForArchitecture(pinned, job.Architecture); err != nil || routed != pinned {
+			return "", fmt.Errorf("job `%s` runs on build cluster %s, which cannot run %s payloads", coLocateWith, pinned, job.Architecture)
+		}
+		klog.Infof("Pinning job %q to build cluster %s alongside job %q", job.Name, pinned, coLocateWith)
+		job.BuildCluster = pinned
+	}
 
-	klog.Infof("Job %q requested by user %q with mode %s prow job %s(%s) - params=%s, inputs=%#v", job.Name, req.User, job.Mode, job.JobName, job.BuildCluster, paramsToString(job.JobParams), job.Inputs)
+	klog.Infof("Job %q requested by user %q (correlationID %s) with mode %s prow job %s(%s) - params=%s, inputs=%#v", job.Name, req.User, job.CorrelationID, job.Mode, job.JobName, job.BuildCluster, paramsToString(job.JobParams), job.Inputs)
 
-	// check what leases are available for platform
-	if req.Architecture == "amd64" && m.lClient != nil {
-		switch req.Platform {
-		case "aws":
-			metrics1, err := m.lClient.Metrics("aws-quota-slice")
-			if err != nil {
-				return "", fmt.Errorf("failed to get metrics for `aws` leases: %v", err)
-			}
-			metrics2, err := m.lClient.Metrics("aws-2-quota-slice")
-			if err != nil {
-				return "", fmt.Errorf("failed to get metrics for `aws-2` leases: %v", err)
-			}
-			if metrics2.Free > metrics1.Free {
-				job.UseSecondaryAccount = true
-			}
-		case "azure":
-			metrics1, err := m.lClient.Metrics("azure4-quota-slice")
-			if err != nil {
-				return "", fmt.Errorf("failed to get metrics for `azure` leases: %v", err)
-			}
-			metrics2, err := m.lClient.Metrics("azure-2-quota-slice")
-			if err != nil {
-				return "", fmt.Errorf("failed to get metrics for `azure-2` leases: %v", err)
-			}
-			if metrics2.Free > metrics1.Free {
-				job.UseSecondaryAccount = true
-			}
-		case "gcp":
-			metrics1, err := m.lClient.Metrics("gcp-quota-slice")
-			if err != nil {
-				return "", fmt.Errorf("failed to get metrics for `gcp` leases: %v", err)
-			}
-			metrics2, err := m.lClient.Metrics("gcp-openshift-gce-devel-ci-2-quota-slice")
-			if err != nil {
-				return "", fmt.Errorf("failed to get metrics for `gcp-openshift-gce-devel-ci-2` leases: %v", err)
-			}
-			if metrics2.Free > metrics1.Free {
-				job.UseSecondaryAccount = true
+	// spread the job across the platform's account pool based on lease quota
+	if req.Architecture == "amd64" {
+		useSecondary, err := m.selectAccount(req.Platform)
+		if err != nil {
+			// a release-blocker launch may tear down a lower-priority cluster
+			// to free a slot; the lease returns once the victim deprovisions,
+			// so the request still parks on the queue either way
+			if victim := m.tryPreemptForCapacity(req); len(victim) > 0 {
+				err = fmt.Errorf("%v; I am tearing down the lower-priority cluster `%s` to make room", err, victim)
 			}
+			// park the request instead of bouncing the user when the
+			// platform is at quota
+			return "", m.queueForPlatformCapacity(req, err)
 		}
+		job.UseSecondaryAccount = useSecondary
 	}
 
 	msg, err := func() (string, error) {
@@ -1938,7 +2205,12 @@ func (m *jobManager) LaunchJobForUser(req *JobRequest) (string, error) {
 		return msg, err
 	}
 
+	endCreateSpan := tracing.StartSpan("create-prowjob",
+		attribute.String("job", job.Name),
+		attribute.String("prowjob", job.JobName),
+	)
 	prowJobUrl, err := m.newJob(job)
+	endCreateSpan(err)
 	if err != nil {
 		// In the case where a ProwJob has been created, but we fail to get its URL, we shouldn't delete anything
 		if !strings.HasPrefix(err.Error(), "did not retrieve job url due to an error:") {
@@ -1953,6 +2225,17 @@ func (m *jobManager) LaunchJobForUser(req *JobRequest) (string, error) {
 
 	go m.handleJobStartup(*job, "start")
 
+	var groupMsg string
+	if spokes > 0 {
+		spokeNames, err := m.launchSpokes(req, job, spokes)
+		if len(spokeNames) > 0 {
+			groupMsg = fmt.Sprintf("\nAlso launching %d spoke cluster(s) (`%s`) on the same build cluster; I'll send each kubeconfig as it becomes ready, and `done` tears down the whole group.", len(spokeNames), strings.Join(spokeNames, "`, `"))
+		}
+		if err != nil {
+			groupMsg = fmt.Sprintf("%s\nwarning: not all spokes could be launched: %v", groupMsg, err)
+		}
+	}
+
 	msg = ""
 	if UseSpotInstances(job) {
 		msg = fmt.Sprintf("%s\nThis AWS cluster will use Spot instances for the worker nodes.", msg)
@@ -1972,6 +2255,9 @@ func (m *jobManager) LaunchJobForUser(req *JobRequest) (string, error) {
 	}
 
 	if job.Mode == JobTypeLaunch || job.Mode == JobTypeWorkflowLaunch {
+		if warning := m.maintenanceWarning(time.Now(), job.ExpiresAt); len(warning) > 0 {
+			msg = fmt.Sprintf("%s%s\n\n", msg, warning)
+		}
 		msg = fmt.Sprintf("%sa <%s|cluster is being created>", msg, prowJobUrl)
 		if job.Operator.Is {
 			msg = fmt.Sprintf("%s - On completion of the creation of the cluster, your optional operator will begin installation", msg)
@@ -1982,6 +2268,9 @@ func (m *jobManager) LaunchJobForUser(req *JobRequest) (string, error) {
 		} else {
 			msg = fmt.Sprintf("%s - I'll send you the credentials when the cluster is ready.", msg)
 		}
+		if len(groupMsg) > 0 {
+			msg = fmt.Sprintf("%s%s", msg, groupMsg)
+		}
 		return "", errors.New(msg)
 	}
 	return "", fmt.Errorf("%s<%s|job> started, you will be notified on completion", msg, prowJobUrl)
@@ -2017,6 +2306,10 @@ func (m *jobManager) TerminateJobForUser(user string) (string, error) {
 		}
 	}
 
+	return m.terminateLaunchedJob(user)
+}
+
+func (m *jobManager) terminateLaunchedJob(user string) (string, error) {
 	name, cluster, err := m.clusterDetailsForUser(user)
 	if err != nil {
 		return "", err
@@ -2026,6 +2319,14 @@ func (m *jobManager) TerminateJobForUser(user string) (string, error) {
 		return "", fmt.Errorf("unable to terminate: %v", err)
 	}
 
+	// tear down any spokes launched as a group with this cluster
+	spokes := m.groupMembers(name)
+	for _, spoke := range spokes {
+		if err := m.stopJob(spoke.name, spoke.cluster); err != nil {
+			klog.Warningf("Failed to terminate spoke %s: %v", spoke.name, err)
+		}
+	}
+
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
@@ -2034,6 +2335,17 @@ func (m *jobManager) TerminateJobForUser(user string) (string, error) {
 		job.Failure = "deletion requested"
 		job.ExpiresAt = time.Now().Add(15 * time.Minute)
 		job.Complete = true
+		// the abort above triggers the install's deprovision step; report
+		// back once the cloud resources are actually freed
+		go m.waitForTeardown(name, cluster, job.RequestedBy, job.RequestedChannel)
+	}
+	for _, spoke := range spokes {
+		if job, ok := m.jobs[spoke.name]; ok {
+			job.Failure = "deletion requested"
+			job.ExpiresAt = time.Now().Add(15 * time.Minute)
+			job.Complete = true
+		}
+		delete(m.requests, spoke.owner)
 	}
 
 	// mark the cluster as failed, clear the request, and allow the user to launch again
@@ -2042,7 +2354,89 @@ func (m *jobManager) TerminateJobForUser(user string) (string, error) {
 		return "", fmt.Errorf("another cluster was launched while trying to stop this cluster")
 	}
 	delete(m.requests, user)
-	return "the cluster was flagged for shutdown, you may now launch another", nil
+	if len(spokes) > 0 {
+		return fmt.Sprintf("the cluster and its %d spoke(s) were flagged for shutdown and are deprovisioning; I'll confirm when the resources are freed. You may now launch another cluster", len(spokes)), nil
+	}
+	return "the cluster was flagged for shutdown and is deprovisioning; I'll confirm when its resources are freed. You may now launch another cluster", nil
+}
+
+// waitForTeardown watches a terminated job's pod until it finishes or is
+// deleted, which is when ci-operator's deprovision step has released the
+// cluster's cloud resources, and then notifies the requester.
+func (m *jobManager) waitForTeardown(name, cluster, owner, channel string) {
+	start := time.Now()
+	err := wait.PollUntilContextTimeout(context.TODO(), 30*time.Second, 2*time.Hour, true, func(ctx context.Context) (bool, error) {
+		clusterClient, ok := m.clusterClients.Get(cluster)
+		if !ok {
+			return false, fmt.Errorf("cluster %s not found in build cluster clients", cluster)
+		}
+		pod, err := clusterClient.CoreClient.CoreV1().Pods(m.prowNamespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		}
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			return true, nil
+		}
+		return false, nil
+	})
+	if err != nil {
+		klog.Warningf("Gave up waiting for job %q to finish deprovisioning: %v", name, err)
+		return
+	}
+	klog.Infof("Job %q finished deprovisioning after %s", name, time.Since(start).Truncate(time.Second))
+	m.lock.Lock()
+	notifier := m.teardownNotifierFn
+	m.lock.Unlock()
+	if notifier != nil {
+		notifier(owner, channel, name, time.Since(start))
+	}
+}
+
+func (m *jobManager) TerminateAllForUser(user string) (string, error) {
+	var messages []string
+	if cluster, _ := m.getROSAClusterForUser(user); cluster != nil {
+		if err := m.deleteCluster(cluster.ID()); err != nil {
+			return "", fmt.Errorf("failed to terminate ROSA cluster `%s`: %v", cluster.ID(), err)
+		}
+		go m.rosaSync() // nolint:errcheck
+		messages = append(messages, fmt.Sprintf("ROSA cluster `%s` marked for deletion", cluster.Name()))
+	}
+
+	managed, _, _, _, _ := m.GetManagedClustersForUser(user)
+	for name := range managed {
+		if err := m.deleteManagedCluster(name); err != nil {
+			return "", fmt.Errorf("failed to delete MCE cluster `%s`: %v", name, err)
+		}
+		messages = append(messages, fmt.Sprintf("MCE cluster `%s` marked for deletion", name))
+	}
+	if len(managed) > 0 {
+		go m.mceSync() // nolint:errcheck
+	}
+
+	if msg, err := m.terminateLaunchedJob(user); err == nil {
+		messages = append(messages, msg)
+	}
+
+	if len(messages) == 0 {
+		return "", fmt.Errorf("you have no running clusters")
+	}
+	return strings.Join(messages, "\n"), nil
+}
+
+func (m *jobManager) TerminateJobByName(name string) (string, error) {
+	m.lock.Lock()
+	job, ok := m.jobs[name]
+	m.lock.Unlock()
+	if !ok {
+		return "", fmt.Errorf("no job named `%s` was found", name)
+	}
+	if len(job.RequestedBy) == 0 {
+		return "", fmt.Errorf("job `%s` has no requesting user recorded", name)
+	}
+	return m.TerminateJobForUser(job.RequestedBy)
 }
 
 func (m *jobManager) SyncJobForUser(user string) (string, error) {
@@ -2127,6 +2521,10 @@ func (m *jobManager) finishedJob(job Job) {
 		sort.Slice(m.recentStartEstimates, func(i, j int) bool {
 			return m.recentStartEstimates[i] < m.recentStartEstimates[j]
 		})
+		m.recordStartDuration(&job)
+	}
+	if (job.Mode == JobTypeLaunch || job.Mode == JobTypeWorkflowLaunch) && len(job.Failure) > 0 {
+		m.recordFailure(&job)
 	}
 
 	if len(job.RequestedChannel) > 0 && len(job.RequestedBy) > 0 {
@@ -2244,7 +2642,7 @@ func (m *jobManager) GetManagedClustersForUser(user string) (map[string]*cluster
 	return managed, deployments, provisions, kubeconfigs, passwords
 }
 
-func (m *jobManager) ListManagedClusters(user string) string {
+func (m *jobManager) ListManagedClusters(user string, timezone *time.Location) string {
 	m.mceClusters.lock.RLock()
 	defer m.mceClusters.lock.RUnlock()
 	numClusters := len(m.mceClusters.clusters)
@@ -2285,7 +2683,7 @@ func (m *jobManager) ListManagedClusters(user string) string {
 				}
 			}
 		}
-		fmt.Fprintf(buf, "- %s (Requested by <@%s>; Provision Status: %s; Remaining Time: %d minutes)\n", name, cluster.Annotations[utils.UserTag], provisionStage, int(remainingTime/time.Minute))
+		fmt.Fprintf(buf, "- %s (Requested by <@%s>; Provision Status: %s; Remaining Time: %d minutes, until %s)\n", name, cluster.Annotations[utils.UserTag], provisionStage, int(remainingTime/time.Minute), utils.LocalTimeString(expiryTime, timezone))
 	}
 	return buf.String()
 }
@@ -2375,6 +2773,47 @@ func (m *jobManager) LookupRosaInputs(versionPrefix string) (string, error) {
 	}
 }
 
+// buildClusterForJob returns the build cluster an existing job runs on, for
+// co-locating a new launch next to it.
+func (m *jobManager) buildClusterForJob(name string) (string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	existing, ok := m.jobs[name]
+	if !ok || len(existing.BuildCluster) == 0 {
+		return "", fmt.Errorf("no job named `%s` with an assigned build cluster was found; use the name shown by `list`", name)
+	}
+	return existing.BuildCluster, nil
+}
+
+// routeForArchitecture verifies the scheduled build cluster can run payloads
+// of the job's architecture and reroutes to a capable cluster when it cannot.
+// Clusters without a capability entry are assumed to handle amd64 only.
+func (m *jobManager) routeForArchitecture(cluster, architecture string) (string, error) {
+	if len(m.buildClusterArchitectures) == 0 {
+		return cluster, nil
+	}
+	if len(architecture) == 0 {
+		architecture = "amd64"
+	}
+	capable := func(name string) bool {
+		archs, ok := m.buildClusterArchitectures[name]
+		if !ok {
+			return architecture == "amd64"
+		}
+		return archs.Has(architecture)
+	}
+	if capable(cluster) {
+		return cluster, nil
+	}
+	for _, name := range sets.List(sets.KeySet(m.buildClusterArchitectures)) {
+		if capable(name) {
+			klog.Infof("Rerouting %s launch from build cluster %s to %s", architecture, cluster, name)
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("no build cluster is configured to run %s payloads", architecture)
+}
+
 func (m *jobManager) schedule(pj *prowapiv1.ProwJob) (string, error) {
 	cluster, err := m.prowScheduler.Schedule(context.TODO(), pj)
 	if err != nil {
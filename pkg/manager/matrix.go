@@ -0,0 +1,167 @@
+package manager
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/blang/semver"
+	"sigs.k8s.io/yaml"
+)
+
+// A request outside the job matrix used to surface an hour later as a failed
+// prow job, or as the opaque "unable to find prow job matching" error.
+// Administrators can describe the holes in the matrix in
+// --support-matrix-config instead: each rule names an unsupported
+// (version × platform × architecture × variant) combination, and requests are
+// validated against the rules at parse time so the user gets a precise error
+// like "4.12 does not support arm64 on vsphere" before anything is launched.
+
+// SupportMatrixRule is one entry of the --support-matrix-config list. The
+// empty fields of a rule match everything; a rule with no version bounds
+// marks the combination unsupported outright.
+type SupportMatrixRule struct {
+	// Platform narrows the rule to one platform, e.g. vsphere.
+	Platform string `json:"platform,omitempty"`
+	// Architecture narrows the rule to one architecture, e.g. arm64.
+	Architecture string `json:"architecture,omitempty"`
+	// Variant narrows the rule to requests carrying the named launch option,
+	// e.g. compact.
+	Variant string `json:"variant,omitempty"`
+	// MinVersion marks versions below it (by major.minor) unsupported.
+	MinVersion string `json:"minVersion,omitempty"`
+	// MaxVersion marks versions above it (by major.minor) unsupported.
+	MaxVersion string `json:"maxVersion,omitempty"`
+	// Reason is appended to the error shown to the user.
+	Reason string `json:"reason,omitempty"`
+}
+
+// LoadSupportMatrix reads a YAML list of support matrix rules.
+func LoadSupportMatrix(path string) ([]SupportMatrixRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read support matrix: %w", err)
+	}
+	var rules []SupportMatrixRule
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("unable to parse support matrix: %w", err)
+	}
+	for i, rule := range rules {
+		if len(rule.Platform) == 0 && len(rule.Architecture) == 0 && len(rule.Variant) == 0 {
+			return nil, fmt.Errorf("support matrix rule %d names no platform, architecture, or variant", i)
+		}
+		for _, version := range []string{rule.MinVersion, rule.MaxVersion} {
+			if len(version) == 0 {
+				continue
+			}
+			if _, err := semver.ParseTolerant(version); err != nil {
+				return nil, fmt.Errorf("support matrix rule %d has an unparseable version %q: %v", i, version, err)
+			}
+		}
+	}
+	return rules, nil
+}
+
+// SetSupportMatrix installs the configured support matrix rules.
+func (m *jobManager) SetSupportMatrix(rules []SupportMatrixRule) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.supportMatrix = rules
+}
+
+// appliesTo reports whether the rule's populated fields match the request.
+func (r *SupportMatrixRule) appliesTo(platform, architecture string, params map[string]string) bool {
+	if len(r.Platform) > 0 && r.Platform != platform {
+		return false
+	}
+	if len(r.Architecture) > 0 && r.Architecture != architecture {
+		return false
+	}
+	if len(r.Variant) > 0 {
+		if _, ok := params[r.Variant]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// majorMinorOf truncates a version to its major.minor for rule comparisons.
+func majorMinorOf(version string) (semver.Version, bool) {
+	v, err := semver.ParseTolerant(version)
+	if err != nil {
+		return semver.Version{}, false
+	}
+	return semver.Version{Major: v.Major, Minor: v.Minor}, true
+}
+
+// checkSupportMatrix validates the resolved request against the configured
+// rules, returning a user-facing error for the first rule it violates.
+func (m *jobManager) checkSupportMatrix(version, platform, architecture string, params map[string]string) error {
+	m.lock.Lock()
+	rules := m.supportMatrix
+	m.lock.Unlock()
+	requested, parsed := majorMinorOf(version)
+	for i := range rules {
+		rule := &rules[i]
+		if !rule.appliesTo(platform, architecture, params) {
+			continue
+		}
+		unsupported := len(rule.MinVersion) == 0 && len(rule.MaxVersion) == 0
+		if !unsupported {
+			// version-bounded rules cannot judge a request whose version
+			// does not parse, e.g. a raw image launch
+			if !parsed {
+				continue
+			}
+			if len(rule.MinVersion) > 0 {
+				if bound, ok := majorMinorOf(rule.MinVersion); ok && requested.LT(bound) {
+					unsupported = true
+				}
+			}
+			if len(rule.MaxVersion) > 0 {
+				if bound, ok := majorMinorOf(rule.MaxVersion); ok && requested.GT(bound) {
+					unsupported = true
+				}
+			}
+		}
+		if !unsupported {
+			continue
+		}
+		subject := "this configuration"
+		if parsed {
+			subject = fmt.Sprintf("%d.%d", requested.Major, requested.Minor)
+		}
+		var target string
+		switch {
+		case len(rule.Architecture) > 0 && len(rule.Platform) > 0:
+			target = fmt.Sprintf("`%s` on `%s`", architecture, platform)
+		case len(rule.Platform) > 0:
+			target = fmt.Sprintf("platform `%s`", platform)
+		case len(rule.Architecture) > 0:
+			target = fmt.Sprintf("architecture `%s`", architecture)
+		default:
+			target = "this combination"
+		}
+		if len(rule.Variant) > 0 {
+			target = fmt.Sprintf("%s with the `%s` option", target, rule.Variant)
+		}
+		msg := fmt.Sprintf("%s does not support %s", subject, target)
+		if len(rule.MinVersion) > 0 {
+			msg = fmt.Sprintf("%s (supported from %s)", msg, rule.MinVersion)
+		}
+		if len(rule.Reason) > 0 {
+			msg = fmt.Sprintf("%s: %s", msg, rule.Reason)
+		}
+		return fmt.Errorf("%s", msg)
+	}
+	return nil
+}
+
+// checkJobSupportMatrix runs the support matrix validation against a resolved
+// job, shared by the parse-time check and the launch path.
+func (m *jobManager) checkJobSupportMatrix(job *Job) error {
+	var version string
+	if len(job.Inputs) > 0 {
+		version = job.Inputs[0].Version
+	}
+	return m.checkSupportMatrix(version, job.Platform, job.Architecture, job.JobParams)
+}
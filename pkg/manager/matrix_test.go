@@ -0,0 +1,77 @@
+package manager
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckSupportMatrix(t *testing.T) {
+	m := &jobManager{
+		supportMatrix: []SupportMatrixRule{
+			{Platform: "vsphere", Architecture: "arm64", MinVersion: "4.13"},
+			{Platform: "nutanix", Architecture: "arm64", Reason: "no arm64 nutanix hardware in CI"},
+			{Platform: "metal", Variant: "compact", MinVersion: "4.10"},
+		},
+	}
+	testCases := []struct {
+		name         string
+		version      string
+		platform     string
+		architecture string
+		params       map[string]string
+		expectedErr  string
+	}{{
+		name:         "supported combination passes",
+		version:      "4.18.0-0.nightly-2026-08-29-120000",
+		platform:     "aws",
+		architecture: "amd64",
+	}, {
+		name:         "version below the minimum is rejected",
+		version:      "4.12.17",
+		platform:     "vsphere",
+		architecture: "arm64",
+		expectedErr:  "4.12 does not support `arm64` on `vsphere` (supported from 4.13)",
+	}, {
+		name:         "version at the minimum passes",
+		version:      "4.13.0-0.nightly-2023-05-01-000000",
+		platform:     "vsphere",
+		architecture: "arm64",
+	}, {
+		name:         "unconditional rule rejects and carries the reason",
+		version:      "4.18.2",
+		platform:     "nutanix",
+		architecture: "arm64",
+		expectedErr:  "no arm64 nutanix hardware in CI",
+	}, {
+		name:         "variant rule only applies when the option is present",
+		version:      "4.9.12",
+		platform:     "metal",
+		architecture: "amd64",
+	}, {
+		name:         "variant rule rejects the option below the minimum",
+		version:      "4.9.12",
+		platform:     "metal",
+		architecture: "amd64",
+		params:       map[string]string{"compact": ""},
+		expectedErr:  "with the `compact` option",
+	}, {
+		name:         "unparseable version skips version-bounded rules",
+		version:      "",
+		platform:     "vsphere",
+		architecture: "arm64",
+	}}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := m.checkSupportMatrix(testCase.version, testCase.platform, testCase.architecture, testCase.params)
+			if len(testCase.expectedErr) == 0 {
+				if err != nil {
+					t.Fatalf("expected no error, got: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), testCase.expectedErr) {
+				t.Fatalf("expected an error containing %q, got: %v", testCase.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package manager
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/klog"
+)
+
+// enforceOfficeHours tears down all non-exempt launched clusters once the
+// configured daily teardown time has passed, unless a scheduled test event is
+// in progress. It runs every minute from the Start loop and acts at most once
+// per day.
+func (m *jobManager) enforceOfficeHours() error {
+	cfg := m.officeHoursConfig
+	if cfg == nil {
+		return nil
+	}
+	cfg.Mutex.RLock()
+	teardownTime := cfg.TeardownTime
+	timezone := cfg.Timezone
+	events := make([]OfficeHoursEvent, len(cfg.Events))
+	copy(events, cfg.Events)
+	cfg.Mutex.RUnlock()
+	if len(teardownTime) == 0 {
+		return nil
+	}
+
+	loc := time.UTC
+	if len(timezone) > 0 {
+		var err error
+		loc, err = time.LoadLocation(timezone)
+		if err != nil {
+			return fmt.Errorf("invalid office hours timezone %q: %w", timezone, err)
+		}
+	}
+	now := time.Now().In(loc)
+	target, err := time.ParseInLocation("15:04", teardownTime, loc)
+	if err != nil {
+		return fmt.Errorf("invalid office hours teardown time %q: %w", teardownTime, err)
+	}
+	target = time.Date(now.Year(), now.Month(), now.Day(), target.Hour(), target.Minute(), 0, 0, loc)
+	if now.Before(target) {
+		return nil
+	}
+
+	m.officeHoursState.lock.Lock()
+	alreadyRan := m.officeHoursState.lastTeardown.In(loc).Format("2006-01-02") == now.Format("2006-01-02")
+	m.officeHoursState.lock.Unlock()
+	if alreadyRan {
+		return nil
+	}
+
+	// a scheduled test event suspends teardown entirely; it resumes (and still
+	// runs tonight's sweep) once the event is over
+	for _, event := range events {
+		if !now.Before(event.Start) && now.Before(event.End) {
+			klog.Infof("office hours teardown suspended during scheduled event %q until %s", event.Name, event.End.Format(time.RFC3339))
+			return nil
+		}
+	}
+
+	type victim struct {
+		owner string
+		name  string
+	}
+	var victims []victim
+	m.lock.Lock()
+	for _, job := range m.jobs {
+		if job == nil || (job.Mode != JobTypeLaunch && job.Mode != JobTypeWorkflowLaunch) || job.Complete {
+			continue
+		}
+		if reason, ok := m.officeHoursState.exemptions[job.Name]; ok {
+			klog.Infof("office hours teardown skipping exempt cluster %s: %s", job.Name, reason)
+			continue
+		}
+		victims = append(victims, victim{owner: job.RequestedBy, name: job.Name})
+	}
+	m.lock.Unlock()
+
+	var reclaimed []string
+	for _, v := range victims {
+		if _, err := m.TerminateJobForUser(v.owner); err != nil {
+			klog.Warningf("office hours teardown failed for cluster %s: %v", v.name, err)
+			continue
+		}
+		reclaimed = append(reclaimed, v.name)
+	}
+	if len(reclaimed) > 0 {
+		klog.Infof("office hours teardown destroyed %d cluster(s): %v", len(reclaimed), reclaimed)
+		if m.adminNotifierFn != nil {
+			m.adminNotifierFn(fmt.Sprintf("office hours teardown at %s destroyed %d cluster(s); users can exempt a cluster with `exempt <reason>`", teardownTime, len(reclaimed)))
+		}
+	}
+
+	m.officeHoursState.lock.Lock()
+	m.officeHoursState.lastTeardown = now
+	// exemptions cover a single night; they are cleared once the sweep they
+	// were requested for has run
+	m.officeHoursState.exemptions = make(map[string]string)
+	m.officeHoursState.lock.Unlock()
+	return nil
+}
+
+// ExemptJob excludes the user's running cluster from tonight's office hours
+// teardown, recording the required justification for the admin channel.
+func (m *jobManager) ExemptJob(user, reason string) (string, error) {
+	if m.officeHoursConfig == nil {
+		return "", fmt.Errorf("automatic teardown is not configured, no exemption is needed")
+	}
+	m.lock.Lock()
+	existing, ok := m.requests[user]
+	var name string
+	if ok && len(existing.Name) > 0 {
+		if job, ok := m.jobs[existing.Name]; ok && !job.Complete {
+			name = job.Name
+		}
+	}
+	m.lock.Unlock()
+	if len(name) == 0 {
+		return "", fmt.Errorf("you don't have a running cluster to exempt")
+	}
+
+	m.officeHoursState.lock.Lock()
+	m.officeHoursState.exemptions[name] = reason
+	m.officeHoursState.lock.Unlock()
+
+	klog.Infof("cluster %s exempted from office hours teardown by %s: %s", name, user, reason)
+	if m.adminNotifierFn != nil {
+		m.adminNotifierFn(fmt.Sprintf("<@%s> exempted cluster `%s` from tonight's automatic teardown: %s", user, name, reason))
+	}
+	return fmt.Sprintf("cluster `%s` is exempt from tonight's automatic teardown; it still expires at its normal time", name), nil
+}
@@ -0,0 +1,287 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacapi "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Handing out the kubeadmin password in chat means a long-lived cluster-admin
+// credential sits in Slack history for the lifetime of the cluster. When an
+// OIDC issuer is configured, the bot instead wires the issuer into each
+// launched cluster's OAuth config as it comes up, grants the requester's
+// identity cluster-admin, and `auth token` mints a short-lived token for that
+// identity via a token exchange against the issuer.
+
+const (
+	// oidcIdentityProviderName is the identity provider entry the bot adds to
+	// a launched cluster's OAuth config.
+	oidcIdentityProviderName = "ci-chat-bot"
+	// oidcClientSecretName is the secret in openshift-config the identity
+	// provider reads the client secret from.
+	oidcClientSecretName = "ci-chat-bot-oidc-client-secret"
+)
+
+// OIDCConfig describes the issuer launched clusters authenticate against.
+type OIDCConfig struct {
+	// IssuerURL is the OIDC issuer identifier; its
+	// .well-known/openid-configuration must be reachable from the bot and the
+	// launched clusters.
+	IssuerURL string
+	// ClientID identifies the bot's client at the issuer. The issuer must
+	// allow this client to exchange tokens on behalf of mapped users.
+	ClientID string
+	// ClientSecret authenticates the bot's client.
+	ClientSecret string
+	// UsernameClaim is the claim the cluster maps to the OpenShift user name.
+	// The issuer must present the requester's Slack user name in this claim
+	// so the RBAC the bot creates lines up. Defaults to preferred_username.
+	UsernameClaim string
+}
+
+func (c OIDCConfig) enabled() bool {
+	return len(c.IssuerURL) > 0 && len(c.ClientID) > 0 && len(c.ClientSecret) > 0
+}
+
+func (c OIDCConfig) usernameClaim() string {
+	if len(c.UsernameClaim) > 0 {
+		return c.UsernameClaim
+	}
+	return "preferred_username"
+}
+
+// SetOIDCConfig configures the issuer used to set up launched clusters and to
+// mint short-lived user tokens.
+func (m *jobManager) SetOIDCConfig(cfg OIDCConfig) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.oidcConfig = cfg
+}
+
+// configureClusterOIDC adds the configured issuer to a freshly launched
+// cluster's OAuth config and grants the requester's identity cluster-admin.
+// It is best-effort - the cluster is still usable via kubeconfig and
+// kubeadmin if it fails - so errors are logged rather than failing the
+// launch.
+func (m *jobManager) configureClusterOIDC(job *Job) {
+	m.lock.Lock()
+	cfg := m.oidcConfig
+	m.lock.Unlock()
+	if !cfg.enabled() {
+		return
+	}
+	if job.Mode != JobTypeLaunch && job.Mode != JobTypeWorkflowLaunch {
+		return
+	}
+	if len(job.Credentials) == 0 || len(job.RequesterUserID) == 0 {
+		return
+	}
+
+	restConfig, err := loadKubeconfigContents(job.Credentials)
+	if err != nil {
+		klog.Errorf("job %q: unable to load kubeconfig for OIDC setup: %v", job.Name, err)
+		return
+	}
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{corev1.AddToScheme, rbacapi.AddToScheme, configv1.Install} {
+		if err := add(scheme); err != nil {
+			klog.Errorf("job %q: unable to build scheme for OIDC setup: %v", job.Name, err)
+			return
+		}
+	}
+	client, err := ctrlruntimeclient.New(restConfig, ctrlruntimeclient.Options{Scheme: scheme})
+	if err != nil {
+		klog.Errorf("job %q: unable to create client for OIDC setup: %v", job.Name, err)
+		return
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      oidcClientSecretName,
+			Namespace: "openshift-config",
+		},
+		StringData: map[string]string{"clientSecret": cfg.ClientSecret},
+	}
+	if err := client.Create(context.Background(), secret); err != nil && !k8serrors.IsAlreadyExists(err) {
+		klog.Errorf("job %q: unable to create OIDC client secret: %v", job.Name, err)
+		return
+	}
+
+	oauth := &configv1.OAuth{}
+	if err := client.Get(context.Background(), ctrlruntimeclient.ObjectKey{Name: "cluster"}, oauth); err != nil {
+		klog.Errorf("job %q: unable to read OAuth config: %v", job.Name, err)
+		return
+	}
+	provider := configv1.IdentityProvider{
+		Name:          oidcIdentityProviderName,
+		MappingMethod: configv1.MappingMethodClaim,
+		IdentityProviderConfig: configv1.IdentityProviderConfig{
+			Type: configv1.IdentityProviderTypeOpenID,
+			OpenID: &configv1.OpenIDIdentityProvider{
+				ClientID:     cfg.ClientID,
+				ClientSecret: configv1.SecretNameReference{Name: oidcClientSecretName},
+				Issuer:       strings.TrimSuffix(cfg.IssuerURL, "/"),
+				Claims: configv1.OpenIDClaims{
+					PreferredUsername: []string{cfg.usernameClaim()},
+					Name:              []string{"name"},
+					Email:             []string{"email"},
+				},
+			},
+		},
+	}
+	replaced := false
+	for i := range oauth.Spec.IdentityProviders {
+		if oauth.Spec.IdentityProviders[i].Name == oidcIdentityProviderName {
+			oauth.Spec.IdentityProviders[i] = provider
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		oauth.Spec.IdentityProviders = append(oauth.Spec.IdentityProviders, provider)
+	}
+	if err := client.Update(context.Background(), oauth); err != nil {
+		klog.Errorf("job %q: unable to update OAuth config: %v", job.Name, err)
+		return
+	}
+
+	binding := &rbacapi.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "ci-chat-bot-oidc-admin"},
+		Subjects:   []rbacapi.Subject{{Kind: "User", Name: job.RequesterUserID}},
+		RoleRef: rbacapi.RoleRef{
+			Kind: "ClusterRole",
+			Name: "cluster-admin",
+		},
+	}
+	if err := client.Create(context.Background(), binding); err != nil && !k8serrors.IsAlreadyExists(err) {
+		klog.Errorf("job %q: unable to create OIDC cluster role binding: %v", job.Name, err)
+		return
+	}
+
+	klog.Infof("Configured OIDC identity provider on cluster for job %q (user %s)", job.Name, job.RequesterUserID)
+	job.PasswordSnippet += fmt.Sprintf("\nSingle sign-on is configured: log in to the console as `%s` via the `%s` identity provider, or use `auth token` for a short-lived CLI token", job.RequesterUserID, oidcIdentityProviderName)
+}
+
+// MintClusterToken exchanges the bot's OIDC client credentials for a
+// short-lived token bound to the requester's identity and returns an oc login
+// snippet for their cluster.
+func (m *jobManager) MintClusterToken(user string) (string, error) {
+	m.lock.Lock()
+	cfg := m.oidcConfig
+	m.lock.Unlock()
+	if !cfg.enabled() {
+		return "", fmt.Errorf("the administrators have not configured an OIDC issuer")
+	}
+
+	job, err := m.GetLaunchJob(user)
+	if err != nil {
+		return "", err
+	}
+	if len(job.Credentials) == 0 {
+		return "", fmt.Errorf("the cluster is not ready yet; try again once you have received your credentials")
+	}
+	restConfig, err := loadKubeconfigContents(job.Credentials)
+	if err != nil {
+		return "", fmt.Errorf("unable to determine the cluster API server: %v", err)
+	}
+
+	tokenEndpoint, err := discoverTokenEndpoint(cfg.IssuerURL)
+	if err != nil {
+		return "", fmt.Errorf("unable to reach the OIDC issuer: %v", err)
+	}
+	botToken, _, err := requestToken(tokenEndpoint, url.Values{
+		"grant_type":    []string{"client_credentials"},
+		"client_id":     []string{cfg.ClientID},
+		"client_secret": []string{cfg.ClientSecret},
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to authenticate with the OIDC issuer: %v", err)
+	}
+	userToken, expiresIn, err := requestToken(tokenEndpoint, url.Values{
+		"grant_type":           []string{"urn:ietf:params:oauth:grant-type:token-exchange"},
+		"client_id":            []string{cfg.ClientID},
+		"client_secret":        []string{cfg.ClientSecret},
+		"subject_token":        []string{botToken},
+		"subject_token_type":   []string{"urn:ietf:params:oauth:token-type:access_token"},
+		"requested_token_type": []string{"urn:ietf:params:oauth:token-type:access_token"},
+		"requested_subject":    []string{job.RequesterUserID},
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to mint a token for `%s`: %v", job.RequesterUserID, err)
+	}
+
+	klog.Infof("Minted OIDC token for %s on job %q", job.RequesterUserID, job.Name)
+	validity := ""
+	if expiresIn > 0 {
+		validity = fmt.Sprintf(", valid for %d minutes", expiresIn/60)
+	}
+	return fmt.Sprintf("here is a token bound to `%s`%s:\n\n```oc login %s --token=%s```", job.RequesterUserID, validity, restConfig.Host, userToken), nil
+}
+
+// discoverTokenEndpoint resolves the issuer's token endpoint via OIDC
+// discovery.
+func discoverTokenEndpoint(issuer string) (string, error) {
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Get(strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("discovery returned %d", resp.StatusCode)
+	}
+	var discovery struct {
+		TokenEndpoint string `json:"token_endpoint"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&discovery); err != nil {
+		return "", err
+	}
+	if len(discovery.TokenEndpoint) == 0 {
+		return "", fmt.Errorf("discovery document has no token_endpoint")
+	}
+	return discovery.TokenEndpoint, nil
+}
+
+// requestToken posts a form to the token endpoint and returns the access
+// token and its lifetime in seconds.
+func requestToken(endpoint string, form url.Values) (string, int, error) {
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.PostForm(endpoint, form)
+	if err != nil {
+		return "", 0, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		return "", 0, fmt.Errorf("issuer returned %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", 0, err
+	}
+	if len(token.AccessToken) == 0 {
+		return "", 0, fmt.Errorf("issuer response has no access_token")
+	}
+	return token.AccessToken, token.ExpiresIn, nil
+}
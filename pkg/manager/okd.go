@@ -0,0 +1,78 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+)
+
+// OKD payloads are published to the origin imagestreams rather than the ocp
+// ones, and their installs must not assume OCP-only content (e.g. the
+// sample operator's registry credentials), so they get their own resolution
+// path and their own prow job selection. Community users on the shared
+// deployment ask for them with the okd- version prefix: `launch okd-4.16
+// aws` picks the newest accepted OKD build of that minor, `launch okd aws`
+// the newest build of the current release, and a full tag such as
+// `okd-4.16.0-0.okd-2024-01-20-103001` is looked up exactly.
+
+// okdVersionPrefix marks versions that resolve against the OKD streams.
+const okdVersionPrefix = "okd"
+
+// okdStreams are the origin imagestreams OKD releases are published to, in
+// the order they are consulted.
+var okdStreams = []string{"release", "release-scos", "release-scos-next"}
+
+// isOKDVersion reports whether the user asked for an OKD payload.
+func isOKDVersion(unresolved string) bool {
+	return unresolved == okdVersionPrefix || strings.HasPrefix(unresolved, okdVersionPrefix+"-")
+}
+
+// isOKDPayload reports whether a resolved version names an OKD build, so job
+// selection can pick OKD-compatible install workflows.
+func isOKDPayload(version string) bool {
+	return strings.Contains(version, ".okd-")
+}
+
+// resolveOKDVersion resolves the part of an okd- version after the prefix
+// against the origin imagestreams, with the same return contract as
+// ResolveImageOrVersion.
+func (m *jobManager) resolveOKDVersion(imageOrVersion, version, architecture string) (string, string, string, error) {
+	if architecture != "amd64" {
+		return "", "", "", fmt.Errorf("OKD releases are only published for amd64")
+	}
+	if len(version) == 0 {
+		version = fmt.Sprintf("%d.%d", CurrentRelease.Major, CurrentRelease.Minor)
+	}
+	for _, isName := range okdStreams {
+		is, err := m.imageClient.ImageV1().ImageStreams("origin").Get(context.TODO(), isName, metav1.GetOptions{})
+		if err != nil {
+			continue
+		}
+		if reMajorMinorVersion.MatchString(version) {
+			// OKD builds name their releases X.Y.0-0.okd and the SCOS
+			// streams X.Y.0-0.okd-scos
+			for _, release := range []string{fmt.Sprintf("%s.0-0.okd", version), fmt.Sprintf("%s.0-0.okd-scos", version)} {
+				if tag := findNewestImageSpecTagWithStream(is, release); tag != nil {
+					klog.Infof("Resolved %s to OKD tag %s", imageOrVersion, tag.Name)
+					spec := buildPullSpec("origin", tag.Name, isName)
+					return spec, tag.Name, spec, nil
+				}
+			}
+			continue
+		}
+		if tag, name := findImageStatusTag(is, version); tag != nil {
+			klog.Infof("Resolved %s to OKD image %s", imageOrVersion, tag.Image)
+			spec := buildPullSpec("origin", tag.Image, isName)
+			return spec, name, spec, nil
+		}
+		if tag := findNewestImageSpecTagWithStream(is, version); tag != nil {
+			klog.Infof("Resolved %s to OKD tag %s", imageOrVersion, tag.Name)
+			spec := buildPullSpec("origin", tag.Name, isName)
+			return spec, tag.Name, spec, nil
+		}
+	}
+	return "", "", "", fmt.Errorf("unable to find an OKD release matching %q on https://amd64.origin.releases.ci.openshift.org", imageOrVersion)
+}
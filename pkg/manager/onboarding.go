@@ -0,0 +1,68 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+
+	"github.com/openshift/ci-chat-bot/pkg/utils"
+)
+
+// First-time users used to learn the ground rules - cluster lifetimes, shared
+// quota, cloud cost - only after tripping over them. A user's first command
+// now gets a short onboarding notice instead, and their first launch is held
+// until they acknowledge it. The acknowledgment is persisted per user so the
+// notice is shown exactly once.
+
+// OnboardingSecretName is the secret used to persist terms acknowledgments.
+// Like the preference secret, it lives in the bot's namespace and each data
+// key is a slack user ID holding the time the user acknowledged.
+var OnboardingSecretName = "ci-chat-bot-onboarding"
+
+// HasAcknowledgedTerms reports whether the user has accepted the usage terms.
+// A store outage fails open so a transient API error cannot lock everyone out.
+func (m *jobManager) HasAcknowledgedTerms(user string) bool {
+	m.lock.Lock()
+	acknowledged := m.onboardedUsers[user]
+	m.lock.Unlock()
+	if acknowledged {
+		return true
+	}
+	secret, err := m.rosaSecretClient.Get(context.TODO(), OnboardingSecretName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return false
+	}
+	if err != nil {
+		klog.Warningf("Failed to read the `%s` secret, assuming %s has acknowledged the terms: %v", OnboardingSecretName, user, err)
+		return true
+	}
+	if _, ok := secret.Data[user]; !ok {
+		return false
+	}
+	m.lock.Lock()
+	m.onboardedUsers[user] = true
+	m.lock.Unlock()
+	return true
+}
+
+// AcknowledgeTerms records that the user accepted the usage terms, unblocking
+// their first launch.
+func (m *jobManager) AcknowledgeTerms(user string) (string, error) {
+	if m.HasAcknowledgedTerms(user) {
+		return "you had already accepted the usage terms - happy launching", nil
+	}
+	if err := utils.UpdateSecret(OnboardingSecretName, m.rosaSecretClient, func(secret *corev1.Secret) {
+		secret.Data[user] = []byte(time.Now().Format(time.RFC3339))
+	}); err != nil {
+		return "", fmt.Errorf("unable to record your acknowledgment, please try again: %v", err)
+	}
+	m.lock.Lock()
+	m.onboardedUsers[user] = true
+	m.lock.Unlock()
+	return "thanks! you are all set - request your first cluster with `launch`, and see `help` for everything else", nil
+}
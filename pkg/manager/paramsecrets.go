@@ -0,0 +1,43 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// JobParamSecretPrefix namespaces the secrets in the bot's namespace that users
+// may reference via the `secret=<name>` job parameter, so that only secrets
+// admins registered for this purpose can ever be injected into a job.
+const JobParamSecretPrefix = "ci-chat-bot-job-param-"
+
+// reJobParamSecretName restricts secret references to valid kube object name
+// characters
+var reJobParamSecretName = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// ResolveJobParamSecret returns the KEY=VALUE contents of the admin-registered
+// secret for the given reference, allowing users to pass sensitive values to a
+// launch without pasting them into Slack.
+func (m *jobManager) ResolveJobParamSecret(name string) (map[string]string, error) {
+	if m.rosaSecretClient == nil {
+		return nil, fmt.Errorf("secret references are not configured for this bot instance")
+	}
+	if !reJobParamSecretName.MatchString(name) {
+		return nil, fmt.Errorf("invalid secret reference `%s`", name)
+	}
+	secret, err := m.rosaSecretClient.Get(context.TODO(), JobParamSecretPrefix+name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, fmt.Errorf("no secret named `%s` has been registered by the bot administrators", name)
+		}
+		return nil, fmt.Errorf("failed to read secret `%s`: %v", name, err)
+	}
+	params := make(map[string]string, len(secret.Data))
+	for k, v := range secret.Data {
+		params[k] = string(v)
+	}
+	return params, nil
+}
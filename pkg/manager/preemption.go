@@ -0,0 +1,117 @@
+package manager
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/klog"
+)
+
+// A release-blocker launch blocked on platform quota used to wait in the
+// queue like everyone else, which defeats the point of the class when every
+// slot is held by routine work. With --enable-preemption the bot may instead
+// tear down one running cluster to free a slot: the victim is the
+// lowest-priority cluster on the platform, nearest to expiry among ties, its
+// metadata is archived so the owner can `relaunch` it, and the owner is told
+// what happened and why. Only `priority=release-blocker` requests - which are
+// already gated to the admin channel - ever trigger a preemption, and
+// clusters of equal or higher priority are never candidates.
+
+// preemptionCandidate picks the cluster to tear down for the request, or nil
+// when nothing on the platform has a strictly lower priority class. Callers
+// must hold m.lock.
+func (m *jobManager) preemptionCandidate(req *JobRequest) *Job {
+	rank := requestPriority(req)
+	var candidate *Job
+	for name, job := range m.jobs {
+		if job == nil || job.Platform != req.Platform || job.Complete || len(job.Failure) > 0 {
+			continue
+		}
+		if job.Mode != JobTypeLaunch && job.Mode != JobTypeWorkflowLaunch {
+			continue
+		}
+		if len(job.RequestedBy) == 0 || IsSpokeOwner(job.RequestedBy) {
+			continue
+		}
+		if _, pending := m.pendingDestroys[name]; pending {
+			// already on its way out; preempting it frees nothing sooner
+			continue
+		}
+		if priorityRanks[job.Priority] >= rank {
+			continue
+		}
+		if candidate == nil {
+			candidate = job
+			continue
+		}
+		switch {
+		case priorityRanks[job.Priority] < priorityRanks[candidate.Priority]:
+			candidate = job
+		case priorityRanks[job.Priority] == priorityRanks[candidate.Priority] && job.ExpiresAt.Before(candidate.ExpiresAt):
+			candidate = job
+		}
+	}
+	return candidate
+}
+
+// tryPreemptForCapacity tears down the best preemption candidate for a
+// release-blocker launch that found no free quota, returning the name of the
+// preempted cluster or the empty string when no preemption happened. The
+// freed lease only returns once the victim's deprovision finishes, so callers
+// should still park the request on the queue afterwards.
+func (m *jobManager) tryPreemptForCapacity(req *JobRequest) string {
+	m.lock.Lock()
+	enabled := m.preemptionEnabled
+	var candidate *Job
+	if enabled && requestPriority(req) == priorityRanks[PriorityReleaseBlocker] {
+		candidate = m.preemptionCandidate(req)
+	}
+	var victim Job
+	if candidate != nil {
+		victim = *candidate
+	}
+	m.lock.Unlock()
+	if candidate == nil {
+		return ""
+	}
+
+	klog.Infof("Preempting cluster %q (owner %s, priority %q, expires %s) for a release-blocker launch by %q on %s",
+		victim.Name, victim.RequestedBy, victim.Priority, victim.ExpiresAt.Format(time.RFC3339), req.User, req.Platform)
+	// archive first so the owner can relaunch an identical cluster and the
+	// pointer to the job's artifacts survives the teardown
+	if err := m.archiveJob(&victim); err != nil {
+		klog.Warningf("Failed to archive metadata for preempted job %s: %v", victim.Name, err)
+	}
+	if _, err := m.terminateLaunchedJob(victim.RequestedBy); err != nil {
+		klog.Warningf("Failed to preempt cluster %s: %v", victim.Name, err)
+		return ""
+	}
+	m.notifyPreemptedOwner(victim)
+	return victim.Name
+}
+
+// notifyPreemptedOwner tells the victim's owner their cluster was torn down
+// and how to get it back.
+func (m *jobManager) notifyPreemptedOwner(victim Job) {
+	m.lock.Lock()
+	notifier := m.queueNotifierFn
+	m.lock.Unlock()
+	message := fmt.Sprintf("your `%s` cluster `%s` was torn down to make room for a `%s` launch - sorry! I archived its metadata, so `relaunch %s` will request an identical cluster, and the job's artifacts remain available",
+		victim.Platform, victim.Name, PriorityReleaseBlocker, victim.Name)
+	if len(victim.URL) > 0 {
+		message += fmt.Sprintf(" via <%s|the job page>", victim.URL)
+	}
+	if notifier == nil {
+		klog.Infof("no queue notifier configured, dropping preemption notice for %q: %s", victim.RequestedBy, message)
+		return
+	}
+	notifier(victim.RequestedBy, victim.RequestedChannel, message)
+}
+
+// SetPreemptionEnabled toggles whether release-blocker launches may preempt
+// lower-priority clusters when a platform is out of quota.
+func (m *jobManager) SetPreemptionEnabled(enabled bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.preemptionEnabled = enabled
+}
@@ -0,0 +1,91 @@
+package manager
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPreemptionCandidate(t *testing.T) {
+	now := time.Now()
+	launch := func(name, owner, platform, priority string, expiresIn time.Duration) *Job {
+		return &Job{
+			Name:        name,
+			Mode:        JobTypeLaunch,
+			Platform:    platform,
+			Priority:    priority,
+			RequestedBy: owner,
+			ExpiresAt:   now.Add(expiresIn),
+		}
+	}
+	blocker := &JobRequest{User: "alice", Platform: "aws", JobParams: map[string]string{PriorityParam: PriorityReleaseBlocker}}
+	testCases := []struct {
+		name            string
+		req             *JobRequest
+		jobs            []*Job
+		pendingDestroys []string
+		expected        string
+	}{
+		{
+			name:     "no clusters on the platform",
+			req:      blocker,
+			jobs:     []*Job{launch("j1", "bob", "gcp", "", time.Hour)},
+			expected: "",
+		},
+		{
+			name: "lowest priority wins over nearer expiry",
+			req:  blocker,
+			jobs: []*Job{
+				launch("j1", "bob", "aws", "", 30*time.Minute),
+				launch("j2", "carol", "aws", PriorityBatch, 2*time.Hour),
+			},
+			expected: "j2",
+		},
+		{
+			name: "nearest expiry breaks priority ties",
+			req:  blocker,
+			jobs: []*Job{
+				launch("j1", "bob", "aws", "", 2*time.Hour),
+				launch("j2", "carol", "aws", PriorityNormal, 30*time.Minute),
+			},
+			expected: "j2",
+		},
+		{
+			name:     "release-blocker clusters are never candidates",
+			req:      blocker,
+			jobs:     []*Job{launch("j1", "bob", "aws", PriorityReleaseBlocker, time.Hour)},
+			expected: "",
+		},
+		{
+			name:            "clusters already pending destruction are skipped",
+			req:             blocker,
+			jobs:            []*Job{launch("j1", "bob", "aws", "", time.Hour)},
+			pendingDestroys: []string{"j1"},
+			expected:        "",
+		},
+		{
+			name:     "normal requests find no candidate among equals",
+			req:      &JobRequest{User: "alice", Platform: "aws", JobParams: map[string]string{}},
+			jobs:     []*Job{launch("j1", "bob", "aws", "", time.Hour)},
+			expected: "",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			m := &jobManager{jobs: map[string]*Job{}, pendingDestroys: map[string]time.Time{}}
+			for _, job := range testCase.jobs {
+				m.jobs[job.Name] = job
+			}
+			for _, name := range testCase.pendingDestroys {
+				m.pendingDestroys[name] = now.Add(10 * time.Minute)
+			}
+			candidate := m.preemptionCandidate(testCase.req)
+			actual := ""
+			if candidate != nil {
+				actual = candidate.Name
+			}
+			if actual != testCase.expected {
+				t.Errorf("expected candidate %q, got %q", testCase.expected, actual)
+			}
+		})
+	}
+}
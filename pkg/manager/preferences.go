@@ -0,0 +1,156 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+
+	"github.com/openshift/ci-chat-bot/pkg/i18n"
+	"github.com/openshift/ci-chat-bot/pkg/utils"
+)
+
+// PreferenceSecretName is the secret used to persist per-user default
+// preferences. Like the alias secret, it lives in the bot's namespace and each
+// data key is a slack user ID.
+var PreferenceSecretName = "ci-chat-bot-user-preferences"
+
+// validatePreference checks that a supported preference key is being set to an
+// acceptable value, so defaults can be applied later without re-validation.
+func validatePreference(name, value string) error {
+	switch name {
+	case "platform":
+		if !utils.Contains(SupportedPlatforms, value) {
+			return fmt.Errorf("platform %s is not supported, choose from: %s", value, strings.Join(SupportedPlatforms, ", "))
+		}
+	case "architecture":
+		if !utils.Contains(SupportedArchitectures, value) {
+			return fmt.Errorf("architecture %s is not supported, choose from: %s", value, strings.Join(SupportedArchitectures, ", "))
+		}
+	case "lifetime":
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("lifetime must be a duration such as `4h` or `90m`: %v", err)
+		}
+		if duration <= 0 {
+			return fmt.Errorf("lifetime must be a positive duration")
+		}
+	case "lang":
+		if !utils.Contains(i18n.SupportedLocales(), value) {
+			return fmt.Errorf("language %s is not supported, choose from: %s", value, strings.Join(i18n.SupportedLocales(), ", "))
+		}
+	case "notifications":
+		if value != "immediate" && value != "digest" {
+			return fmt.Errorf("notifications must be `immediate` or `digest`")
+		}
+	default:
+		return fmt.Errorf("unknown preference `%s`, supported preferences: `platform`, `architecture`, `lifetime`, `lang`, `notifications`", name)
+	}
+	return nil
+}
+
+func (m *jobManager) getUserPreferences(user string) (map[string]string, error) {
+	secret, err := m.rosaSecretClient.Get(context.TODO(), PreferenceSecretName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get `%s` secret: %w", PreferenceSecretName, err)
+	}
+	preferences := map[string]string{}
+	if raw, ok := secret.Data[user]; ok {
+		if err := json.Unmarshal(raw, &preferences); err != nil {
+			return nil, fmt.Errorf("Failed to unmarshal preferences for user: %w", err)
+		}
+	}
+	return preferences, nil
+}
+
+func (m *jobManager) saveUserPreferences(user string, preferences map[string]string) error {
+	raw, err := json.Marshal(preferences)
+	if err != nil {
+		return err
+	}
+	return utils.UpdateSecret(PreferenceSecretName, m.rosaSecretClient, func(secret *corev1.Secret) {
+		if len(preferences) == 0 {
+			delete(secret.Data, user)
+			return
+		}
+		secret.Data[user] = raw
+	})
+}
+
+// SetUserPreference stores a per-user default that is applied when launch
+// arguments omit the corresponding value.
+func (m *jobManager) SetUserPreference(user, name, value string) (string, error) {
+	name = strings.TrimSpace(name)
+	value = strings.TrimSpace(value)
+	if err := validatePreference(name, value); err != nil {
+		return "", err
+	}
+	preferences, err := m.getUserPreferences(user)
+	if err != nil {
+		return "", err
+	}
+	preferences[name] = value
+	if err := m.saveUserPreferences(user, preferences); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("`%s` now defaults to `%s` - unset it with `unset %s`", name, value, name), nil
+}
+
+// UnsetUserPreference deletes one of the user's stored preferences.
+func (m *jobManager) UnsetUserPreference(user, name string) (string, error) {
+	preferences, err := m.getUserPreferences(user)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := preferences[name]; !ok {
+		return "", fmt.Errorf("you have no preference named `%s`", name)
+	}
+	delete(preferences, name)
+	if err := m.saveUserPreferences(user, preferences); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("preference `%s` unset", name), nil
+}
+
+// ListUserPreferences renders the user's stored preferences.
+func (m *jobManager) ListUserPreferences(user string) (string, error) {
+	preferences, err := m.getUserPreferences(user)
+	if err != nil {
+		return "", err
+	}
+	if len(preferences) == 0 {
+		return "you have no preferences set - set one with `set <name>=<value>`", nil
+	}
+	names := make([]string, 0, len(preferences))
+	for name := range preferences {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	buf := &strings.Builder{}
+	fmt.Fprintf(buf, "you have %d preference(s) set:\n", len(preferences))
+	for _, name := range names {
+		fmt.Fprintf(buf, "• `%s` = `%s`\n", name, preferences[name])
+	}
+	return buf.String(), nil
+}
+
+// GetUserPreferences returns the user's stored preferences, or an empty map
+// when none are set or the store cannot be read.
+func (m *jobManager) GetUserPreferences(user string) map[string]string {
+	preferences, err := m.getUserPreferences(user)
+	if err != nil {
+		klog.Errorf("Failed to read preferences for user %s: %v", user, err)
+		return map[string]string{}
+	}
+	return preferences
+}
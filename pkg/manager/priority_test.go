@@ -0,0 +1,82 @@
+package manager
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidatePriority(t *testing.T) {
+	for _, value := range []string{"", PriorityReleaseBlocker, PriorityNormal, PriorityBatch} {
+		if err := ValidatePriority(value); err != nil {
+			t.Errorf("expected %q to be a valid priority, got: %v", value, err)
+		}
+	}
+	err := ValidatePriority("urgent")
+	if err == nil || !strings.Contains(err.Error(), "is not supported") {
+		t.Errorf("expected an unsupported-priority error, got: %v", err)
+	}
+}
+
+func TestShouldYieldToQueuedPriority(t *testing.T) {
+	queued := func(user, platform, priority string) *JobRequest {
+		req := &JobRequest{User: user, Platform: platform, JobParams: map[string]string{}}
+		if len(priority) > 0 {
+			req.JobParams[PriorityParam] = priority
+		}
+		return req
+	}
+	testCases := []struct {
+		name     string
+		req      *JobRequest
+		queued   []*JobRequest
+		expected bool
+	}{
+		{
+			name:     "no other queued requests",
+			req:      queued("alice", "aws", ""),
+			expected: false,
+		},
+		{
+			name:     "normal yields to release-blocker on the same platform",
+			req:      queued("alice", "aws", ""),
+			queued:   []*JobRequest{queued("bob", "aws", PriorityReleaseBlocker)},
+			expected: true,
+		},
+		{
+			name:     "release-blocker on another platform does not block",
+			req:      queued("alice", "aws", ""),
+			queued:   []*JobRequest{queued("bob", "gcp", PriorityReleaseBlocker)},
+			expected: false,
+		},
+		{
+			name:     "batch yields to normal",
+			req:      queued("alice", "aws", PriorityBatch),
+			queued:   []*JobRequest{queued("bob", "aws", PriorityNormal)},
+			expected: true,
+		},
+		{
+			name:     "equal priority does not yield",
+			req:      queued("alice", "aws", PriorityNormal),
+			queued:   []*JobRequest{queued("bob", "aws", "")},
+			expected: false,
+		},
+		{
+			name:     "release-blocker yields to nobody",
+			req:      queued("alice", "aws", PriorityReleaseBlocker),
+			queued:   []*JobRequest{queued("bob", "aws", PriorityReleaseBlocker), queued("carol", "aws", PriorityNormal)},
+			expected: false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			m := &jobManager{queuedLaunches: map[string]*JobRequest{}}
+			for _, req := range testCase.queued {
+				m.queuedLaunches[req.User] = req
+			}
+			m.queuedLaunches[testCase.req.User] = testCase.req
+			if actual := m.shouldYieldToQueuedPriority(testCase.req); actual != testCase.expected {
+				t.Errorf("expected %t, got %t", testCase.expected, actual)
+			}
+		})
+	}
+}
@@ -19,8 +19,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/blang/semver"
 	"github.com/openshift/ci-chat-bot/pkg/catalog"
 	"github.com/openshift/ci-chat-bot/pkg/prow"
+	botapiv1 "github.com/openshift/ci-chat-bot/pkg/prow/apiv1"
 	"github.com/openshift/ci-chat-bot/pkg/utils"
 
 	"k8s.io/klog"
@@ -53,10 +55,76 @@ var SupportedUpgradeTests = []string{"e2e-upgrade", "e2e-upgrade-all", "e2e-upgr
 
 // SupportedPlatforms requires a job within the release periodics that can launch a
 // cluster that has the label job-env: platform-name.
-var SupportedPlatforms = []string{"aws", "gcp", "azure", "vsphere", "metal", "ovirt", "openstack", "hypershift-hosted", "nutanix", "alibaba", "hypershift-hosted-powervs", "azure-stackhub"}
+var SupportedPlatforms = []string{"aws", "gcp", "azure", "vsphere", "metal", "ovirt", "openstack", "hypershift-hosted", "nutanix", "alibaba", "hypershift-hosted-powervs", "azure-stackhub", "ibmcloud", "powervs"}
 
 // SupportedParameters are the allowed parameter keys that can be passed to jobs
-var SupportedParameters = []string{"ovn", "ovn-hybrid", "proxy", "compact", "fips", "mirror", "shared-vpc", "large", "xlarge", "ipv4", "ipv6", "dualstack", "dualstack-primaryv6", "preserve-bootstrap", "test", "rt", "single-node", "cgroupsv2", "techpreview", "upi", "crun", "nfv", "kuryr", "sdn", "no-spot", "no-capabilities", "virtualization-support", "multi-zone", "multi-zone-techpreview", "bundle", "private"}
+var SupportedParameters = []string{"ovn", "ovn-hybrid", "proxy", "compact", "fips", "mirror", "shared-vpc", "large", "xlarge", "ipv4", "ipv6", "dualstack", "dualstack-primaryv6", "preserve-bootstrap", "test", "rt", "single-node", "cgroupsv2", "techpreview", "upi", "crun", "nfv", "kuryr", "sdn", "no-spot", "no-capabilities", "virtualization-support", "multi-zone", "multi-zone-techpreview", "bundle", "private", "assisted", FeatureSetParam, FeatureGatesParam, NetworkTypeParam, DevscriptsConfigParam, IDPParam, TagParam, InstallConfigParam, CapabilitiesParam, EncryptionParam, AuditProfileParam}
+
+// FeatureSetParam selects an install-time feature set, e.g.
+// `featureset=TechPreviewNoUpgrade`.
+const FeatureSetParam = "featureset"
+
+// FeatureGatesParam enables individual feature gates, semicolon-separated
+// since commas delimit options, e.g. `featuregates=GateA;GateB`.
+const FeatureGatesParam = "featuregates"
+
+// supportedFeatureSets are the feature sets the installer accepts; enabling
+// individual gates requires CustomNoUpgrade, which is defaulted when only
+// featuregates is passed.
+var supportedFeatureSets = sets.New[string]("TechPreviewNoUpgrade", "DevPreviewNoUpgrade", "CustomNoUpgrade")
+
+// NetworkTypeParam selects the cluster network plugin, e.g.
+// `network=OVNKubernetes`.
+const NetworkTypeParam = "network"
+
+// supportedNetworkTypes are the values accepted for NetworkTypeParam.
+// OpenShiftSDN is additionally gated on the requested version since new
+// installs no longer support it from 4.15 on.
+var supportedNetworkTypes = sets.New[string]("OVNKubernetes", "OpenShiftSDN", "Calico", "Cilium")
+
+// DevscriptsConfigParam appends extra entries to the DEVSCRIPTS_CONFIG used
+// by the metal dev-scripts workflows, semicolon-separated since commas
+// delimit options, e.g. `devscripts=IP_STACK=v6;MIRROR_IMAGES=true`. The
+// workflow defaults cover everything else.
+const DevscriptsConfigParam = "devscripts"
+
+// EncryptionParam enables etcd encryption at rest on the launched cluster,
+// e.g. `encryption=aescbc`, applied by the install workflow so security QE
+// get compliant clusters without manual post-configuration.
+const EncryptionParam = "encryption"
+
+// supportedEncryptionTypes are the APIServer encryption types accepted for
+// EncryptionParam.
+var supportedEncryptionTypes = sets.New[string]("aescbc", "aesgcm")
+
+// AuditProfileParam selects the API server audit profile, e.g.
+// `audit-profile=WriteRequestBodies`.
+const AuditProfileParam = "audit-profile"
+
+// supportedAuditProfiles are the APIServer audit profiles accepted for
+// AuditProfileParam.
+var supportedAuditProfiles = sets.New[string]("Default", "WriteRequestBodies", "AllRequestBodies", "None")
+
+// IDPParam configures an identity provider on the launched cluster so the
+// delivered credentials belong to a regular user rather than kubeadmin.
+// `idp=htpasswd` provisions an htpasswd user set; an https issuer URL
+// configures external OIDC against that issuer.
+const IDPParam = "idp"
+
+// TagParam attaches free-form labels to a cluster, semicolon-separated since
+// commas delimit options, e.g. `tag=perf;ovn`. Tags are shown by `list` and
+// can be used to filter it; they do not affect the job that is run.
+const TagParam = "tag"
+
+// IPStackPlatforms maps the IP stack variants to the platforms whose install
+// workflows can provision them. The IPv6 and dual-stack variants are backed
+// by the metal dev-scripts workflows; other platforms only install IPv4
+// clusters today.
+var IPStackPlatforms = map[string]sets.Set[string]{
+	"ipv6":                sets.New[string]("metal"),
+	"dualstack":           sets.New[string]("metal"),
+	"dualstack-primaryv6": sets.New[string]("metal"),
+}
 
 // MultistageParameters is the mapping of SupportedParameters that can be configured via multistage parameters to the correct environment variable format
 var MultistageParameters = map[string]EnvVar{
@@ -180,7 +248,7 @@ var (
 
 func testStepForPlatform(platform string) string {
 	switch platform {
-	case "aws", "aws-2", "gcp", "azure", "vsphere", "ovirt", "openstack", "nutanix", "alibaba":
+	case "aws", "aws-2", "gcp", "azure", "vsphere", "ovirt", "openstack", "nutanix", "alibaba", "ibmcloud", "powervs":
 		return "openshift-e2e-test"
 	case "metal":
 		return "baremetalds-e2e-test"
@@ -248,32 +316,7 @@ func (r *URLConfigResolver) Resolve(org, repo, branch, variant string) ([]byte,
 // stopJob triggers graceful cluster teardown. If this method returns nil,
 // it is safe to consider the cluster released.
 func (m *jobManager) stopJob(name, cluster string) error {
-	pj, err := m.prowClient.ProwJobs(m.prowNamespace).Get(context.TODO(), name, metav1.GetOptions{})
-	if err != nil {
-		if errors.IsNotFound(err) {
-			// There may have been an issue creating the prowjob; treat as success
-			return nil
-		}
-		return err
-	}
-
-	_, err = m.clusterClients[cluster].CoreClient.CoreV1().Pods(m.prowNamespace).Get(context.TODO(), name, metav1.GetOptions{})
-	if err != nil {
-		if errors.IsNotFound(err) {
-			if pj.Status.State == prowapiv1.TriggeredState {
-				return fmt.Errorf("original request is still initializing -- please try again in a few minutes")
-			}
-			// Since prowjob State != Triggered, pod creation should have been attempted.
-			// If it is not here, there's nothing to stop
-			return nil
-		}
-		return err
-	}
-
-	klog.Infof("ProwJob pod for job %q will be aborted", name)
-	pj.Status.State = prowapiv1.AbortedState
-	_, err = m.prowClient.ProwJobs(m.prowNamespace).Update(context.Background(), pj, metav1.UpdateOptions{})
-	return err
+	return m.executor.Abort(context.TODO(), name, cluster)
 }
 
 // newJob creates a ProwJob for running the provided job and exits.
@@ -296,6 +339,11 @@ func (m *jobManager) newJob(job *Job) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	// run the job on the cluster the manager routed it to, which may differ
+	// from the config's default when architecture routing is configured
+	if len(job.BuildCluster) > 0 {
+		pj.Spec.Cluster = job.BuildCluster
+	}
 
 	jobInputData, err := json.Marshal(job.Inputs)
 	if err != nil {
@@ -309,12 +357,13 @@ func (m *jobManager) newJob(job *Job) (string, error) {
 			"ci-chat-bot.openshift.io/originalMessage": job.OriginalMessage,
 			"ci-chat-bot.openshift.io/mode":            job.Mode,
 			"ci-chat-bot.openshift.io/jobParams":       paramsToString(job.JobParams),
-			"ci-chat-bot.openshift.io/user":            job.RequestedBy,
-			"ci-chat-bot.openshift.io/channel":         job.RequestedChannel,
 			"ci-chat-bot.openshift.io/ns":              namespace,
 			"ci-chat-bot.openshift.io/platform":        job.Platform,
 			"ci-chat-bot.openshift.io/jobInputs":       string(jobInputData),
 			"ci-chat-bot.openshift.io/buildCluster":    job.BuildCluster,
+			"ci-chat-bot.openshift.io/priority":        job.Priority,
+			"ci-chat-bot.openshift.io/gpu":             job.GpuPool,
+			"ci-chat-bot.openshift.io/windows":         strconv.FormatBool(job.WindowsWorkers),
 
 			"prow.k8s.io/job": pj.Spec.Job,
 
@@ -344,9 +393,18 @@ func (m *jobManager) newJob(job *Job) (string, error) {
 		pj.Annotations["release.openshift.io/from-tag"] = job.Inputs[0].Version
 		pj.Annotations["release.openshift.io/tag"] = job.Inputs[1].Version
 	}
-	// set standard annotations and environment variables
-	pj.Annotations["ci-chat-bot.openshift.io/expires"] = strconv.Itoa(int(m.maxAge.Seconds() + launchDeadline.Seconds()))
-	prow.OverrideJobEnvVar(&pj.Spec, "CLUSTER_DURATION", strconv.Itoa(int(m.maxAge.Seconds())))
+	// stamp the traceability annotations Deck and external tooling attribute
+	// jobs by; the keys are the contract in pkg/prow/apiv1
+	botapiv1.Traceability{
+		Requester:      job.RequestedBy,
+		Channel:        job.RequestedChannel,
+		CommandHash:    botapiv1.HashCommand(job.OriginalMessage),
+		CorrelationID:  job.CorrelationID,
+		ExpiresSeconds: int(m.maxAge.Seconds() + launchDeadline.Seconds()),
+	}.Apply(&pj.ObjectMeta)
+	// set standard environment variables; the grace window padding keeps the
+	// job alive long enough for the two-phase destroy in expiry.go to run
+	prow.OverrideJobEnvVar(&pj.Spec, "CLUSTER_DURATION", strconv.Itoa(int(m.maxAge.Seconds()+m.graceWindow.Seconds())))
 	if job.Mode == JobTypeBuild || job.Mode == JobTypeCatalog {
 		// keep the built payload images around for a week
 		prow.SetJobEnvVar(&pj.Spec, "PRESERVE_DURATION", "168h")
@@ -503,6 +561,137 @@ func (m *jobManager) newJob(job *Job) (string, error) {
 				matchedTarget.MultiStageTestConfiguration.Environment[envForParam.name] = envForParam.value
 			}
 		}
+		// feature gate parameters carry user-chosen values, unlike the fixed
+		// variant values in MultistageParameters, and are plumbed straight
+		// into the install steps so gated features are enabled at install
+		// time rather than by hand afterwards
+		featureSet := job.JobParams[FeatureSetParam]
+		featureGates := job.JobParams[FeatureGatesParam]
+		if len(featureSet) > 0 || len(featureGates) > 0 {
+			if len(featureGates) > 0 && len(featureSet) == 0 {
+				// individual gates can only be toggled from the custom set
+				featureSet = "CustomNoUpgrade"
+			}
+			if !supportedFeatureSets.Has(featureSet) {
+				return "", fmt.Errorf("unsupported feature set %q, supported values: %s", featureSet, strings.Join(sets.List(supportedFeatureSets), ", "))
+			}
+			if matchedTarget.MultiStageTestConfiguration.Environment == nil {
+				matchedTarget.MultiStageTestConfiguration.Environment = citools.TestEnvironment{}
+			}
+			matchedTarget.MultiStageTestConfiguration.Environment["FEATURE_SET"] = featureSet
+			if len(featureGates) > 0 {
+				gates := strings.Split(featureGates, ";")
+				for i, gate := range gates {
+					if !strings.Contains(gate, "=") {
+						gates[i] = gate + "=true"
+					}
+				}
+				encoded, err := json.Marshal(gates)
+				if err != nil {
+					return "", err
+				}
+				matchedTarget.MultiStageTestConfiguration.Environment["FEATURE_GATES"] = string(encoded)
+			}
+		}
+		if networkType := job.JobParams[NetworkTypeParam]; len(networkType) > 0 {
+			if !supportedNetworkTypes.Has(networkType) {
+				return "", fmt.Errorf("unsupported network type %q, supported values: %s", networkType, strings.Join(sets.List(supportedNetworkTypes), ", "))
+			}
+			if networkType == "OpenShiftSDN" {
+				for _, input := range job.Inputs {
+					if v, err := semver.ParseTolerant(input.Version); err == nil && (v.Major > 4 || (v.Major == 4 && v.Minor >= 15)) {
+						return "", fmt.Errorf("OpenShiftSDN is not supported for new installs of 4.15 or newer; use `network=OVNKubernetes`")
+					}
+				}
+			}
+			if matchedTarget.MultiStageTestConfiguration.Environment == nil {
+				matchedTarget.MultiStageTestConfiguration.Environment = citools.TestEnvironment{}
+			}
+			matchedTarget.MultiStageTestConfiguration.Environment["NETWORK_TYPE"] = networkType
+		}
+		if devscripts := job.JobParams[DevscriptsConfigParam]; len(devscripts) > 0 {
+			if job.Platform != "metal" {
+				return "", fmt.Errorf("`%s` is only supported on the metal platform", DevscriptsConfigParam)
+			}
+			if matchedTarget.MultiStageTestConfiguration.Environment == nil {
+				matchedTarget.MultiStageTestConfiguration.Environment = citools.TestEnvironment{}
+			}
+			// dev-scripts expects one KEY=value per line appended to its config
+			matchedTarget.MultiStageTestConfiguration.Environment["DEVSCRIPTS_CONFIG"] = strings.ReplaceAll(devscripts, ";", "\n")
+		}
+		if patch := job.JobParams[InstallConfigParam]; len(patch) > 0 {
+			var version string
+			if len(job.Inputs) > 0 {
+				version = job.Inputs[0].Version
+			}
+			env, value, err := installConfigPatchEnv(patch, version)
+			if err != nil {
+				return "", err
+			}
+			if matchedTarget.MultiStageTestConfiguration.Environment == nil {
+				matchedTarget.MultiStageTestConfiguration.Environment = citools.TestEnvironment{}
+			}
+			matchedTarget.MultiStageTestConfiguration.Environment[env] = value
+		}
+		if caps := job.JobParams[CapabilitiesParam]; len(caps) > 0 {
+			baseline, additional, err := parseCapabilitiesParam(caps)
+			if err != nil {
+				return "", err
+			}
+			if matchedTarget.MultiStageTestConfiguration.Environment == nil {
+				matchedTarget.MultiStageTestConfiguration.Environment = citools.TestEnvironment{}
+			}
+			// folded into the install-config override so it composes with a
+			// user-supplied patch
+			override := map[string]interface{}{}
+			if existing := matchedTarget.MultiStageTestConfiguration.Environment["INSTALL_CONFIG_OVERRIDE"]; len(existing) > 0 {
+				if err := json.Unmarshal([]byte(existing), &override); err != nil {
+					return "", err
+				}
+			}
+			capabilities := map[string]interface{}{"baselineCapabilitySet": baseline}
+			if len(additional) > 0 {
+				capabilities["additionalEnabledCapabilities"] = additional
+			}
+			override["capabilities"] = capabilities
+			encoded, err := json.Marshal(override)
+			if err != nil {
+				return "", err
+			}
+			matchedTarget.MultiStageTestConfiguration.Environment["INSTALL_CONFIG_OVERRIDE"] = string(encoded)
+		}
+		if encryption := job.JobParams[EncryptionParam]; len(encryption) > 0 {
+			if !supportedEncryptionTypes.Has(encryption) {
+				return "", fmt.Errorf("unsupported encryption type %q, supported values: %s", encryption, strings.Join(sets.List(supportedEncryptionTypes), ", "))
+			}
+			if matchedTarget.MultiStageTestConfiguration.Environment == nil {
+				matchedTarget.MultiStageTestConfiguration.Environment = citools.TestEnvironment{}
+			}
+			matchedTarget.MultiStageTestConfiguration.Environment["ETCD_ENCRYPTION_TYPE"] = encryption
+		}
+		if profile := job.JobParams[AuditProfileParam]; len(profile) > 0 {
+			if !supportedAuditProfiles.Has(profile) {
+				return "", fmt.Errorf("unsupported audit profile %q, supported values: %s", profile, strings.Join(sets.List(supportedAuditProfiles), ", "))
+			}
+			if matchedTarget.MultiStageTestConfiguration.Environment == nil {
+				matchedTarget.MultiStageTestConfiguration.Environment = citools.TestEnvironment{}
+			}
+			matchedTarget.MultiStageTestConfiguration.Environment["AUDIT_PROFILE"] = profile
+		}
+		if idp := utils.StripLinks(job.JobParams[IDPParam]); len(idp) > 0 {
+			if matchedTarget.MultiStageTestConfiguration.Environment == nil {
+				matchedTarget.MultiStageTestConfiguration.Environment = citools.TestEnvironment{}
+			}
+			switch {
+			case idp == "htpasswd":
+				matchedTarget.MultiStageTestConfiguration.Environment["IDP_TYPE"] = "htpasswd"
+			case strings.HasPrefix(idp, "https://"):
+				matchedTarget.MultiStageTestConfiguration.Environment["IDP_TYPE"] = "oidc"
+				matchedTarget.MultiStageTestConfiguration.Environment["OIDC_ISSUER_URL"] = idp
+			default:
+				return "", fmt.Errorf("unsupported identity provider %q, use `idp=htpasswd` or `idp=<https issuer URL>`", idp)
+			}
+		}
 		if job.Mode == JobTypeTest {
 			if strings.HasPrefix(targetName, "launch") {
 				testStep := testStepForPlatform(job.Platform)
@@ -846,31 +1035,8 @@ func (m *jobManager) newJob(job *Job) (string, error) {
 		klog.Infof("Job %q will create prow job:\n%s", job.Name, string(data))
 	}
 
-	_, err = m.prowClient.ProwJobs(m.prowNamespace).Create(context.TODO(), pj, metav1.CreateOptions{})
-	if err != nil && !errors.IsAlreadyExists(err) {
-		return "", err
-	}
-
-	// TODO: Any errors returned after this point need to make sure that they are properly handled by the enclosing logic calling newJob()
-	var prowJobURL string
-	// Wait for ProwJob URL to be assigned
-	err = wait.PollUntilContextTimeout(context.TODO(), 10*time.Second, 5*time.Minute, true, func(ctx context.Context) (bool, error) {
-		latestPJ, err := m.prowClient.ProwJobs(m.prowNamespace).Get(context.TODO(), job.Name, metav1.GetOptions{})
-		if err != nil {
-			return false, err
-		}
-
-		if len(latestPJ.Status.URL) > 0 {
-			prowJobURL = latestPJ.Status.URL
-			return true, nil
-		}
-		return false, nil
-	})
-	if err != nil {
-		return "", fmt.Errorf("did not retrieve job url due to an error: %v", err)
-	}
-
-	return prowJobURL, nil
+	// TODO: Any errors returned after the job is submitted need to make sure that they are properly handled by the enclosing logic calling newJob()
+	return m.executor.Submit(context.TODO(), pj)
 }
 
 func processOperatorPR(oldOperatorRepo string, sourceConfig, targetConfig *citools.ReleaseBuildConfiguration, job *Job, ref *prowapiv1.Refs, pj *prowapiv1.ProwJob) (string, error) {
@@ -998,9 +1164,9 @@ func processOperatorPR(oldOperatorRepo string, sourceConfig, targetConfig *citoo
 }
 
 func getClusterClient(m *jobManager, job *Job) (*utils.BuildClusterClientConfig, error) {
-	clusterClient, ok := m.clusterClients[job.BuildCluster]
+	clusterClient, ok := m.clusterClients.Get(job.BuildCluster)
 	if !ok {
-		return nil, fmt.Errorf("Cluster %s not found in %v", job.BuildCluster, m.clusterClients)
+		return nil, fmt.Errorf("Cluster %s not found in %v", job.BuildCluster, m.clusterClients.Names())
 	}
 	return clusterClient, nil
 }
@@ -1076,6 +1242,7 @@ func (m *jobManager) waitForJob(job *Job) error {
 		return nil
 	}
 	namespace := fmt.Sprintf("ci-ln-%s", namespaceSafeHash(job.Name))
+	job.TypicalDuration = m.typicalStartDuration(job)
 
 	// set up the access RBAC for the cluster Initiator
 	go m.setupAccessRBAC(job, namespace)
@@ -1086,7 +1253,7 @@ func (m *jobManager) waitForJob(job *Job) error {
 		if m.jobIsComplete(job) {
 			return false, errJobCompleted
 		}
-		latest, err := m.prowClient.ProwJobs(m.prowNamespace).Get(context.TODO(), job.Name, metav1.GetOptions{})
+		latest, err := m.executor.Get(context.TODO(), job.Name, job.BuildCluster)
 		if err != nil {
 			return false, err
 		}
@@ -1138,7 +1305,7 @@ func (m *jobManager) waitForJob(job *Job) error {
 			if m.jobIsComplete(job) {
 				return false, errJobCompleted
 			}
-			pj, err := m.prowClient.ProwJobs(m.prowNamespace).Get(context.TODO(), job.Name, metav1.GetOptions{})
+			pj, err := m.executor.Get(context.TODO(), job.Name, job.BuildCluster)
 			if err != nil {
 				return false, err
 			}
@@ -1237,6 +1404,11 @@ func (m *jobManager) waitForJob(job *Job) error {
 	klog.Infof("Job %q waiting for setup container in pod %s to complete", job.Name, namespace)
 
 	seen = false
+	// the node may evict the install pod under resource pressure; prow
+	// reschedules it under a new UID and the install starts over, so watch for
+	// pod replacement and in-place container restarts rather than going silent
+	var podUID types.UID
+	var containerRestarts int32
 	err = wait.PollUntilContextTimeout(context.TODO(), 15*time.Second, setupContainerTimeout, true, func(ctx context.Context) (bool, error) {
 		if m.jobIsComplete(job) {
 			return false, errJobCompleted
@@ -1253,6 +1425,17 @@ func (m *jobManager) waitForJob(job *Job) error {
 		if prowJobPod.Status.Phase == "Succeeded" || prowJobPod.Status.Phase == "Failed" {
 			return false, errJobCompleted
 		}
+		restarts := podRestartCount(prowJobPod)
+		switch {
+		case len(podUID) == 0:
+			podUID, containerRestarts = prowJobPod.UID, restarts
+		case prowJobPod.UID != podUID:
+			podUID, containerRestarts = prowJobPod.UID, restarts
+			m.recordJobPodRestart(job, "the install pod was evicted and rescheduled")
+		case restarts > containerRestarts:
+			containerRestarts = restarts
+			m.recordJobPodRestart(job, "a container in the install pod restarted")
+		}
 		secretDir, err := clusterClient.CoreClient.CoreV1().Secrets(namespace).Get(context.TODO(), targetName, metav1.GetOptions{})
 		if err != nil {
 			// It will take awhile before the secret is established and for the ci-chat-bot serviceaccount
@@ -1336,13 +1519,48 @@ func (m *jobManager) waitForJob(job *Job) error {
 		job.PasswordSnippet = "\nError: Unable to retrieve kubeadmin password, you must use the kubeconfig file to access the cluster"
 	}
 
+	if waitErr == nil {
+		m.configureClusterOIDC(job)
+		m.configureClusterGPU(job)
+		m.configureClusterWindows(job)
+		m.proxyCredentials(job)
+	}
+
 	created := len(pj.Annotations["ci-chat-bot.openshift.io/expires"]) == 0
 	startDuration := time.Since(started)
+	job.StartDuration = startDuration
 	m.clearNotificationAnnotations(job, created, startDuration)
 
 	return waitErr
 }
 
+// podRestartCount sums the restart counts across a pod's containers.
+func podRestartCount(pod *corev1.Pod) int32 {
+	var restarts int32
+	for _, status := range pod.Status.InitContainerStatuses {
+		restarts += status.RestartCount
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		restarts += status.RestartCount
+	}
+	return restarts
+}
+
+// recordJobPodRestart notes an eviction or restart of the job's pod, bumps the
+// capacity-tuning metric, and notifies the requester that setup is starting
+// over rather than leaving them with a stale estimate.
+func (m *jobManager) recordJobPodRestart(job *Job, reason string) {
+	job.PodRestarts++
+	jobPodRestartsMetric.WithLabelValues(job.Platform).Inc()
+	klog.Infof("Job %q: %s (%d interruptions so far)", job.Name, reason, job.PodRestarts)
+	m.lock.Lock()
+	notifier := m.jobNotifierFn
+	m.lock.Unlock()
+	if notifier != nil {
+		go notifier(*job)
+	}
+}
+
 // clearNotificationAnnotations removes the channel notification annotations in case we crash,
 // so we don't attempt to redeliver, and set the best estimate we have of the expiration time if we created the cluster
 func (m *jobManager) clearNotificationAnnotations(job *Job, created bool, startDuration time.Duration) {
@@ -0,0 +1,127 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog"
+)
+
+// When every account for a platform is out of lease quota the bot used to
+// bounce the user with a bare "try again later". Instead the request is parked
+// here: the user gets an ETA derived from the expiry times of the clusters
+// currently occupying the platform, and a background worker re-submits the
+// launch as soon as capacity frees up, pinging the user when it actually
+// starts.
+
+const (
+	// queuePollInterval is how often a parked request re-checks the account
+	// pool for free quota.
+	queuePollInterval = 2 * time.Minute
+	// queueMaxWait bounds how long a request stays parked before the user is
+	// asked to resubmit it themselves.
+	queueMaxWait = 6 * time.Hour
+)
+
+// platformCapacityETA estimates when the next slot on the platform frees,
+// using the earliest expiry among that platform's running clusters. Zero means
+// there is nothing to wait on and no estimate can be made.
+func (m *jobManager) platformCapacityETA(platform string) time.Duration {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var earliest time.Time
+	for _, job := range m.jobs {
+		if job == nil || job.Platform != platform || job.Complete || len(job.Failure) > 0 {
+			continue
+		}
+		if job.Mode != JobTypeLaunch && job.Mode != JobTypeWorkflowLaunch {
+			continue
+		}
+		if job.ExpiresAt.IsZero() {
+			continue
+		}
+		if earliest.IsZero() || job.ExpiresAt.Before(earliest) {
+			earliest = job.ExpiresAt
+		}
+	}
+	if earliest.IsZero() {
+		return 0
+	}
+	eta := time.Until(earliest)
+	if eta < time.Minute {
+		eta = time.Minute
+	}
+	return eta
+}
+
+// queueForPlatformCapacity parks a launch that is blocked on platform quota
+// and returns the message shown to the user in place of the raw quota error.
+func (m *jobManager) queueForPlatformCapacity(req *JobRequest, cause error) error {
+	m.lock.Lock()
+	if _, ok := m.queuedLaunches[req.User]; ok {
+		m.lock.Unlock()
+		return fmt.Errorf("%v; your earlier request is already queued and will start automatically when capacity frees up", cause)
+	}
+	m.queuedLaunches[req.User] = req
+	m.lock.Unlock()
+
+	go m.runQueuedLaunch(req)
+
+	msg := cause.Error()
+	if eta := m.platformCapacityETA(req.Platform); eta > 0 {
+		msg = fmt.Sprintf("%s; the earliest running `%s` cluster expires in ~%d minutes", msg, req.Platform, int(math.Ceil(eta.Minutes())))
+	}
+	return fmt.Errorf("%s. I queued your request and will start it automatically when a slot frees up, and let you know here when it does", msg)
+}
+
+// runQueuedLaunch polls for free quota, re-submits the parked launch, and
+// reports the outcome back to the user through the queue notifier.
+func (m *jobManager) runQueuedLaunch(req *JobRequest) {
+	err := wait.PollUntilContextTimeout(context.TODO(), queuePollInterval, queueMaxWait, false, func(ctx context.Context) (bool, error) {
+		// freed capacity goes to the highest priority class first; see
+		// priority.go
+		if m.shouldYieldToQueuedPriority(req) {
+			return false, nil
+		}
+		if _, err := m.selectAccount(req.Platform); err != nil {
+			return false, nil
+		}
+		return true, nil
+	})
+
+	// remove the parked entry before re-submitting so the launch can park
+	// itself again if quota disappeared between the poll and the submit
+	m.lock.Lock()
+	delete(m.queuedLaunches, req.User)
+	m.lock.Unlock()
+
+	if err != nil {
+		klog.Infof("Queued launch for %q timed out waiting for %s capacity", req.User, req.Platform)
+		m.notifyQueuedUser(req, fmt.Sprintf("I gave up waiting for free `%s` capacity after %d hours; please request your cluster again later", req.Platform, int(queueMaxWait.Hours())))
+		return
+	}
+
+	klog.Infof("Capacity freed on %s, starting queued launch for %q", req.Platform, req.User)
+	msg, launchErr := m.LaunchJobForUser(req)
+	// LaunchJobForUser reports its success message through the error return
+	if launchErr != nil {
+		msg = launchErr.Error()
+	}
+	m.notifyQueuedUser(req, msg)
+}
+
+// notifyQueuedUser delivers a queue status message to the requesting user, or
+// logs it when no notifier is wired up.
+func (m *jobManager) notifyQueuedUser(req *JobRequest, message string) {
+	m.lock.Lock()
+	notifier := m.queueNotifierFn
+	m.lock.Unlock()
+	if notifier == nil {
+		klog.Infof("no queue notifier configured, dropping message for %q: %s", req.User, message)
+		return
+	}
+	notifier(req.User, req.Channel, message)
+}
@@ -0,0 +1,70 @@
+package manager
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog"
+
+	"sigs.k8s.io/prow/pkg/metrics"
+)
+
+// reapLeakedResources scans the shared ROSA account for operator roles and
+// OIDC providers that are tagged with cluster IDs no longer known to OCM and
+// deletes them. Failed teardowns leak these resources, and they otherwise
+// accumulate until someone cleans the account by hand. Reclaimed resources are
+// reported through the reaper notifier so admins can audit what was removed.
+func (m *jobManager) reapLeakedResources() error {
+	if m.rClient == nil || m.rClient.AWSClient == nil {
+		return nil
+	}
+	live := sets.New[string]()
+	m.rosaClusters.lock.RLock()
+	for id := range m.rosaClusters.clusters {
+		live.Insert(id)
+	}
+	m.rosaClusters.lock.RUnlock()
+
+	var reclaimed []string
+	roleGroups, err := m.rClient.AWSClient.ListOperatorRoles("", "", "")
+	if err != nil {
+		metrics.RecordError(errorRosaCleanup, m.errorMetric)
+		return fmt.Errorf("failed to list operator roles: %w", err)
+	}
+	for _, roles := range roleGroups {
+		for _, role := range roles {
+			if len(role.ClusterID) == 0 || live.Has(role.ClusterID) {
+				continue
+			}
+			if _, err := m.rClient.AWSClient.DeleteOperatorRole(role.RoleName, role.ManagedPolicy, false); err != nil {
+				metrics.RecordError(errorRosaCleanup, m.errorMetric)
+				klog.Warningf("Failed to delete leaked operator role %s (cluster %s): %v", role.RoleName, role.ClusterID, err)
+				continue
+			}
+			reclaimed = append(reclaimed, fmt.Sprintf("operator role `%s` (cluster `%s`)", role.RoleName, role.ClusterID))
+		}
+	}
+	providers, err := m.rClient.AWSClient.ListOidcProviders("", nil)
+	if err != nil {
+		metrics.RecordError(errorRosaCleanup, m.errorMetric)
+		return fmt.Errorf("failed to list OIDC providers: %w", err)
+	}
+	for _, provider := range providers {
+		if len(provider.ClusterId) == 0 || live.Has(provider.ClusterId) {
+			continue
+		}
+		if err := m.rClient.AWSClient.DeleteOpenIDConnectProvider(provider.Arn); err != nil {
+			metrics.RecordError(errorRosaCleanup, m.errorMetric)
+			klog.Warningf("Failed to delete leaked OIDC provider %s (cluster %s): %v", provider.Arn, provider.ClusterId, err)
+			continue
+		}
+		reclaimed = append(reclaimed, fmt.Sprintf("OIDC provider `%s` (cluster `%s`)", provider.Arn, provider.ClusterId))
+	}
+	if len(reclaimed) > 0 {
+		klog.Infof("Reaper reclaimed %d leaked resource(s)", len(reclaimed))
+		if m.reaperNotifierFn != nil {
+			m.reaperNotifierFn(reclaimed)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,293 @@
+package manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+	"k8s.io/klog"
+	"sigs.k8s.io/yaml"
+)
+
+// ResolveImageOrVersion historically knew only about the CI release
+// imagestreams. Deployments that launch OKD, released versions, or images
+// from their own registries need other sources, so resolution is pluggable:
+// a VersionResolver turns a version string into a pullspec, and resolvers
+// are routed by prefix (e.g. `okd-4.19` strips `okd-` and asks the resolver
+// registered for that prefix). Inputs matching no configured prefix fall
+// through to the built-in imagestream lookup, so existing commands are
+// unaffected.
+
+// VersionResolver resolves a version string to a release image pullspec.
+type VersionResolver interface {
+	// Resolve returns the install pullspec, the tag or version name it
+	// resolved to, and the pullspec tests should run against. ok is false
+	// when the resolver does not know the version; err reports a failure
+	// worth surfacing to the user.
+	Resolve(version, architecture string) (installSpec, name, runSpec string, ok bool, err error)
+}
+
+// VersionResolverRoute sends versions carrying a prefix to a resolver; the
+// prefix is stripped before the resolver sees the version.
+type VersionResolverRoute struct {
+	Prefix   string
+	Resolver VersionResolver
+}
+
+// versionResolverConfig is one entry of the --version-resolvers-config file.
+type versionResolverConfig struct {
+	// Prefix routes versions starting with this string, e.g. "okd-".
+	Prefix string `json:"prefix"`
+	// Type is one of release-controller, ocm, quay, or static.
+	Type string `json:"type"`
+	// URL is the base URL for release-controller and ocm resolvers.
+	URL string `json:"url,omitempty"`
+	// Repository is the quay repository, e.g. openshift-release-dev/ocp-release.
+	Repository string `json:"repository,omitempty"`
+	// Versions maps version names to pullspecs for the static resolver.
+	Versions map[string]string `json:"versions,omitempty"`
+}
+
+// LoadVersionResolvers reads a YAML list of prefix-routed resolvers.
+func LoadVersionResolvers(path string) ([]VersionResolverRoute, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read version resolvers: %w", err)
+	}
+	var configs []versionResolverConfig
+	if err := yaml.Unmarshal(data, &configs); err != nil {
+		return nil, fmt.Errorf("unable to parse version resolvers: %w", err)
+	}
+	var routes []VersionResolverRoute
+	for i, cfg := range configs {
+		if len(cfg.Prefix) == 0 {
+			return nil, fmt.Errorf("version resolver %d has no prefix", i)
+		}
+		var resolver VersionResolver
+		switch cfg.Type {
+		case "release-controller":
+			if len(cfg.URL) == 0 {
+				return nil, fmt.Errorf("version resolver %q requires a url", cfg.Prefix)
+			}
+			resolver = &releaseControllerResolver{baseURL: strings.TrimSuffix(cfg.URL, "/")}
+		case "ocm":
+			url := cfg.URL
+			if len(url) == 0 {
+				url = "https://api.openshift.com"
+			}
+			resolver = &ocmResolver{baseURL: strings.TrimSuffix(url, "/")}
+		case "quay":
+			if len(cfg.Repository) == 0 {
+				return nil, fmt.Errorf("version resolver %q requires a repository", cfg.Prefix)
+			}
+			resolver = &quayResolver{repository: cfg.Repository}
+		case "static":
+			if len(cfg.Versions) == 0 {
+				return nil, fmt.Errorf("version resolver %q has no versions", cfg.Prefix)
+			}
+			resolver = staticResolver(cfg.Versions)
+		default:
+			return nil, fmt.Errorf("version resolver %q has unknown type %q", cfg.Prefix, cfg.Type)
+		}
+		routes = append(routes, VersionResolverRoute{Prefix: cfg.Prefix, Resolver: resolver})
+	}
+	return routes, nil
+}
+
+// SetVersionResolvers installs the prefix-routed resolvers consulted before
+// the built-in imagestream lookup.
+func (m *jobManager) SetVersionResolvers(routes []VersionResolverRoute) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.versionResolvers = routes
+}
+
+// resolveByPrefix routes a version through the configured resolvers. handled
+// is false when no prefix matched and the built-in lookup should run.
+func (m *jobManager) resolveByPrefix(version, architecture string) (installSpec, name, runSpec string, handled bool, err error) {
+	m.lock.Lock()
+	routes := m.versionResolvers
+	m.lock.Unlock()
+	for _, route := range routes {
+		if !strings.HasPrefix(version, route.Prefix) {
+			continue
+		}
+		stripped := strings.TrimPrefix(version, route.Prefix)
+		installSpec, name, runSpec, ok, err := route.Resolver.Resolve(stripped, architecture)
+		if err != nil {
+			return "", "", "", true, err
+		}
+		if !ok {
+			// the user addressed this resolver explicitly; do not fall
+			// through to the imagestreams and resolve something else
+			return "", "", "", true, fmt.Errorf("unable to find a release matching %q via the `%s` resolver", stripped, route.Prefix)
+		}
+		klog.Infof("Resolved %s to %s via the %s resolver", version, name, route.Prefix)
+		return installSpec, name, runSpec, true, nil
+	}
+	return "", "", "", false, nil
+}
+
+var resolverClient = &http.Client{Timeout: 15 * time.Second}
+
+// releaseControllerResolver resolves versions against a release controller
+// (e.g. the OKD controller at https://amd64.origin.releases.ci.openshift.org):
+// a stream name resolves to its latest accepted release, anything else is
+// looked up as a tag in the 4-stable stream.
+type releaseControllerResolver struct {
+	baseURL string
+}
+
+func (r *releaseControllerResolver) Resolve(version, _ string) (string, string, string, bool, error) {
+	release, found, err := r.fetch(fmt.Sprintf("%s/api/v1/releasestream/%s/latest", r.baseURL, url.PathEscape(version)))
+	if err != nil {
+		return "", "", "", false, err
+	}
+	if !found {
+		release, found, err = r.fetch(fmt.Sprintf("%s/api/v1/releasestream/4-stable/release/%s", r.baseURL, url.PathEscape(version)))
+		if err != nil {
+			return "", "", "", false, err
+		}
+	}
+	if !found || len(release.PullSpec) == 0 {
+		return "", "", "", false, nil
+	}
+	return release.PullSpec, release.Name, release.PullSpec, true, nil
+}
+
+func (r *releaseControllerResolver) fetch(url string) (*releaseControllerRelease, bool, error) {
+	resp, err := resolverClient.Get(url)
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to reach the release controller: %v", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("release controller returned %d", resp.StatusCode)
+	}
+	release := &releaseControllerRelease{}
+	if err := json.NewDecoder(resp.Body).Decode(release); err != nil {
+		return nil, false, err
+	}
+	return release, true, nil
+}
+
+type releaseControllerRelease struct {
+	Name     string `json:"name"`
+	PullSpec string `json:"pullSpec"`
+}
+
+// ocmResolver resolves released versions through OCM's public upgrade graph:
+// an exact version resolves to its published payload, and a major.minor
+// resolves to the newest version in its stable channel.
+type ocmResolver struct {
+	baseURL string
+}
+
+func (r *ocmResolver) Resolve(version, architecture string) (string, string, string, bool, error) {
+	parsed, err := semver.ParseTolerant(version)
+	if err != nil {
+		return "", "", "", false, nil
+	}
+	channel := fmt.Sprintf("stable-%d.%d", parsed.Major, parsed.Minor)
+	arch := architecture
+	if len(arch) == 0 {
+		arch = "amd64"
+	}
+	resp, err := resolverClient.Get(fmt.Sprintf("%s/api/upgrades_info/v1/graph?channel=%s&arch=%s", r.baseURL, url.QueryEscape(channel), url.QueryEscape(arch)))
+	if err != nil {
+		return "", "", "", false, fmt.Errorf("unable to reach OCM: %v", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return "", "", "", false, fmt.Errorf("OCM returned %d for channel %s", resp.StatusCode, channel)
+	}
+	var graph struct {
+		Nodes []struct {
+			Version string `json:"version"`
+			Payload string `json:"payload"`
+		} `json:"nodes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&graph); err != nil {
+		return "", "", "", false, err
+	}
+	exact := strings.Count(version, ".") >= 2
+	var bestVersion semver.Version
+	var bestPayload string
+	for _, node := range graph.Nodes {
+		if exact {
+			if node.Version == version {
+				return node.Payload, node.Version, node.Payload, true, nil
+			}
+			continue
+		}
+		v, err := semver.ParseTolerant(node.Version)
+		if err != nil {
+			continue
+		}
+		if len(bestPayload) == 0 || v.GT(bestVersion) {
+			bestVersion, bestPayload = v, node.Payload
+		}
+	}
+	if len(bestPayload) == 0 {
+		return "", "", "", false, nil
+	}
+	return bestPayload, bestVersion.String(), bestPayload, true, nil
+}
+
+// quayResolver resolves a version by checking the tag exists in a quay.io
+// repository.
+type quayResolver struct {
+	repository string
+}
+
+func (r *quayResolver) Resolve(version, _ string) (string, string, string, bool, error) {
+	resp, err := resolverClient.Get(fmt.Sprintf("https://quay.io/api/v1/repository/%s/tag/?specificTag=%s&onlyActiveTags=true", r.repository, url.QueryEscape(version)))
+	if err != nil {
+		return "", "", "", false, fmt.Errorf("unable to reach quay.io: %v", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return "", "", "", false, fmt.Errorf("quay.io returned %d for %s", resp.StatusCode, r.repository)
+	}
+	var tags struct {
+		Tags []struct {
+			Name string `json:"name"`
+		} `json:"tags"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tags); err != nil {
+		return "", "", "", false, err
+	}
+	for _, tag := range tags.Tags {
+		if tag.Name == version {
+			spec := fmt.Sprintf("quay.io/%s:%s", r.repository, version)
+			return spec, version, spec, true, nil
+		}
+	}
+	return "", "", "", false, nil
+}
+
+// staticResolver resolves versions from a fixed map in the config file, for
+// pinned or internal releases no public endpoint serves.
+type staticResolver map[string]string
+
+func (r staticResolver) Resolve(version, _ string) (string, string, string, bool, error) {
+	spec, ok := r[version]
+	if !ok {
+		return "", "", "", false, nil
+	}
+	return spec, version, spec, true, nil
+}
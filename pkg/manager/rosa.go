@@ -563,3 +563,54 @@ func CreateHTPasswdUserBuilder(username, password string) *clustermgmtv1.HTPassw
 func trimTagName(tagName string) string {
 	return strings.Replace(tagName, ".openshift.io", "", 1)
 }
+
+// RosaQuota summarizes how much of the shared ROSA account capacity is in use
+// so users can see whether a `rosa create` is likely to succeed before trying.
+func (m *jobManager) RosaQuota() string {
+	m.rosaClusters.lock.RLock()
+	defer m.rosaClusters.lock.RUnlock()
+	states := map[clustermgmtv1.ClusterState]int{}
+	for _, cluster := range m.rosaClusters.clusters {
+		states[cluster.State()]++
+	}
+	buf := &strings.Builder{}
+	fmt.Fprintf(buf, "%d of %d ROSA clusters in use (%d pending request(s))\n", len(m.rosaClusters.clusters), m.rosaClusterLimit, m.rosaClusters.pendingClusters)
+	remaining := m.rosaClusterLimit - len(m.rosaClusters.clusters) - m.rosaClusters.pendingClusters
+	if remaining < 0 {
+		remaining = 0
+	}
+	fmt.Fprintf(buf, "• %d cluster(s) can still be created\n", remaining)
+	for _, state := range []clustermgmtv1.ClusterState{clustermgmtv1.ClusterStateReady, clustermgmtv1.ClusterStateInstalling, clustermgmtv1.ClusterStateUninstalling, clustermgmtv1.ClusterStateError} {
+		if states[state] > 0 {
+			fmt.Fprintf(buf, "• %d cluster(s) %s\n", states[state], state)
+		}
+	}
+	return buf.String()
+}
+
+// RosaWhoami describes the OCM account and AWS creator identity the bot
+// provisions ROSA clusters with.
+func (m *jobManager) RosaWhoami() (string, error) {
+	if m.rClient == nil || m.rClient.OCMClient == nil {
+		return "", fmt.Errorf("ROSA support is not configured for this bot instance")
+	}
+	buf := &strings.Builder{}
+	account, err := m.rClient.OCMClient.GetCurrentAccount()
+	if err != nil {
+		return "", fmt.Errorf("Failed to get the current OCM account: %v", err)
+	}
+	if account != nil {
+		fmt.Fprintf(buf, "• OCM account: %s %s (`%s`)\n", account.FirstName(), account.LastName(), account.Username())
+	}
+	orgID, orgExternalID, err := m.rClient.OCMClient.GetCurrentOrganization()
+	if err != nil {
+		return "", fmt.Errorf("Failed to get the current OCM organization: %v", err)
+	}
+	fmt.Fprintf(buf, "• OCM organization: `%s` (external id `%s`)\n", orgID, orgExternalID)
+	if m.rClient.Creator != nil {
+		fmt.Fprintf(buf, "• AWS account: `%s`\n", m.rClient.Creator.AccountID)
+		fmt.Fprintf(buf, "• AWS creator ARN: `%s`\n", m.rClient.Creator.ARN)
+	}
+	fmt.Fprintf(buf, "• API endpoint: %s\n", m.rClient.OCMClient.GetConnectionURL())
+	return buf.String(), nil
+}
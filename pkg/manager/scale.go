@@ -0,0 +1,197 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Testers who need a bigger cluster - or a node of a particular shape - end
+// up hand-editing MachineSet manifests with oc, which is error-prone and
+// platform-specific. `scale workers=<n>` redistributes the worker count
+// across the cluster's existing worker MachineSets, and `add machinepool
+// type=<instance>` clones a worker MachineSet into a new pool with the given
+// instance type, with the bot handling the per-platform provider spec
+// differences.
+
+const (
+	// machineAPINamespace is where IPI clusters keep their MachineSets.
+	machineAPINamespace = "openshift-machine-api"
+	// machinePoolLabel marks MachineSets the bot created through `add
+	// machinepool`, so `scale workers` leaves them alone.
+	machinePoolLabel = "ci-chat-bot.openshift.io/machine-pool"
+	// machineRoleLabel is the machine API's role label on a MachineSet's
+	// machine template.
+	machineRoleLabel = "machine.openshift.io/cluster-api-machine-role"
+	// machineSetNameLabel ties a MachineSet's machines back to it; a cloned
+	// pool must carry its own value or the two sets fight over machines.
+	machineSetNameLabel = "machine.openshift.io/cluster-api-machineset"
+	// maxWorkerScale caps how many worker machines a user may request, since
+	// every machine bills against the platform quota.
+	maxWorkerScale = 20
+)
+
+// instanceTypeFields are the per-platform provider spec keys that hold the
+// instance type; exactly one of them is present on a given platform.
+var instanceTypeFields = []string{"instanceType", "machineType", "vmSize"}
+
+// workerMachineSets lists the cluster's original worker MachineSets,
+// excluding pools the bot added.
+func workerMachineSets(ctx context.Context, client ctrlruntimeclient.Client) ([]machinev1beta1.MachineSet, error) {
+	machineSets := &machinev1beta1.MachineSetList{}
+	if err := client.List(ctx, machineSets, ctrlruntimeclient.InNamespace(machineAPINamespace)); err != nil {
+		return nil, fmt.Errorf("unable to list the cluster's machine sets: %v", err)
+	}
+	var workers []machinev1beta1.MachineSet
+	for _, machineSet := range machineSets.Items {
+		if _, ok := machineSet.Labels[machinePoolLabel]; ok {
+			continue
+		}
+		if machineSet.Spec.Template.Labels[machineRoleLabel] != "worker" {
+			continue
+		}
+		workers = append(workers, machineSet)
+	}
+	if len(workers) == 0 {
+		return nil, fmt.Errorf("the cluster has no worker machine sets; machine pool commands only work on IPI clusters that manage their workers through the machine API")
+	}
+	return workers, nil
+}
+
+// ScaleWorkers redistributes the requested worker count across the cluster's
+// worker MachineSets, spreading machines as evenly as possible.
+func (m *jobManager) ScaleWorkers(user string, count int) (string, error) {
+	if count < 0 || count > maxWorkerScale {
+		return "", fmt.Errorf("the worker count must be between 0 and %d", maxWorkerScale)
+	}
+	client, job, err := m.launchedClusterConfigClient(user)
+	if err != nil {
+		return "", err
+	}
+	workers, err := workerMachineSets(context.TODO(), client)
+	if err != nil {
+		return "", err
+	}
+
+	base := int32(count / len(workers))
+	extra := count % len(workers)
+	for i := range workers {
+		replicas := base
+		if i < extra {
+			replicas++
+		}
+		if workers[i].Spec.Replicas != nil && *workers[i].Spec.Replicas == replicas {
+			continue
+		}
+		workers[i].Spec.Replicas = &replicas
+		if err := client.Update(context.TODO(), &workers[i]); err != nil {
+			return "", fmt.Errorf("unable to scale machine set `%s`: %v", workers[i].Name, err)
+		}
+	}
+
+	msg := fmt.Sprintf("scaled `%s` to %d worker machine(s) across %d machine set(s) - new machines take several minutes to provision and join", job.Name, count, len(workers))
+	if count == 0 {
+		msg += "; note that with zero workers the ingress routers and console will go down"
+	}
+	return msg, nil
+}
+
+// cloneWorkerMachineSet copies the first of the cluster's worker MachineSets
+// into a new, not-yet-created pool with its own name and selector, marked so
+// `scale workers` leaves it alone.
+func cloneWorkerMachineSet(ctx context.Context, client ctrlruntimeclient.Client, name string, replicas int32) (*machinev1beta1.MachineSet, error) {
+	workers, err := workerMachineSets(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	template := workers[0]
+
+	pool := template.DeepCopy()
+	labels := map[string]string{machinePoolLabel: name}
+	for key, value := range template.Labels {
+		labels[key] = value
+	}
+	pool.ObjectMeta = metav1.ObjectMeta{
+		Name:      name,
+		Namespace: machineAPINamespace,
+		Labels:    labels,
+	}
+	if pool.Spec.Selector.MatchLabels == nil || pool.Spec.Template.Labels == nil {
+		return nil, fmt.Errorf("the `%s` machine set is missing the labels a clone needs; add the pool manually with oc", template.Name)
+	}
+	pool.Spec.Selector.MatchLabels[machineSetNameLabel] = name
+	pool.Spec.Template.Labels[machineSetNameLabel] = name
+	pool.Spec.Replicas = &replicas
+	pool.Status = machinev1beta1.MachineSetStatus{}
+	return pool, nil
+}
+
+// updateProviderSpec applies a mutation to a MachineSet's raw provider spec,
+// hiding the per-platform JSON handling from callers.
+func updateProviderSpec(machineSet *machinev1beta1.MachineSet, mutate func(map[string]interface{}) error) error {
+	if machineSet.Spec.Template.Spec.ProviderSpec.Value == nil {
+		return fmt.Errorf("the machine set has no provider spec to clone")
+	}
+	var providerSpec map[string]interface{}
+	if err := json.Unmarshal(machineSet.Spec.Template.Spec.ProviderSpec.Value.Raw, &providerSpec); err != nil {
+		return fmt.Errorf("unable to read the machine set's provider spec: %v", err)
+	}
+	if err := mutate(providerSpec); err != nil {
+		return err
+	}
+	raw, err := json.Marshal(providerSpec)
+	if err != nil {
+		return err
+	}
+	machineSet.Spec.Template.Spec.ProviderSpec.Value.Raw = raw
+	return nil
+}
+
+// createMachinePool clones the first of the cluster's worker MachineSets into
+// a new pool with the given name, instance type, and size, adjusting the
+// provider spec field the platform uses.
+func createMachinePool(ctx context.Context, client ctrlruntimeclient.Client, platform, name, instanceType string, replicas int32) error {
+	pool, err := cloneWorkerMachineSet(ctx, client, name, replicas)
+	if err != nil {
+		return err
+	}
+	if err := updateProviderSpec(pool, func(providerSpec map[string]interface{}) error {
+		for _, field := range instanceTypeFields {
+			if _, ok := providerSpec[field]; ok {
+				providerSpec[field] = instanceType
+				return nil
+			}
+		}
+		return fmt.Errorf("unable to find the instance type in the `%s` provider spec; machine pools are supported on AWS, GCP, and Azure clusters", platform)
+	}); err != nil {
+		return err
+	}
+
+	if err := client.Create(ctx, pool); err != nil {
+		if k8serrors.IsAlreadyExists(err) {
+			return fmt.Errorf("a `%s` machine pool already exists on your cluster", instanceType)
+		}
+		return fmt.Errorf("unable to create the machine pool: %v", err)
+	}
+	return nil
+}
+
+// AddMachinePool clones one of the cluster's worker MachineSets into a new
+// single-machine pool with the given instance type.
+func (m *jobManager) AddMachinePool(user, instanceType string) (string, error) {
+	client, job, err := m.launchedClusterConfigClient(user)
+	if err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("chat-bot-pool-%s", strings.ReplaceAll(strings.ToLower(instanceType), ".", "-"))
+	if err := createMachinePool(context.TODO(), client, job.Platform, name, instanceType, 1); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("created machine pool `%s` with one `%s` machine on `%s` - the machine takes several minutes to provision and join, and `scale workers` will not touch this pool", name, instanceType, job.Name), nil
+}
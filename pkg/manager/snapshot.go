@@ -0,0 +1,170 @@
+package manager
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/ci-chat-bot/pkg/utils"
+)
+
+// Recreating "the cluster I had last sprint" from memory - the right nightly,
+// the install-config patch, the feature gates - is error-prone, and the job
+// history only reaches back a few days. `snapshot` captures the significant
+// configuration of the caller's running cluster (the resolved release inputs,
+// platform and architecture, and every job parameter including an attached
+// install-config patch) into a named bundle, and `launch from-snapshot=<id>`
+// replays it later, with any explicitly passed options taking precedence over
+// the snapshot's.
+
+// SnapshotSecretName is the secret used to persist snapshot bundles. Like the
+// job history secret, it lives in the bot's namespace and each data key is a
+// snapshot ID.
+var SnapshotSecretName = "ci-chat-bot-snapshots"
+
+// snapshotRetention is how long a snapshot is kept before it is pruned; it is
+// deliberately much longer than the job history retention since snapshots are
+// an explicit "I will want this again" signal.
+const snapshotRetention = 90 * 24 * time.Hour
+
+// FromSnapshotParam is the launch option naming the snapshot to recreate.
+const FromSnapshotParam = "from-snapshot"
+
+// ClusterSnapshot is the reusable bundle `snapshot` captures. Inputs hold the
+// resolved versions rather than what the user originally typed, so replaying
+// the snapshot pins the exact releases instead of whatever `nightly` means
+// that day.
+type ClusterSnapshot struct {
+	ID           string            `json:"id"`
+	User         string            `json:"user"`
+	UserName     string            `json:"userName,omitempty"`
+	SourceJob    string            `json:"sourceJob,omitempty"`
+	Platform     string            `json:"platform"`
+	Architecture string            `json:"architecture,omitempty"`
+	Inputs       [][]string        `json:"inputs,omitempty"`
+	JobParams    map[string]string `json:"jobParams,omitempty"`
+	CreatedAt    time.Time         `json:"createdAt"`
+}
+
+// SnapshotCluster captures the caller's running cluster configuration into a
+// new snapshot bundle and returns how to replay it.
+func (m *jobManager) SnapshotCluster(user string) (string, error) {
+	job, err := m.GetLaunchJob(user)
+	if err != nil {
+		return "", err
+	}
+	data := make([]byte, 4)
+	if _, err := rand.Read(data); err != nil {
+		return "", err
+	}
+	id := fmt.Sprintf("snap-%s", hex.EncodeToString(data))
+	snapshot := ClusterSnapshot{
+		ID:           id,
+		User:         user,
+		UserName:     job.RequesterUserID,
+		SourceJob:    job.Name,
+		Platform:     job.Platform,
+		Architecture: job.Architecture,
+		Inputs:       jobInputStrings(job.Inputs),
+		JobParams:    job.JobParams,
+		CreatedAt:    time.Now(),
+	}
+	raw, err := json.Marshal(snapshot)
+	if err != nil {
+		return "", err
+	}
+	if err := utils.UpdateSecret(SnapshotSecretName, m.rosaSecretClient, func(secret *corev1.Secret) {
+		for name, data := range secret.Data {
+			var existing ClusterSnapshot
+			if err := json.Unmarshal(data, &existing); err != nil || time.Since(existing.CreatedAt) > snapshotRetention {
+				delete(secret.Data, name)
+			}
+		}
+		secret.Data[id] = raw
+	}); err != nil {
+		return "", fmt.Errorf("unable to store the snapshot: %v", err)
+	}
+	return fmt.Sprintf("captured `%s` from `%s` - %s on %s/%s with %d parameter(s). Recreate an equivalent cluster any time in the next %d days with `launch from-snapshot=%s`",
+		id, job.Name, describeSnapshotInputs(snapshot.Inputs), snapshot.Platform, snapshot.Architecture, len(snapshot.JobParams), int(snapshotRetention.Hours()/24), id), nil
+}
+
+// describeSnapshotInputs renders the captured inputs for listings.
+func describeSnapshotInputs(inputs [][]string) string {
+	var parts []string
+	for _, input := range inputs {
+		parts = append(parts, strings.Join(input, ","))
+	}
+	description := strings.Join(parts, " to ")
+	if len(description) == 0 {
+		description = "default version"
+	}
+	return description
+}
+
+// GetClusterSnapshot loads a snapshot for replay, enforcing that only its
+// creator may use it.
+func (m *jobManager) GetClusterSnapshot(user, id string) (*ClusterSnapshot, error) {
+	secret, err := m.rosaSecretClient.Get(context.TODO(), SnapshotSecretName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, fmt.Errorf("no snapshot named `%s` was found", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get `%s` secret: %w", SnapshotSecretName, err)
+	}
+	raw, ok := secret.Data[id]
+	if !ok {
+		return nil, fmt.Errorf("no snapshot named `%s` was found - snapshots are kept for %d days; `snapshot list` shows yours", id, int(snapshotRetention.Hours()/24))
+	}
+	var snapshot ClusterSnapshot
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal snapshot: %w", err)
+	}
+	if snapshot.User != user {
+		return nil, fmt.Errorf("only the user who captured `%s` may launch from it", id)
+	}
+	return &snapshot, nil
+}
+
+// ListClusterSnapshots renders the caller's snapshots, newest first.
+func (m *jobManager) ListClusterSnapshots(user string) (string, error) {
+	secret, err := m.rosaSecretClient.Get(context.TODO(), SnapshotSecretName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return "you have no snapshots - capture your running cluster's configuration with `snapshot`", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("Failed to get `%s` secret: %w", SnapshotSecretName, err)
+	}
+	var snapshots []ClusterSnapshot
+	for _, raw := range secret.Data {
+		var snapshot ClusterSnapshot
+		if err := json.Unmarshal(raw, &snapshot); err != nil {
+			continue
+		}
+		if snapshot.User != user || time.Since(snapshot.CreatedAt) > snapshotRetention {
+			continue
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	if len(snapshots) == 0 {
+		return "you have no snapshots - capture your running cluster's configuration with `snapshot`", nil
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].CreatedAt.After(snapshots[j].CreatedAt)
+	})
+	buf := &strings.Builder{}
+	fmt.Fprintf(buf, "you have %d snapshot(s), newest first - replay one with `launch from-snapshot=<id>`:\n", len(snapshots))
+	for _, snapshot := range snapshots {
+		fmt.Fprintf(buf, "• `%s` - %s on %s/%s, %d parameter(s), captured %s\n",
+			snapshot.ID, describeSnapshotInputs(snapshot.Inputs), snapshot.Platform, snapshot.Architecture, len(snapshot.JobParams), snapshot.CreatedAt.Format("2006-01-02"))
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,71 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+
+	"github.com/openshift/ci-chat-bot/pkg/utils"
+)
+
+// SSHKeySecretName is the secret used to persist per-user SSH public keys.
+// Like the alias secret, it lives in the bot's namespace and each data key is
+// a slack user ID.
+var SSHKeySecretName = "ci-chat-bot-user-ssh-keys"
+
+// sshKeyTypes are the public key types the bot accepts for injection into the
+// install config
+var sshKeyTypes = []string{"ssh-rsa", "ssh-ed25519", "ecdsa-sha2-nistp256", "ecdsa-sha2-nistp384", "ecdsa-sha2-nistp521"}
+
+// SetUserSSHKey stores the user's SSH public key; launches inject it into the
+// install config so node-level debugging does not require editing machine
+// configs after the fact.
+func (m *jobManager) SetUserSSHKey(user, key string) (string, error) {
+	key = strings.TrimSpace(key)
+	fields := strings.Fields(key)
+	if len(fields) < 2 || !utils.Contains(sshKeyTypes, fields[0]) {
+		return "", fmt.Errorf("that doesn't look like an SSH public key - expected something like `ssh-ed25519 AAAA... user@host` with a type of %s", strings.Join(sshKeyTypes, ", "))
+	}
+	if err := utils.UpdateSecret(SSHKeySecretName, m.rosaSecretClient, func(secret *corev1.Secret) {
+		secret.Data[user] = []byte(key)
+	}); err != nil {
+		return "", err
+	}
+	return "your SSH public key is saved - future launches will inject it into the install config. Remove it with `unset ssh-key`", nil
+}
+
+// RemoveUserSSHKey deletes the user's stored SSH public key.
+func (m *jobManager) RemoveUserSSHKey(user string) (string, error) {
+	key, ok := m.GetUserSSHKey(user)
+	if !ok || len(key) == 0 {
+		return "", fmt.Errorf("you have no SSH public key registered")
+	}
+	if err := utils.UpdateSecret(SSHKeySecretName, m.rosaSecretClient, func(secret *corev1.Secret) {
+		delete(secret.Data, user)
+	}); err != nil {
+		return "", err
+	}
+	return "your SSH public key has been removed - future launches will no longer inject it", nil
+}
+
+// GetUserSSHKey returns the user's stored SSH public key, if one is registered.
+func (m *jobManager) GetUserSSHKey(user string) (string, bool) {
+	secret, err := m.rosaSecretClient.Get(context.TODO(), SSHKeySecretName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return "", false
+	}
+	if err != nil {
+		klog.Errorf("Failed to get `%s` secret: %v", SSHKeySecretName, err)
+		return "", false
+	}
+	key, ok := secret.Data[user]
+	if !ok {
+		return "", false
+	}
+	return string(key), true
+}
@@ -0,0 +1,70 @@
+package manager
+
+import (
+	"fmt"
+	"time"
+
+	clustermgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog"
+
+	"sigs.k8s.io/prow/pkg/metrics"
+)
+
+const (
+	// rosaUninstallRetryAfter is how long a cluster may sit in the
+	// uninstalling state before the delete is re-issued in best-effort mode.
+	rosaUninstallRetryAfter = 2 * time.Hour
+	// rosaUninstallEscalateAfter is how long a cluster may sit in the
+	// uninstalling state before admins are told manual cleanup is needed.
+	rosaUninstallEscalateAfter = 4 * time.Hour
+)
+
+// verifyRosaTeardowns tracks how long each cluster has been uninstalling and
+// escalates teardowns that do not finish. Uninstalls occasionally wedge on
+// leftover cloud resources; a first retry re-issues the delete in best-effort
+// mode, and if the cluster still does not go away admins are notified that
+// manual intervention is required.
+func (m *jobManager) verifyRosaTeardowns(clusters map[string]*clustermgmtv1.Cluster) {
+	m.rosaUninstalls.lock.Lock()
+	defer m.rosaUninstalls.lock.Unlock()
+	if m.rosaUninstalls.started == nil {
+		m.rosaUninstalls.started = map[string]time.Time{}
+		m.rosaUninstalls.retried = sets.New[string]()
+		m.rosaUninstalls.escalated = sets.New[string]()
+	}
+	for id, cluster := range clusters {
+		if cluster.State() != clustermgmtv1.ClusterStateUninstalling {
+			continue
+		}
+		started, ok := m.rosaUninstalls.started[id]
+		if !ok {
+			m.rosaUninstalls.started[id] = time.Now()
+			continue
+		}
+		elapsed := time.Since(started)
+		if elapsed > rosaUninstallRetryAfter && !m.rosaUninstalls.retried.Has(id) {
+			klog.Warningf("Cluster %s has been uninstalling for %d minutes; re-issuing delete in best-effort mode", id, elapsed/time.Minute)
+			if _, err := m.rClient.OCMClient.DeleteCluster(id, true, m.rClient.Creator); err != nil {
+				metrics.RecordError(errorRosaDelete, m.errorMetric)
+				klog.Errorf("Failed to re-issue delete for cluster %s: %v", id, err)
+			}
+			m.rosaUninstalls.retried.Insert(id)
+		}
+		if elapsed > rosaUninstallEscalateAfter && !m.rosaUninstalls.escalated.Has(id) {
+			metrics.RecordError(errorRosaCleanup, m.errorMetric)
+			if m.adminNotifierFn != nil {
+				m.adminNotifierFn(fmt.Sprintf("rosa cluster `%s` (`%s`) has been uninstalling for %d minutes and did not respond to a best-effort retry; manual cleanup of its cloud resources is likely required", cluster.Name(), id, elapsed/time.Minute))
+			}
+			m.rosaUninstalls.escalated.Insert(id)
+		}
+	}
+	// drop tracking for clusters that finished uninstalling or changed state
+	for id := range m.rosaUninstalls.started {
+		if cluster, ok := clusters[id]; !ok || cluster.State() != clustermgmtv1.ClusterStateUninstalling {
+			delete(m.rosaUninstalls.started, id)
+			m.rosaUninstalls.retried.Delete(id)
+			m.rosaUninstalls.escalated.Delete(id)
+		}
+	}
+}
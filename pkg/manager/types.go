@@ -121,6 +121,14 @@ var rosaClustersMetric = prometheus.NewGauge(
 	},
 )
 
+var jobPodRestartsMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "ci_chat_bot_job_pod_restarts_total",
+		Help: "cluster bot number of launch job pod evictions or container restarts observed while waiting for clusters",
+	},
+	[]string{"platform"},
+)
+
 type EnvVar struct {
 	name      string
 	value     string
@@ -144,7 +152,105 @@ type RosaSubnets struct {
 
 type WorkflowConfig struct {
 	Workflows map[string]WorkflowConfigItem `yaml:"workflows"`
-	Mutex     sync.RWMutex                  `yaml:"-"` // this field just allows us to update the above values without races
+	// Presets maps an admin-defined preset name to a comma-delimited launch
+	// options list that users can invoke with `preset=<name>`
+	Presets map[string]string `yaml:"presets,omitempty"`
+	// MicroshiftWorkflow names the workflow (which must also appear in
+	// Workflows) that `launch microshift` provisions a MicroShift VM with;
+	// empty disables the command
+	MicroshiftWorkflow string `yaml:"microshiftWorkflow,omitempty"`
+	// PerfscaleProfiles maps a profile name users invoke with `perfscale
+	// <profile>` to a predefined performance/scale run; empty disables the
+	// command
+	PerfscaleProfiles map[string]PerfscaleProfile `yaml:"perfscaleProfiles,omitempty"`
+	Mutex             sync.RWMutex                `yaml:"-"` // this field just allows us to update the above values without races
+}
+
+// PerfscaleProfile describes one predefined performance/scale run: the
+// workflow that installs the sized cluster and drives the workload
+// (kube-burner, ingress-perf, etc.), the inputs it runs with, and where its
+// results are published.
+type PerfscaleProfile struct {
+	// Description is shown in the profile listing and the launch reply.
+	Description string `yaml:"description,omitempty"`
+	// Workflow names the workflow (which must also appear in Workflows) the
+	// profile runs.
+	Workflow string `yaml:"workflow"`
+	// Version is the release the profile runs against when the caller does
+	// not pass one; empty defaults to the newest nightly.
+	Version string `yaml:"version,omitempty"`
+	// Parameters are job parameters the workflow runs with, e.g. the worker
+	// count or the kube-burner workload name.
+	Parameters map[string]string `yaml:"parameters,omitempty"`
+	// GrafanaURL and ElasticsearchURL point at the dashboards and index the
+	// workload publishes its results into; set ones are linked in the reply.
+	GrafanaURL       string `yaml:"grafanaURL,omitempty"`
+	ElasticsearchURL string `yaml:"elasticsearchURL,omitempty"`
+}
+
+// OfficeHoursConfig describes the daily window outside of which non-exempt
+// clusters are automatically destroyed, reducing overnight cloud waste.
+type OfficeHoursConfig struct {
+	// TeardownTime is the local wall-clock time, in "15:04" form, at which all
+	// non-exempt launched clusters are destroyed each day. Empty disables
+	// automatic teardown.
+	TeardownTime string `yaml:"teardownTime"`
+	// Timezone is the IANA time zone name TeardownTime is interpreted in;
+	// defaults to UTC.
+	Timezone string `yaml:"timezone,omitempty"`
+	// Events are windows, such as a scheduled overnight scale test, during
+	// which automatic teardown is suspended.
+	Events []OfficeHoursEvent `yaml:"events,omitempty"`
+	Mutex  sync.RWMutex       `yaml:"-"` // this field just allows us to update the above values without races
+}
+
+type OfficeHoursEvent struct {
+	Name  string    `yaml:"name"`
+	Start time.Time `yaml:"start"`
+	End   time.Time `yaml:"end"`
+}
+
+// CostBudgetConfig assigns monthly cost budgets to groups of Slack users.
+// Spend is estimated from cluster runtime at a flat hourly rate.
+type CostBudgetConfig struct {
+	// HourlyRate is the estimated cost, in dollars, of one cluster-hour.
+	HourlyRate float64 `yaml:"hourlyRate"`
+	// OverrideToken, when set, lets a user launch past an exhausted budget by
+	// passing `override=<token>` in the launch options.
+	OverrideToken string            `yaml:"overrideToken,omitempty"`
+	Groups        []CostBudgetGroup `yaml:"groups"`
+	Mutex         sync.RWMutex      `yaml:"-"` // this field just allows us to update the above values without races
+}
+
+type CostBudgetGroup struct {
+	Name string `yaml:"name"`
+	// Users are the Slack user IDs whose launches are charged to this group.
+	Users []string `yaml:"users"`
+	// MonthlyBudget is the group's budget in dollars; zero disables
+	// enforcement for the group.
+	MonthlyBudget float64 `yaml:"monthlyBudget"`
+}
+
+// MaintenanceConfig describes recurring weekly windows during which new
+// cluster launches are refused, e.g. while CI infrastructure is upgraded.
+type MaintenanceConfig struct {
+	// Timezone is the IANA time zone name the window times are interpreted
+	// in; defaults to UTC.
+	Timezone string              `yaml:"timezone,omitempty"`
+	Windows  []MaintenanceWindow `yaml:"windows"`
+	Mutex    sync.RWMutex        `yaml:"-"` // this field just allows us to update the above values without races
+}
+
+type MaintenanceWindow struct {
+	// Day is the weekday name the window recurs on, e.g. Saturday.
+	Day string `yaml:"day"`
+	// Start is the local wall-clock time, in "15:04" form, the window opens.
+	Start string `yaml:"start"`
+	// Duration is how long the window lasts, in Go duration form, e.g. 4h.
+	Duration string `yaml:"duration"`
+	// Message is extra context shown to users whose launch is refused or
+	// whose cluster's lifetime crosses the window.
+	Message string `yaml:"message,omitempty"`
 }
 
 type WorkflowConfigItem struct {
@@ -166,6 +272,37 @@ type jobManager struct {
 	jobs                 map[string]*Job
 	started              time.Time
 	recentStartEstimates []time.Duration
+	// startHistory keeps recent setup durations per platform/version bucket;
+	// see estimates.go
+	startHistory map[string][]time.Duration
+	// queuedLaunches holds requests parked on platform quota, keyed by user;
+	// see queue.go
+	queuedLaunches map[string]*JobRequest
+	// onboardedUsers caches which users have acknowledged the usage terms;
+	// see onboarding.go
+	onboardedUsers map[string]bool
+	// deprecatedVersions lists versions that warn before launching, and
+	// deprecatedLaunches counts requests against them; see deprecation.go
+	deprecatedVersions []DeprecatedVersion
+	deprecatedLaunches map[string]int
+	// supportMatrix rules out version/platform/architecture/variant
+	// combinations at parse time; see matrix.go
+	supportMatrix []SupportMatrixRule
+	// recentFailures is a bounded history of failed launches for the operator
+	// dashboard; see dashboard.go
+	recentFailures []DashboardFailure
+	// credentialDelivery describes where `auth to` may write kubeconfigs; see
+	// credentials.go
+	credentialDelivery CredentialDeliveryConfig
+	// oidcConfig describes the issuer launched clusters authenticate against;
+	// see oidc.go
+	oidcConfig OIDCConfig
+	// auditProxy, when set, routes launched clusters' credentials through a
+	// per-user access log; see auditproxy.go
+	auditProxy *AuditProxy
+	// versionResolvers routes prefixed version strings to alternate release
+	// sources; see resolvers.go
+	versionResolvers []VersionResolverRoute
 
 	clusterPrefix string
 	maxClusters   int
@@ -179,11 +316,16 @@ type jobManager struct {
 	hiveConfigMapClient corev1.ConfigMapInterface
 	clusterClients      utils.BuildClusterClientConfigMap
 	prowNamespace       string
+	executor            Executor
 	githubClient        github.Client
 	forcePROwner        string
 
 	configResolver ConfigResolver
 
+	// envPresetURL is the base URL environment presets for workflow commands
+	// are resolved against; empty disables env-preset support
+	envPresetURL string
+
 	muJob struct {
 		lock    sync.Mutex
 		running map[string]struct{}
@@ -206,6 +348,12 @@ type jobManager struct {
 		lock     sync.RWMutex
 		versions []string
 	}
+	rosaUninstalls struct {
+		lock      sync.Mutex
+		started   map[string]time.Time
+		retried   sets.Set[string]
+		escalated sets.Set[string]
+	}
 	rosaClusterLimit         int
 	rosaClusterAdminUsername string
 	rosaSubnets              *RosaSubnets
@@ -237,6 +385,60 @@ type jobManager struct {
 	}
 	mceNotifierFn MCECallbackFunc
 	mceConfig     MceConfig
+
+	reaperNotifierFn   ReaperCallbackFunc
+	adminNotifierFn    AdminCallbackFunc
+	teardownNotifierFn TeardownCallbackFunc
+	queueNotifierFn    QueueCallbackFunc
+
+	// graceWindow defers destruction after expiry so the owner can keep
+	// artifacts or extend; pendingDestroys maps job name to the moment the
+	// deferral ends; see expiry.go
+	graceWindow      time.Duration
+	pendingDestroys  map[string]time.Time
+	expiryNotifierFn ExpiryCallbackFunc
+
+	// preemptionEnabled allows a release-blocker launch blocked on quota to
+	// tear down a lower-priority cluster; see preemption.go
+	preemptionEnabled bool
+
+	// chaosRuns maps job name to the chaos scenario currently running
+	// against it, so only one disruption hits a cluster at a time; see
+	// chaos.go
+	chaosRuns       map[string]string
+	chaosNotifierFn ChaosCallbackFunc
+
+	// conformanceRuns maps a launch's job name to the suite currently
+	// running against it; conformanceImage holds the openshift-tests image
+	// admins configured; see conformance.go
+	conformanceRuns       map[string]string
+	conformanceImage      string
+	conformanceNotifierFn ConformanceCallbackFunc
+
+	// backupRuns maps a launch's job name to its in-flight backup job, so a
+	// cluster only takes one etcd backup at a time; see backup.go
+	backupRuns       map[string]string
+	backupNotifierFn BackupCallbackFunc
+
+	officeHoursConfig *OfficeHoursConfig
+	costBudgetConfig  *CostBudgetConfig
+	maintenanceConfig *MaintenanceConfig
+
+	// buildClusterArchitectures maps build cluster names to the payload
+	// architectures they can run; empty disables architecture routing
+	buildClusterArchitectures map[string]sets.Set[string]
+	officeHoursState          struct {
+		lock         sync.Mutex
+		lastTeardown time.Time
+		exemptions   map[string]string
+	}
+
+	versionWatchNotifierFn VersionWatchCallbackFunc
+	versionWatches         struct {
+		lock sync.Mutex
+		// entries maps user to watch spec (e.g. `4.17-nightly`) to watch state
+		entries map[string]map[string]*versionWatch
+	}
 }
 
 // JobRequest keeps information about the request a user made to create
@@ -265,6 +467,10 @@ type JobRequest struct {
 	RequestedAt time.Time
 	Name        string
 
+	// CorrelationID identifies the Slack command that created this request in
+	// logs, the ProwJob, and artifacts.
+	CorrelationID string
+
 	JobName   string
 	JobParams map[string]string
 
@@ -279,18 +485,97 @@ type JobManager interface {
 	SetNotifier(JobCallbackFunc)
 	SetRosaNotifier(RosaCallbackFunc)
 	SetMceNotifier(MCECallbackFunc)
+	SetReaperNotifier(ReaperCallbackFunc)
+	SetAdminNotifier(AdminCallbackFunc)
+	SetVersionWatchNotifier(VersionWatchCallbackFunc)
+	SetTeardownNotifier(TeardownCallbackFunc)
+	SetQueueNotifier(QueueCallbackFunc)
+	SetExecutor(Executor)
+	SetCredentialDelivery(CredentialDeliveryConfig)
+	SetOIDCConfig(OIDCConfig)
+	SetAuditProxy(*AuditProxy)
+	SetVersionResolvers([]VersionResolverRoute)
+	SetExpiryNotifier(ExpiryCallbackFunc)
+	SetExpiryGraceWindow(time.Duration)
+	SetPreemptionEnabled(bool)
+	SetChaosNotifier(ChaosCallbackFunc)
+	RunChaosScenario(user, channel, scenario string) (string, error)
+	RunTimeSkew(user, channel, offset string) (string, error)
+	RunCertRotation(user, channel string) (string, error)
+	SetConformanceNotifier(ConformanceCallbackFunc)
+	SetConformanceImage(image string)
+	RunConformance(user, channel, suite string) (string, error)
+	SetBackupNotifier(BackupCallbackFunc)
+	RunEtcdBackup(user, channel string) (string, error)
+	RunClusterUpgrade(user, channel, target, updateChannel string, force bool) (string, error)
+	RunClusterUpgradeAbort(user, channel string) (string, error)
+	ScaleWorkers(user string, count int) (string, error)
+	AddMachinePool(user, instanceType string) (string, error)
+	AddGpuPool(user, spec string) (string, error)
+	KeepArtifacts(requester, name string) (string, error)
+	DashboardSnapshot() DashboardData
+	DeliverCredentials(user, destination string) (string, error)
+	MintClusterToken(user string) (string, error)
 
 	LaunchJobForUser(req *JobRequest) (string, error)
+	ExplainJobRequest(req *JobRequest) (string, error)
 	CreateRosaCluster(user, channel, version string, duration time.Duration) (string, error)
 	CheckValidJobConfiguration(req *JobRequest) error
 	SyncJobForUser(user string) (string, error)
 	TerminateJobForUser(user string) (string, error)
+	TerminateAllForUser(user string) (string, error)
+	TerminateJobByName(name string) (string, error)
+	ExtendJobExpiry(requester, name string, extension time.Duration) (string, error)
+	RelaunchJob(user, channel, jobName string) (string, error)
+	JobHistory(user string) (string, error)
+	SnapshotCluster(user string) (string, error)
+	ListClusterSnapshots(user string) (string, error)
+	GetClusterSnapshot(user, id string) (*ClusterSnapshot, error)
+	SupportedVersions() (string, error)
+	TeamClusterChannels() []string
+	ExemptJob(user, reason string) (string, error)
+	CostReport(user string) (string, error)
+	InspectPayload(imageOrVersion string, components []string) (string, error)
+	ImageInfo(imageOrVersion, component string) (string, error)
+	AddVersionWatch(user, channel, spec string) (string, error)
+	RemoveVersionWatch(user, spec string) (string, error)
+	PayloadStatus(version string) (string, error)
+	AdminListAll(timezone *time.Location) string
+	AdminTerminatePlatform(platform string) (string, error)
+	AdminExtendAll(extension time.Duration) (string, error)
+	AdminExtendJob(name string, extension time.Duration) (string, error)
+	AdminSetMaxClusters(limit int) (string, error)
+	AdminAuditLog(name string) (string, error)
+	AdminDeprecationReport() string
+	DeprovisionUser(user string) (string, error)
+	SetDeprecatedVersions([]DeprecatedVersion)
+	SetSupportMatrix([]SupportMatrixRule)
+	ClusterOwners() map[string]string
+	BroadcastTargets(platform, version string) map[string]string
 	GetLaunchJob(user string) (*Job, error)
 	GetROSACluster(user string) (*clustermgmtv1.Cluster, string)
 	DescribeROSACluster(cluster string) (string, error)
+	RosaQuota() string
+	RosaWhoami() (string, error)
 	LookupInputs(inputs []string, architecture string) (string, error)
 	LookupRosaInputs(versionPrefix string) (string, error)
-	ListJobs(users string, filters ListFilters) string
+	ListJobs(users string, timezone *time.Location, filters ListFilters) string
+	CompareJobRuns(jobA, jobB string) (string, error)
+	SetUserAlias(user, name, expansion string) (string, error)
+	RemoveUserAlias(user, name string) (string, error)
+	ListUserAliases(user string) (string, error)
+	ExpandUserAlias(user, text string) (string, bool)
+	ResolveEnvPreset(name string) (map[string]string, error)
+	ResolveJobParamSecret(name string) (map[string]string, error)
+	SetUserSSHKey(user, key string) (string, error)
+	SetUserPreference(user, name, value string) (string, error)
+	HasAcknowledgedTerms(user string) bool
+	AcknowledgeTerms(user string) (string, error)
+	UnsetUserPreference(user, name string) (string, error)
+	ListUserPreferences(user string) (string, error)
+	GetUserPreferences(user string) map[string]string
+	RemoveUserSSHKey(user string) (string, error)
+	GetUserSSHKey(user string) (string, bool)
 	GetWorkflowConfig() *WorkflowConfig
 	ResolveImageOrVersion(imageOrVersion, defaultImageOrVersion, architecture string) (string, string, string, error)
 	ResolveAsPullRequest(spec string) (*prowapiv1.Refs, error)
@@ -298,7 +583,7 @@ type JobManager interface {
 	CreateMceCluster(user, channel, platform, imageset string, duration time.Duration) (string, error)
 	DeleteMceCluster(user, clusterName string) (string, error)
 	GetManagedClustersForUser(user string) (map[string]*clusterv1.ManagedCluster, map[string]*hivev1.ClusterDeployment, map[string]*hivev1.ClusterProvision, map[string]string, map[string]string)
-	ListManagedClusters(user string) string
+	ListManagedClusters(user string, timezone *time.Location) string
 	ListMceVersions() string
 	GetMceUserConfig() *MceConfig
 }
@@ -315,6 +600,42 @@ type RosaCallbackFunc func(*clustermgmtv1.Cluster, string)
 // way. Takes the ManagedCluster object, kubeconfig, and admin password.
 type MCECallbackFunc func(*clusterv1.ManagedCluster, *hivev1.ClusterDeployment, *hivev1.ClusterProvision, string, string)
 
+// ReaperCallbackFunc is invoked when the resource reaper reclaims leaked cloud
+// resources. Takes descriptions of the resources that were deleted.
+type ReaperCallbackFunc func(reclaimed []string)
+
+// TeardownCallbackFunc is called when a terminated launch's deprovision step
+// finishes and the cluster's cloud resources have actually been freed.
+type TeardownCallbackFunc func(user, channel, jobName string, duration time.Duration)
+
+// ExpiryCallbackFunc is called when an expired cluster enters its grace
+// window, with the moment destruction will proceed.
+type ExpiryCallbackFunc func(job Job, deadline time.Time)
+
+// ChaosCallbackFunc is called when a chaos scenario finishes with the
+// recovery result for the requesting user.
+type ChaosCallbackFunc func(user, channel, message string)
+
+// ConformanceCallbackFunc is called when a conformance run finishes with the
+// pass/fail outcome for the requesting user.
+type ConformanceCallbackFunc func(user, channel, message string)
+
+// BackupCallbackFunc is called when an etcd backup finishes with the link to
+// the uploaded snapshot for the requesting user.
+type BackupCallbackFunc func(user, channel, message string)
+
+// QueueCallbackFunc is invoked when a launch parked on platform quota changes
+// state - when it finally starts, or when the bot gives up waiting.
+type QueueCallbackFunc func(user, channel, message string)
+
+// VersionWatchCallbackFunc is invoked when a new accepted payload matching a
+// user's watch appears on the release controller.
+type VersionWatchCallbackFunc func(user, channel, spec, version string)
+
+// AdminCallbackFunc is invoked when a condition requires administrator
+// attention, such as a teardown that needs manual intervention.
+type AdminCallbackFunc func(message string)
+
 // JobInput defines the input to a job. Different modes need different inputs.
 type JobInput struct {
 	Image    string
@@ -340,6 +661,18 @@ type Job struct {
 
 	Mode string
 
+	// Priority is the launch's priority class (see priority.go); empty means
+	// PriorityNormal.
+	Priority string
+
+	// GpuPool is the `<count>x<model>` GPU pool requested at launch (see
+	// gpu.go); empty means no GPU machines.
+	GpuPool string
+
+	// WindowsWorkers records that the launch asked for Windows worker nodes
+	// (see windows.go).
+	WindowsWorkers bool
+
 	Inputs []JobInput
 
 	Credentials     string
@@ -349,6 +682,7 @@ type Job struct {
 	RequestedBy      string
 	RequesterUserID  string
 	RequestedChannel string
+	CorrelationID    string
 
 	RequestedAt   time.Time
 	ExpiresAt     time.Time
@@ -358,6 +692,21 @@ type Job struct {
 	Architecture string
 	BuildCluster string
 
+	// GroupID ties hub and spoke clusters launched together; it holds the hub
+	// job's name and is empty for standalone jobs.
+	GroupID string
+
+	// PodRestarts counts how many times the prow job's pod was evicted or had a
+	// container restart while we waited for the cluster, so users can be told
+	// why setup is taking longer than usual.
+	PodRestarts int
+
+	// TypicalDuration is the median setup time of recent launches with the
+	// same platform and minor version, or zero when there is no history yet.
+	// It is recomputed each time a worker starts waiting on the job, so it is
+	// advisory only and not recovered from the ProwJob after a restart.
+	TypicalDuration time.Duration
+
 	WorkflowName string
 
 	UseSecondaryAccount bool
@@ -382,6 +731,7 @@ type ListFilters struct {
 	Platform  string
 	Version   string
 	Requestor string
+	Tag       string
 }
 
 type MceConfig struct {
@@ -0,0 +1,263 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Upgrade testing usually means launching a fresh cluster through `test
+// upgrade`, but plenty of questions - does my operator survive the update,
+// does this workload keep serving - need the upgrade to happen on the cluster
+// the user is already holding. `upgrade to` patches ClusterVersion on the
+// caller's running cluster, exactly as an administrator would, then watches
+// the rollout and reports completion or the operators that degraded. It
+// shares the one-disruption-at-a-time slot and the chaos notifier with the
+// chaos machinery, since an upgrade should never race a chaos scenario.
+
+const (
+	// upgradeTimeout bounds how long the rollout may take before the bot
+	// reports it as stuck; minor-version updates normally finish within it.
+	upgradeTimeout = 2 * time.Hour
+	// upgradePollInterval is how often ClusterVersion is re-checked.
+	upgradePollInterval = time.Minute
+)
+
+// clusterConfigClient returns a client for the config and machine API
+// resources of a launched cluster, built from its admin kubeconfig.
+func clusterConfigClient(job *Job) (ctrlruntimeclient.Client, error) {
+	if len(job.Credentials) == 0 {
+		return nil, fmt.Errorf("the cluster is not ready yet; try again once you have received your credentials")
+	}
+	restConfig, err := loadKubeconfigContents(job.Credentials)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the cluster's kubeconfig: %v", err)
+	}
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{corev1.AddToScheme, configv1.Install, machinev1beta1.Install} {
+		if err := add(scheme); err != nil {
+			return nil, fmt.Errorf("unable to build the config scheme: %v", err)
+		}
+	}
+	client, err := ctrlruntimeclient.New(restConfig, ctrlruntimeclient.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to the cluster: %v", err)
+	}
+	return client, nil
+}
+
+// launchedClusterConfigClient resolves the caller's launched cluster and
+// returns a config client for it.
+func (m *jobManager) launchedClusterConfigClient(user string) (ctrlruntimeclient.Client, *Job, error) {
+	job, err := m.GetLaunchJob(user)
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := clusterConfigClient(job)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, job, nil
+}
+
+// RunClusterUpgrade patches ClusterVersion on the caller's running cluster to
+// the given version or release pullspec, optionally switching the update
+// channel and forcing past verification, then watches the rollout and
+// reports the outcome asynchronously.
+func (m *jobManager) RunClusterUpgrade(user, channel, target, updateChannel string, force bool) (string, error) {
+	client, job, err := m.launchedClusterConfigClient(user)
+	if err != nil {
+		return "", err
+	}
+
+	desired := configv1.Update{Force: force}
+	// a release pullspec contains a registry path; anything else is treated
+	// as a version the update channel must offer
+	if strings.ContainsAny(target, "/@") {
+		desired.Image = target
+	} else {
+		desired.Version = target
+	}
+
+	if err := m.claimChaosRun(job.Name, fmt.Sprintf("upgrade to %s", target)); err != nil {
+		return "", err
+	}
+
+	cv := &configv1.ClusterVersion{}
+	if err := client.Get(context.TODO(), ctrlruntimeclient.ObjectKey{Name: "version"}, cv); err != nil {
+		m.releaseChaosRun(job.Name)
+		return "", fmt.Errorf("unable to read the cluster's ClusterVersion: %v", err)
+	}
+	current := cv.Status.Desired.Version
+	if len(updateChannel) > 0 {
+		cv.Spec.Channel = updateChannel
+	}
+	cv.Spec.DesiredUpdate = &desired
+	if err := client.Update(context.TODO(), cv); err != nil {
+		m.releaseChaosRun(job.Name)
+		if len(desired.Version) > 0 && !force {
+			return "", fmt.Errorf("the cluster rejected the update: %v - if `%s` is not in the current channel's available updates, pass the release pullspec instead, or add `force`", err, target)
+		}
+		return "", fmt.Errorf("the cluster rejected the update: %v", err)
+	}
+
+	go m.watchClusterUpgrade(client, job.Name, user, channel, target, "upgrade")
+	msg := fmt.Sprintf("upgrading `%s` from `%s` to `%s` in place - I will report completion or degraded operators here, and give up after %d hours", job.Name, current, target, int(upgradeTimeout.Hours()))
+	if len(updateChannel) > 0 {
+		msg += fmt.Sprintf("; the update channel is now `%s`", updateChannel)
+	}
+	return msg, nil
+}
+
+// RunClusterUpgradeAbort applies the documented recovery for an in-flight
+// in-place upgrade: ClusterVersion is force-set back to the last completed
+// release, and the bot watches whether the cluster returns to a healthy
+// state. The in-flight upgrade's watcher stands down once it sees its target
+// superseded.
+func (m *jobManager) RunClusterUpgradeAbort(user, channel string) (string, error) {
+	client, job, err := m.launchedClusterConfigClient(user)
+	if err != nil {
+		return "", err
+	}
+
+	cv := &configv1.ClusterVersion{}
+	if err := client.Get(context.TODO(), ctrlruntimeclient.ObjectKey{Name: "version"}, cv); err != nil {
+		return "", fmt.Errorf("unable to read the cluster's ClusterVersion: %v", err)
+	}
+	if len(cv.Status.History) == 0 || cv.Status.History[0].State == configv1.CompletedUpdate {
+		return "", fmt.Errorf("no upgrade is in progress on `%s`", job.Name)
+	}
+	var previous *configv1.UpdateHistory
+	for i := range cv.Status.History[1:] {
+		if entry := &cv.Status.History[i+1]; entry.State == configv1.CompletedUpdate {
+			previous = entry
+			break
+		}
+	}
+	if previous == nil {
+		return "", fmt.Errorf("`%s` has never completed a release rollout, so there is nothing to roll back to; `done` the cluster and launch a fresh one", job.Name)
+	}
+
+	if err := m.takeOverUpgradeClaim(job.Name, fmt.Sprintf("upgrade abort back to %s", previous.Version)); err != nil {
+		return "", err
+	}
+	// rolling back to an already-verified release still needs force, since
+	// the CVO refuses to move backwards on its own
+	cv.Spec.DesiredUpdate = &configv1.Update{Image: previous.Image, Force: true}
+	if err := client.Update(context.TODO(), cv); err != nil {
+		m.releaseChaosRun(job.Name)
+		return "", fmt.Errorf("the cluster rejected the rollback: %v", err)
+	}
+
+	go m.watchClusterUpgrade(client, job.Name, user, channel, previous.Image, "rollback")
+	return fmt.Sprintf("aborting the in-place upgrade of `%s` and rolling back to `%s` - components that already updated must walk themselves back, so this is best effort and rollbacks across minor versions are not supported; I will report here whether the cluster returns to a healthy state", job.Name, previous.Version), nil
+}
+
+// takeOverUpgradeClaim lets the abort replace an in-flight upgrade's
+// disruption claim, so the rollback holds the slot the upgrade held, while
+// still refusing to run over an unrelated disruption.
+func (m *jobManager) takeOverUpgradeClaim(jobName, label string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.chaosRuns == nil {
+		m.chaosRuns = make(map[string]string)
+	}
+	if running, ok := m.chaosRuns[jobName]; ok && !strings.HasPrefix(running, "upgrade ") {
+		return fmt.Errorf("`%s` is still running against your cluster; wait for its result before starting another disruption", running)
+	}
+	m.chaosRuns[jobName] = label
+	return nil
+}
+
+// watchClusterUpgrade polls ClusterVersion until the rollout to the target
+// completes or times out, then delivers the outcome with any degraded
+// operators. The watcher stands down quietly when another actor - the abort
+// helper, or the user via oc - points the cluster at a different release.
+func (m *jobManager) watchClusterUpgrade(client ctrlruntimeclient.Client, jobName, user, channel, target, action string) {
+	superseded := false
+	defer func() {
+		if !superseded {
+			m.releaseChaosRun(jobName)
+		}
+	}()
+
+	started := time.Now()
+	var failing string
+	err := wait.PollUntilContextTimeout(context.TODO(), upgradePollInterval, upgradeTimeout, false, func(ctx context.Context) (bool, error) {
+		cv := &configv1.ClusterVersion{}
+		if err := client.Get(ctx, ctrlruntimeclient.ObjectKey{Name: "version"}, cv); err != nil {
+			// the API server may bounce mid-upgrade
+			klog.V(2).Infof("Upgrade of %s: unable to check ClusterVersion: %v", jobName, err)
+			return false, nil
+		}
+		if desired := cv.Spec.DesiredUpdate; desired == nil || (desired.Version != target && desired.Image != target) {
+			superseded = true
+			return true, nil
+		}
+		failing = ""
+		for _, condition := range cv.Status.Conditions {
+			if condition.Type == configv1.ClusterStatusConditionType("Failing") && condition.Status == configv1.ConditionTrue {
+				failing = condition.Message
+			}
+		}
+		if len(cv.Status.History) == 0 {
+			return false, nil
+		}
+		latest := cv.Status.History[0]
+		return latest.State == configv1.CompletedUpdate && (latest.Version == target || latest.Image == target), nil
+	})
+	if superseded {
+		klog.Infof("Upgrade watch for %s: target %s superseded, standing down", jobName, target)
+		return
+	}
+	duration := time.Since(started).Round(time.Minute)
+
+	degraded := degradedClusterOperators(client)
+	var message string
+	switch {
+	case err != nil:
+		message = fmt.Sprintf("the %s of `%s` to `%s` did not complete within %d hours", action, jobName, target, int(upgradeTimeout.Hours()))
+		if len(failing) > 0 {
+			message += fmt.Sprintf(" - the cluster reports: %s", failing)
+		}
+	case len(degraded) > 0:
+		message = fmt.Sprintf("the %s of `%s` to `%s` completed in %s, but some operators are degraded", action, jobName, target, duration)
+	default:
+		message = fmt.Sprintf("the %s of `%s` to `%s` completed in %s and all operators are healthy", action, jobName, target, duration)
+	}
+	if len(degraded) > 0 {
+		message += fmt.Sprintf("\n> degraded or unavailable operators: %s", strings.Join(degraded, ", "))
+	}
+	m.notifyChaosResult(user, channel, message)
+}
+
+// degradedClusterOperators lists the operators reporting Degraded or not
+// Available, best effort; an empty slice means a clean bill of health.
+func degradedClusterOperators(client ctrlruntimeclient.Client) []string {
+	operators := &configv1.ClusterOperatorList{}
+	if err := client.List(context.TODO(), operators); err != nil {
+		return nil
+	}
+	var degraded []string
+	for _, operator := range operators.Items {
+		for _, condition := range operator.Status.Conditions {
+			if (condition.Type == configv1.OperatorDegraded && condition.Status == configv1.ConditionTrue) ||
+				(condition.Type == configv1.OperatorAvailable && condition.Status == configv1.ConditionFalse) {
+				degraded = append(degraded, fmt.Sprintf("`%s`", operator.Name))
+				break
+			}
+		}
+	}
+	sort.Strings(degraded)
+	return degraded
+}
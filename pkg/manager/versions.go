@@ -0,0 +1,197 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// releaseStreamsByArchitecture maps each supported architecture to the release
+// controller imagestream that advertises its launchable payloads. These mirror
+// the streams ResolveImageOrVersion resolves against.
+var releaseStreamsByArchitecture = map[string]struct {
+	Namespace   string
+	Imagestream string
+}{
+	"amd64": {Namespace: "ocp", Imagestream: "release"},
+	"arm64": {Namespace: "ocp-arm64", Imagestream: "release-arm64"},
+	"multi": {Namespace: "ocp-multi", Imagestream: "release-multi"},
+}
+
+// launchableMinorsForArchitecture collects the distinct major.minor versions
+// with at least one accepted payload in the architecture's release stream.
+func (m *jobManager) launchableMinorsForArchitecture(architecture string) ([]string, error) {
+	stream, ok := releaseStreamsByArchitecture[architecture]
+	if !ok {
+		return nil, fmt.Errorf("Unsupported architecture: %s", architecture)
+	}
+	is, err := m.imageClient.ImageV1().ImageStreams(stream.Namespace).Get(context.TODO(), stream.Imagestream, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s/%s imagestream: %w", stream.Namespace, stream.Imagestream, err)
+	}
+	var minors semver.Versions
+	seen := map[string]bool{}
+	for _, tag := range is.Spec.Tags {
+		if tag.Annotations["release.openshift.io/phase"] != "Accepted" {
+			continue
+		}
+		v, err := semver.ParseTolerant(tag.Name)
+		if err != nil {
+			continue
+		}
+		minor := semver.Version{Major: v.Major, Minor: v.Minor}
+		key := fmt.Sprintf("%d.%d", minor.Major, minor.Minor)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		minors = append(minors, minor)
+	}
+	sort.Sort(minors)
+	var out []string
+	for _, v := range minors {
+		out = append(out, fmt.Sprintf("%d.%d", v.Major, v.Minor))
+	}
+	return out, nil
+}
+
+// ForceLaunchParam is the launch option that overrides the nightly payload
+// health gate for users who really do want a rejected or untested payload.
+const ForceLaunchParam = "force"
+
+// checkNightlyPayloadHealth verifies that a nightly payload was accepted by
+// the release controller's blocking jobs before a cluster is launched from
+// it. Users can override the gate with the `force` option.
+func (m *jobManager) checkNightlyPayloadHealth(version, architecture string) error {
+	if !strings.Contains(version, "nightly") {
+		return nil
+	}
+	stream, ok := releaseStreamsByArchitecture[architecture]
+	if !ok {
+		return nil
+	}
+	is, err := m.imageClient.ImageV1().ImageStreams(stream.Namespace).Get(context.TODO(), stream.Imagestream, metav1.GetOptions{})
+	if err != nil {
+		// the gate is advisory; do not block launches when the release
+		// controller state cannot be read
+		return nil
+	}
+	for _, tag := range is.Spec.Tags {
+		if tag.Name != version {
+			continue
+		}
+		switch phase := tag.Annotations["release.openshift.io/phase"]; phase {
+		case "Accepted":
+			return nil
+		case "Rejected":
+			return fmt.Errorf("nightly payload `%s` was rejected by the release controller's blocking jobs; add the `%s` option to launch it anyway", version, ForceLaunchParam)
+		default:
+			return fmt.Errorf("nightly payload `%s` has not passed the release controller's blocking jobs yet (phase: %s); add the `%s` option to launch it anyway", version, phase, ForceLaunchParam)
+		}
+	}
+	return nil
+}
+
+// verificationStatus mirrors the per-job entries of the release controller's
+// `release.openshift.io/verify` annotation.
+type verificationStatus struct {
+	State   string `json:"state"`
+	URL     string `json:"url"`
+	Retries int    `json:"retries,omitempty"`
+}
+
+// PayloadStatus summarizes the release-gating job results for a payload from
+// the release controller's verification state.
+func (m *jobManager) PayloadStatus(version string) (string, error) {
+	for _, architecture := range SupportedArchitectures {
+		stream := releaseStreamsByArchitecture[architecture]
+		is, err := m.imageClient.ImageV1().ImageStreams(stream.Namespace).Get(context.TODO(), stream.Imagestream, metav1.GetOptions{})
+		if err != nil {
+			continue
+		}
+		for _, tag := range is.Spec.Tags {
+			if tag.Name != version {
+				continue
+			}
+			buf := &strings.Builder{}
+			phase := tag.Annotations["release.openshift.io/phase"]
+			fmt.Fprintf(buf, "payload `%s` (%s) is in phase *%s*", version, architecture, phase)
+			if message := tag.Annotations["release.openshift.io/message"]; len(message) > 0 {
+				fmt.Fprintf(buf, ": %s", message)
+			}
+			fmt.Fprintf(buf, "\n")
+			verify := tag.Annotations["release.openshift.io/verify"]
+			if len(verify) == 0 {
+				fmt.Fprintf(buf, "no verification job results have been recorded yet")
+				return buf.String(), nil
+			}
+			var results map[string]verificationStatus
+			if err := json.Unmarshal([]byte(verify), &results); err != nil {
+				return "", fmt.Errorf("failed to parse verification results for %s: %w", version, err)
+			}
+			var succeeded int
+			names := make([]string, 0, len(results))
+			for name := range results {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				result := results[name]
+				var marker string
+				switch result.State {
+				case "Succeeded":
+					marker = "✅"
+					succeeded++
+				case "Failed":
+					marker = "❌"
+				default:
+					marker = "⏳"
+				}
+				if len(result.URL) > 0 {
+					fmt.Fprintf(buf, "• %s <%s|%s>", marker, result.URL, name)
+				} else {
+					fmt.Fprintf(buf, "• %s %s", marker, name)
+				}
+				if result.Retries > 0 {
+					fmt.Fprintf(buf, " (%d retries)", result.Retries)
+				}
+				fmt.Fprintf(buf, "\n")
+			}
+			fmt.Fprintf(buf, "%d of %d gating jobs succeeded", succeeded, len(results))
+			return buf.String(), nil
+		}
+	}
+	return "", fmt.Errorf("payload `%s` was not found in any release controller stream", version)
+}
+
+// SupportedVersions renders the currently launchable minor versions per
+// architecture from release controller state, so users do not have to guess
+// what `launch` will accept.
+func (m *jobManager) SupportedVersions() (string, error) {
+	buf := &strings.Builder{}
+	fmt.Fprintf(buf, "currently launchable versions, from release controller state:\n")
+	for _, architecture := range SupportedArchitectures {
+		minors, err := m.launchableMinorsForArchitecture(architecture)
+		if err != nil {
+			return "", err
+		}
+		if len(minors) == 0 {
+			fmt.Fprintf(buf, "• %s: no accepted payloads right now\n", architecture)
+			continue
+		}
+		note := ""
+		if architecture == "multi" {
+			note = " (hypershift-hosted only)"
+		}
+		fmt.Fprintf(buf, "• %s%s: %s\n", architecture, note, strings.Join(minors, ", "))
+	}
+	fmt.Fprintf(buf, "the default version for `launch` with no arguments is the latest %d.%d nightly. ", CurrentRelease.Major, CurrentRelease.Minor)
+	fmt.Fprintf(buf, "platforms: `%s`. ", strings.Join(SupportedPlatforms, "`, `"))
+	fmt.Fprintf(buf, "for ROSA versions use `rosa lookup`, and for MCE imagesets use `mce versions`")
+	return buf.String(), nil
+}
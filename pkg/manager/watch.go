@@ -0,0 +1,172 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+)
+
+// versionWatch is a user's request to be notified when a new accepted payload
+// matching a spec like `4.17-nightly` appears on the release controller.
+type versionWatch struct {
+	user      string
+	channel   string
+	spec      string
+	minor     string
+	stream    string
+	lastSeen  string
+	createdAt time.Time
+}
+
+// parseWatchSpec splits a spec like `4.17-nightly` into the major.minor
+// version and the stream to match. The stream defaults to nightly.
+func parseWatchSpec(spec string) (string, string, error) {
+	minor, stream, found := strings.Cut(spec, "-")
+	if !found {
+		stream = "nightly"
+	}
+	if stream != "nightly" && stream != "ci" {
+		return "", "", fmt.Errorf("unsupported stream `%s`, use `nightly` or `ci`", stream)
+	}
+	if v, err := semver.ParseTolerant(minor); err != nil || v.Major == 0 {
+		return "", "", fmt.Errorf("`%s` is not a version; use a major.minor like `4.17-nightly`", spec)
+	}
+	return minor, stream, nil
+}
+
+// newestAcceptedVersion returns the newest accepted payload in the amd64
+// release stream whose version matches the minor and stream.
+func (m *jobManager) newestAcceptedVersion(minor, stream string) (string, error) {
+	target := releaseStreamsByArchitecture["amd64"]
+	is, err := m.imageClient.ImageV1().ImageStreams(target.Namespace).Get(context.TODO(), target.Imagestream, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get %s/%s imagestream: %w", target.Namespace, target.Imagestream, err)
+	}
+	var newest string
+	var newestVersion semver.Version
+	for _, tag := range is.Spec.Tags {
+		if tag.Annotations["release.openshift.io/phase"] != "Accepted" {
+			continue
+		}
+		if !strings.Contains(tag.Name, stream) {
+			continue
+		}
+		v, err := semver.ParseTolerant(tag.Name)
+		if err != nil {
+			continue
+		}
+		if fmt.Sprintf("%d.%d", v.Major, v.Minor) != minor {
+			continue
+		}
+		if len(newest) == 0 || v.GT(newestVersion) {
+			newest = tag.Name
+			newestVersion = v
+		}
+	}
+	return newest, nil
+}
+
+func (m *jobManager) SetVersionWatchNotifier(fn VersionWatchCallbackFunc) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.versionWatchNotifierFn = fn
+}
+
+func (m *jobManager) AddVersionWatch(user, channel, spec string) (string, error) {
+	minor, stream, err := parseWatchSpec(spec)
+	if err != nil {
+		return "", err
+	}
+	// baseline against the current newest payload so only payloads accepted
+	// after the watch was created trigger a notification
+	lastSeen, err := m.newestAcceptedVersion(minor, stream)
+	if err != nil {
+		return "", err
+	}
+	m.versionWatches.lock.Lock()
+	defer m.versionWatches.lock.Unlock()
+	if m.versionWatches.entries[user] == nil {
+		m.versionWatches.entries[user] = make(map[string]*versionWatch)
+	}
+	if _, ok := m.versionWatches.entries[user][spec]; ok {
+		return fmt.Sprintf("you are already watching `%s`", spec), nil
+	}
+	m.versionWatches.entries[user][spec] = &versionWatch{
+		user:      user,
+		channel:   channel,
+		spec:      spec,
+		minor:     minor,
+		stream:    stream,
+		lastSeen:  lastSeen,
+		createdAt: time.Now(),
+	}
+	msg := fmt.Sprintf("watching `%s` - you will be notified here when a new accepted %s payload for %s appears; stop with `unwatch %s`", spec, stream, minor, spec)
+	if len(lastSeen) > 0 {
+		msg += fmt.Sprintf(" (the current newest is `%s`)", lastSeen)
+	}
+	return msg, nil
+}
+
+func (m *jobManager) RemoveVersionWatch(user, spec string) (string, error) {
+	m.versionWatches.lock.Lock()
+	defer m.versionWatches.lock.Unlock()
+	if _, ok := m.versionWatches.entries[user][spec]; !ok {
+		var specs []string
+		for s := range m.versionWatches.entries[user] {
+			specs = append(specs, fmt.Sprintf("`%s`", s))
+		}
+		if len(specs) == 0 {
+			return "", fmt.Errorf("you have no watches")
+		}
+		return "", fmt.Errorf("you are not watching `%s`, your watches are: %s", spec, strings.Join(specs, ", "))
+	}
+	delete(m.versionWatches.entries[user], spec)
+	return fmt.Sprintf("no longer watching `%s`", spec), nil
+}
+
+// checkVersionWatches notifies watchers whose stream gained a newer accepted
+// payload since the last poll. Watches are kept in memory only - they do not
+// survive a restart, which matches how long release-watchers typically care.
+func (m *jobManager) checkVersionWatches() error {
+	m.versionWatches.lock.Lock()
+	var watches []*versionWatch
+	for _, byUser := range m.versionWatches.entries {
+		for _, watch := range byUser {
+			watches = append(watches, watch)
+		}
+	}
+	notifier := m.versionWatchNotifierFn
+	m.versionWatches.lock.Unlock()
+	if len(watches) == 0 {
+		return nil
+	}
+	// resolve each distinct minor/stream once per poll
+	newest := make(map[string]string)
+	for _, watch := range watches {
+		key := watch.minor + "/" + watch.stream
+		if _, ok := newest[key]; !ok {
+			version, err := m.newestAcceptedVersion(watch.minor, watch.stream)
+			if err != nil {
+				return err
+			}
+			newest[key] = version
+		}
+		version := newest[key]
+		if len(version) == 0 || version == watch.lastSeen {
+			continue
+		}
+		m.versionWatches.lock.Lock()
+		watch.lastSeen = version
+		m.versionWatches.lock.Unlock()
+		klog.Infof("Notifying %s that %s has a new accepted payload %s", watch.user, watch.spec, version)
+		if notifier != nil {
+			notifier(watch.user, watch.channel, watch.spec, version)
+		}
+	}
+	return nil
+}
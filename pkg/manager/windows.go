@@ -0,0 +1,186 @@
+package manager
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Windows-container QE needs clusters with Windows worker nodes, and setting
+// one up by hand means enabling OVN hybrid overlay networking, installing the
+// Windows Machine Config Operator with its SSH key secret, and writing a
+// Windows machine set with the right image for the platform. The `windows`
+// launch option does all of that once the cluster is up, on the platforms
+// where a Windows image can be referenced without per-account lookups.
+
+const (
+	// WindowsParam is the launch option requesting Windows worker nodes.
+	WindowsParam = "windows"
+	// wmcoNamespace is where the Windows Machine Config Operator installs.
+	wmcoNamespace = "openshift-windows-machine-config-operator"
+	// wmcoKeySecretName is the private key secret WMCO uses to configure
+	// Windows instances; the bot generates a throwaway key per cluster.
+	wmcoKeySecretName = "cloud-private-key"
+	// windowsMachineSetName is the Windows machine set the bot creates.
+	windowsMachineSetName = "chat-bot-windows"
+	// windowsOSLabel marks a machine set's machines as Windows so WMCO picks
+	// them up instead of the Linux machine config stack.
+	windowsOSLabel = "machine.openshift.io/os-id"
+)
+
+// windowsImages maps a platform to the provider spec mutation that points the
+// machine set at a public Windows Server image. AWS is absent because its
+// Windows AMIs are per-region, per-account lookups the bot cannot guess.
+var windowsImages = map[string]func(providerSpec map[string]interface{}){
+	"azure": func(providerSpec map[string]interface{}) {
+		providerSpec["image"] = map[string]interface{}{
+			"offer":      "WindowsServer",
+			"publisher":  "MicrosoftWindowsServer",
+			"sku":        "2022-datacenter-smalldisk",
+			"version":    "latest",
+			"resourceID": "",
+		}
+		if osDisk, ok := providerSpec["osDisk"].(map[string]interface{}); ok {
+			osDisk["osType"] = "Windows"
+		}
+	},
+	"gcp": func(providerSpec map[string]interface{}) {
+		if disks, ok := providerSpec["disks"].([]interface{}); ok && len(disks) > 0 {
+			if disk, ok := disks[0].(map[string]interface{}); ok {
+				disk["image"] = "projects/windows-cloud/global/images/family/windows-2022-core"
+				delete(disk, "sourceSnapshot")
+			}
+		}
+	},
+}
+
+// ValidateWindowsPlatform reports whether the bot can add Windows workers on
+// the given platform; it is checked at launch time so the request fails fast.
+func ValidateWindowsPlatform(platform string) error {
+	if _, ok := windowsImages[platform]; !ok {
+		return fmt.Errorf("the `windows` option is only supported on `azure` and `gcp` clusters, where a public Windows image can be referenced")
+	}
+	return nil
+}
+
+// configureClusterWindows performs the Windows worker enablement a `windows`
+// launch option asked for once the cluster is up: hybrid overlay networking,
+// the WMCO subscription and key secret, and a Windows machine set. Like the
+// OIDC and GPU setup it is best-effort, surfacing failures in the credential
+// notice rather than failing the launch.
+func (m *jobManager) configureClusterWindows(job *Job) {
+	if !job.WindowsWorkers {
+		return
+	}
+	client, err := clusterConfigClient(job)
+	if err != nil {
+		klog.Errorf("job %q: unable to connect for Windows worker setup: %v", job.Name, err)
+		return
+	}
+	ctx := context.TODO()
+
+	fail := func(step string, err error) {
+		klog.Errorf("job %q: Windows worker setup failed to %s: %v", job.Name, step, err)
+		job.PasswordSnippet += fmt.Sprintf("\nI could not finish the Windows worker setup you asked for (failed to %s: %v); the remaining steps are in the WMCO documentation", step, err)
+	}
+
+	if err := enableHybridOverlay(ctx, client); err != nil {
+		fail("enable hybrid overlay networking", err)
+		return
+	}
+	if err := subscribeOperator(ctx, client, wmcoNamespace, "windows-machine-config-operator", "redhat-operators", "stable"); err != nil {
+		fail("subscribe the Windows Machine Config Operator", err)
+		return
+	}
+	if err := createWindowsKeySecret(ctx, client); err != nil {
+		fail("create the WMCO key secret", err)
+		return
+	}
+	if err := createWindowsMachineSet(ctx, client, job.Platform); err != nil {
+		fail("create the Windows machine set", err)
+		return
+	}
+
+	klog.Infof("Configured Windows workers on cluster for job %q", job.Name)
+	job.PasswordSnippet += "\nWindows workers are being set up: hybrid overlay networking is on, the Windows Machine Config Operator is subscribing, and the `chat-bot-windows` machine set will add a Windows node once the operator configures it - expect this to take a while"
+}
+
+// enableHybridOverlay turns on OVN hybrid overlay networking, which Windows
+// nodes require; the change rolls the cluster network, so the nodes may be
+// briefly unsettled afterwards.
+func enableHybridOverlay(ctx context.Context, client ctrlruntimeclient.Client) error {
+	network := &unstructured.Unstructured{}
+	network.SetAPIVersion("operator.openshift.io/v1")
+	network.SetKind("Network")
+	if err := client.Get(ctx, ctrlruntimeclient.ObjectKey{Name: "cluster"}, network); err != nil {
+		return fmt.Errorf("unable to read the cluster network config: %v", err)
+	}
+	hybrid := []interface{}{
+		map[string]interface{}{"cidr": "10.132.0.0/14", "hostPrefix": int64(23)},
+	}
+	if err := unstructured.SetNestedSlice(network.Object, hybrid, "spec", "defaultNetwork", "ovnKubernetesConfig", "hybridOverlayConfig", "hybridClusterNetwork"); err != nil {
+		return err
+	}
+	if err := client.Update(ctx, network); err != nil {
+		return fmt.Errorf("unable to update the cluster network config: %v", err)
+	}
+	return nil
+}
+
+// createWindowsKeySecret generates a throwaway RSA key and stores it where
+// WMCO expects the private key it uses to configure Windows instances.
+func createWindowsKeySecret(ctx context.Context, client ctrlruntimeclient.Client) error {
+	key, err := rsa.GenerateKey(rand.Reader, 4096)
+	if err != nil {
+		return err
+	}
+	encoded := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      wmcoKeySecretName,
+			Namespace: wmcoNamespace,
+		},
+		Data: map[string][]byte{"private-key.pem": encoded},
+	}
+	if err := client.Create(ctx, secret); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// createWindowsMachineSet clones a worker machine set into a single-machine
+// Windows pool: the machines carry the Windows OS label so WMCO adopts them,
+// use the platform's public Windows image, and boot with the user data WMCO
+// publishes.
+func createWindowsMachineSet(ctx context.Context, client ctrlruntimeclient.Client, platform string) error {
+	pool, err := cloneWorkerMachineSet(ctx, client, windowsMachineSetName, 1)
+	if err != nil {
+		return err
+	}
+	pool.Spec.Template.Labels[windowsOSLabel] = "Windows"
+	pool.Spec.Selector.MatchLabels[windowsOSLabel] = "Windows"
+	if err := updateProviderSpec(pool, func(providerSpec map[string]interface{}) error {
+		windowsImages[platform](providerSpec)
+		providerSpec["userDataSecret"] = map[string]interface{}{"name": "windows-user-data"}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := client.Create(ctx, pool); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return fmt.Errorf("unable to create the Windows machine set: %v", err)
+	}
+	return nil
+}
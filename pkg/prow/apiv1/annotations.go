@@ -0,0 +1,84 @@
+// Package apiv1 defines the annotation contract bot-created ProwJobs carry
+// for traceability. The bot stamps these at submission time; Deck plugins
+// and external tooling should attribute jobs through the accessors here
+// rather than hard-coding the keys.
+package apiv1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AnnotationRequester is the Slack user ID that requested the job.
+	AnnotationRequester = "ci-chat-bot.openshift.io/user"
+	// AnnotationChannel is the Slack channel the request arrived on.
+	AnnotationChannel = "ci-chat-bot.openshift.io/channel"
+	// AnnotationCommandHash is the SHA-256 of the command text that started
+	// the job, so tooling can group identical requests without reproducing
+	// user input.
+	AnnotationCommandHash = "ci-chat-bot.openshift.io/commandHash"
+	// AnnotationCorrelationID ties the job to the bot's logs and trace spans.
+	AnnotationCorrelationID = "ci-chat-bot.openshift.io/correlationID"
+	// AnnotationExpires is the job's lifetime in seconds from creation; the
+	// bot patches it when an owner extends the cluster.
+	AnnotationExpires = "ci-chat-bot.openshift.io/expires"
+)
+
+// Traceability is the attributable subset of a bot job's annotations.
+type Traceability struct {
+	Requester     string
+	Channel       string
+	CommandHash   string
+	CorrelationID string
+	// ExpiresSeconds is the job's lifetime in seconds from creation; zero
+	// means the annotation is absent.
+	ExpiresSeconds int
+}
+
+// HashCommand returns the value AnnotationCommandHash carries for the given
+// command text.
+func HashCommand(originalMessage string) string {
+	sum := sha256.Sum256([]byte(originalMessage))
+	return hex.EncodeToString(sum[:])
+}
+
+// Apply stamps the traceability annotations onto the object, allocating the
+// annotation map when needed. Empty fields are not written.
+func (t Traceability) Apply(obj metav1.Object) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	for key, value := range map[string]string{
+		AnnotationRequester:     t.Requester,
+		AnnotationChannel:       t.Channel,
+		AnnotationCommandHash:   t.CommandHash,
+		AnnotationCorrelationID: t.CorrelationID,
+	} {
+		if len(value) > 0 {
+			annotations[key] = value
+		}
+	}
+	if t.ExpiresSeconds > 0 {
+		annotations[AnnotationExpires] = strconv.Itoa(t.ExpiresSeconds)
+	}
+	obj.SetAnnotations(annotations)
+}
+
+// FromObject reads the traceability annotations off an object; absent keys
+// leave their fields zero.
+func FromObject(obj metav1.Object) Traceability {
+	annotations := obj.GetAnnotations()
+	expires, _ := strconv.Atoi(annotations[AnnotationExpires])
+	return Traceability{
+		Requester:      annotations[AnnotationRequester],
+		Channel:        annotations[AnnotationChannel],
+		CommandHash:    annotations[AnnotationCommandHash],
+		CorrelationID:  annotations[AnnotationCorrelationID],
+		ExpiresSeconds: expires,
+	}
+}
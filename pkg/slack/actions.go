@@ -2,6 +2,9 @@ package slack
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -13,6 +16,7 @@ import (
 	"github.com/slack-go/slack"
 	"github.com/slack-go/slack/slackevents"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog"
 )
 
 func LaunchCluster(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
@@ -26,18 +30,102 @@ func LaunchCluster(client *slack.Client, jobManager manager.JobManager, event *s
 		inputs = [][]string{from}
 	}
 
-	platform, architecture, params, err := ParseOptions(properties.StringParam("options", ""), inputs, manager.JobTypeInstall)
+	options, err := ExpandLaunchPreset(jobManager.GetWorkflowConfig(), properties.StringParam("options", ""))
+	if err != nil {
+		return err.Error()
+	}
+	options = ApplyDefaultLaunchOptions(jobManager, event.User, options)
+	platform, architecture, params, err := ParseOptions(options, inputs, manager.JobTypeInstall)
+	if err != nil {
+		return err.Error()
+	}
+	inputs, platform, architecture, params, err = applySnapshot(jobManager, event.User, options, inputs, platform, architecture, params)
 	if err != nil {
 		return err.Error()
 	}
 	if len(params["test"]) > 0 {
 		return "TestUpgrade arguments may not be passed from the launch command"
 	}
+	params, err = MergeAttachedParams(client, event, params)
+	if err != nil {
+		return err.Error()
+	}
+	params, err = ExtractInstallConfigPatch(client, event, params)
+	if err != nil {
+		return err.Error()
+	}
+	params, err = ResolveSecretParams(jobManager, params)
+	if err != nil {
+		return err.Error()
+	}
+	params, err = ResolvePullSecretParam(jobManager, params)
+	if err != nil {
+		return err.Error()
+	}
+	params = InjectUserSSHKey(jobManager, event.User, params)
+	if msg := checkPriorityParam(client, event.User, params); len(msg) > 0 {
+		return msg
+	}
+
+	user, channel := event.User, event.Channel
+	if team, ok := params[manager.TeamParam]; ok {
+		match := channelMention.FindStringSubmatch(strings.TrimSpace(team))
+		if match == nil {
+			return "the team option must reference a channel, e.g. `team=#forum-my-team`"
+		}
+		if !IsChannelMember(client, match[1], event.User) {
+			return "you must be a member of the channel that will own the team cluster"
+		}
+		// the channel owns the cluster: it is tracked under the channel's
+		// identity and notices are posted there
+		user = manager.TeamOwner(match[1])
+		channel = match[1]
+		delete(params, manager.TeamParam)
+	}
 
 	msg, err := jobManager.LaunchJobForUser(&manager.JobRequest{
+		OriginalMessage: event.Text,
+		User:            user,
+		UserName:        userName,
+		CorrelationID:   utils.CorrelationID(event.ClientMsgID, event.User, event.TimeStamp),
+		Inputs:          inputs,
+		Type:            manager.JobTypeInstall,
+		Channel:         channel,
+		Platform:        platform,
+		JobParams:       params,
+		Architecture:    architecture,
+	})
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func Explain(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	userName := GetUserName(client, event.User)
+	from, err := ParseImageInput(properties.StringParam("image_or_version_or_prs", ""))
+	if err != nil {
+		return err.Error()
+	}
+	var inputs [][]string
+	if len(from) > 0 {
+		inputs = [][]string{from}
+	}
+
+	options, err := ExpandLaunchPreset(jobManager.GetWorkflowConfig(), properties.StringParam("options", ""))
+	if err != nil {
+		return err.Error()
+	}
+	platform, architecture, params, err := ParseOptions(options, inputs, manager.JobTypeInstall)
+	if err != nil {
+		return err.Error()
+	}
+
+	msg, err := jobManager.ExplainJobRequest(&manager.JobRequest{
 		OriginalMessage: event.Text,
 		User:            event.User,
 		UserName:        userName,
+		CorrelationID:   utils.CorrelationID(event.ClientMsgID, event.User, event.TimeStamp),
 		Inputs:          inputs,
 		Type:            manager.JobTypeInstall,
 		Channel:         event.Channel,
@@ -77,11 +165,510 @@ func Lookup(client *slack.Client, jobManager manager.JobManager, event *slackeve
 }
 
 func List(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
-	return jobManager.ListJobs(event.User, manager.ListFilters{})
+	return jobManager.ListJobs(event.User, GetUserTimezone(client, event.User), manager.ListFilters{})
 }
 
+// doneConfirmPrompt is the reply to anything that would tear down a single
+// cluster - the `done` command or its reaction shortcut - so a slip of the
+// finger never destroys one without an explicit `done confirm`.
+const doneConfirmPrompt = "this will terminate your running cluster; confirm with `done confirm`, or use `done all` to tear down every cluster you own"
+
 func Done(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
-	msg, err := jobManager.TerminateJobForUser(event.User)
+	return doneConfirmPrompt
+}
+
+func DoneConfirm(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	msg, err := jobManager.TerminateJobForUser(clusterOwner(client, jobManager, event.User))
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func DoneAll(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	msg, err := jobManager.TerminateAllForUser(event.User)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+var userMention = regexp.MustCompile(`^<@([A-Z0-9]+)(?:\|[^>]*)?>$`)
+
+var channelMention = regexp.MustCompile(`^<#([A-Z0-9]+)(?:\|[^>]*)?>$`)
+
+// clusterOwner returns the identity whose cluster a command operates on: the
+// user themselves if they own a cluster, otherwise the team cluster of a
+// channel the user is a member of.
+func clusterOwner(client *slack.Client, jobManager manager.JobManager, user string) string {
+	if _, err := jobManager.GetLaunchJob(user); err == nil {
+		return user
+	}
+	for _, channel := range jobManager.TeamClusterChannels() {
+		if IsChannelMember(client, channel, user) {
+			return manager.TeamOwner(channel)
+		}
+	}
+	return user
+}
+
+func DoneFor(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	if !IsBotAdmin(client, event.User) {
+		return "only members of the admin channel may tear down another user's cluster"
+	}
+	target := strings.TrimSpace(properties.StringParam("target", ""))
+	if match := userMention.FindStringSubmatch(target); match != nil {
+		msg, err := jobManager.TerminateJobForUser(match[1])
+		if err != nil {
+			return err.Error()
+		}
+		return msg
+	}
+	msg, err := jobManager.TerminateJobByName(target)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func Extend(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	name := strings.TrimSpace(properties.StringParam("name_or_group", ""))
+	duration, err := time.ParseDuration(strings.TrimSpace(properties.StringParam("duration", "")))
+	if err != nil {
+		return "the duration must look like `30m` or `2h`"
+	}
+	msg, err := jobManager.ExtendJobExpiry(event.User, name, duration)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func AdminListAll(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	if !IsBotAdmin(client, event.User) {
+		return "only members of the admin channel may list the whole fleet"
+	}
+	return jobManager.AdminListAll(GetUserTimezone(client, event.User))
+}
+
+func AdminDonePlatform(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	if !IsBotAdmin(client, event.User) {
+		return "only members of the admin channel may tear down clusters by platform"
+	}
+	platform := strings.TrimSpace(properties.StringParam("platform", ""))
+	msg, err := jobManager.AdminTerminatePlatform(platform)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func AdminExtendAll(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	if !IsBotAdmin(client, event.User) {
+		return "only members of the admin channel may extend all clusters"
+	}
+	extension, err := time.ParseDuration(strings.TrimSpace(properties.StringParam("duration", "")))
+	if err != nil {
+		return fmt.Sprintf("invalid duration: %v", err)
+	}
+	msg, err := jobManager.AdminExtendAll(extension)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func AdminDeprecationReport(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	if !IsBotAdmin(client, event.User) {
+		return "only members of the admin channel may view the deprecation report"
+	}
+	return jobManager.AdminDeprecationReport()
+}
+
+func AdminMessageOwners(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	if !IsBotAdmin(client, event.User) {
+		return "only members of the admin channel may message all cluster owners"
+	}
+	text := strings.TrimSpace(properties.StringParam("text", ""))
+	if len(text) == 0 {
+		return "you must provide the message to send, e.g. `admin message-owners AWS clusters will be torn down at 17:00 UTC`"
+	}
+	owners := jobManager.ClusterOwners()
+	if len(owners) == 0 {
+		return "no running clusters, nobody to message"
+	}
+	return messageClusterOwners(client, owners, text)
+}
+
+// messageClusterOwners delivers text to each owner in the channel their
+// cluster was launched from and reports how many were reached.
+func messageClusterOwners(client *slack.Client, owners map[string]string, text string) string {
+	var sent, failed int
+	for owner, channel := range owners {
+		// team-owned clusters notify their channel rather than a user
+		message := text
+		if _, isTeam := manager.TeamChannel(owner); !isTeam {
+			message = fmt.Sprintf("<@%s>: %s", owner, text)
+		}
+		if len(channel) == 0 {
+			failed++
+			continue
+		}
+		if _, _, err := client.PostMessage(channel, slack.MsgOptionText(message, false)); err != nil {
+			klog.Warningf("Failed to message cluster owner %s: %v", owner, err)
+			failed++
+			continue
+		}
+		sent++
+	}
+	msg := fmt.Sprintf("messaged %d cluster owner(s)", sent)
+	if failed > 0 {
+		msg += fmt.Sprintf("; %d could not be reached, see the bot logs", failed)
+	}
+	return msg
+}
+
+func Broadcast(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	if !IsBotAdmin(client, event.User) {
+		return "only members of the admin channel may broadcast to cluster owners"
+	}
+	text := strings.TrimSpace(properties.StringParam("message", ""))
+	// optional leading platform=/version= tokens narrow the audience
+	var platform, version string
+	for {
+		token, rest, _ := strings.Cut(text, " ")
+		if value, ok := strings.CutPrefix(token, "platform="); ok {
+			platform = value
+			text = strings.TrimSpace(rest)
+			continue
+		}
+		if value, ok := strings.CutPrefix(token, "version="); ok {
+			version = value
+			text = strings.TrimSpace(rest)
+			continue
+		}
+		break
+	}
+	if len(text) == 0 {
+		return "you must provide the message to broadcast, e.g. `broadcast platform=aws AWS is having API problems, expect slow launches`"
+	}
+	targets := jobManager.BroadcastTargets(platform, version)
+	if len(targets) == 0 {
+		return "no active clusters match, nobody to notify"
+	}
+	return messageClusterOwners(client, targets, text)
+}
+
+func Inspect(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	from, err := ParseImageInput(properties.StringParam("image_or_version", ""))
+	if err != nil {
+		return err.Error()
+	}
+	if len(from) != 1 {
+		return "you must specify exactly one release image or version to inspect"
+	}
+	components, err := ParseImageInput(properties.StringParam("components", ""))
+	if err != nil {
+		return err.Error()
+	}
+	msg, err := jobManager.InspectPayload(from[0], components)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func ImageInfo(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	from, err := ParseImageInput(properties.StringParam("image_or_version", ""))
+	if err != nil {
+		return err.Error()
+	}
+	if len(from) != 1 {
+		return "you must specify exactly one release image or version"
+	}
+	component := strings.TrimSpace(properties.StringParam("component", ""))
+	if len(component) == 0 {
+		return "you must specify the payload component to look up, e.g. `image info nightly installer`"
+	}
+	msg, err := jobManager.ImageInfo(from[0], component)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func CostTeam(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	msg, err := jobManager.CostReport(event.User)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func Exempt(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	reason := strings.TrimSpace(properties.StringParam("reason", ""))
+	if len(reason) == 0 {
+		return "you must provide a reason for keeping your cluster up overnight, e.g. `exempt running a long scale test`"
+	}
+	msg, err := jobManager.ExemptJob(clusterOwner(client, jobManager, event.User), reason)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func PayloadStatus(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	version := utils.StripLinks(strings.TrimSpace(properties.StringParam("version", "")))
+	if len(version) == 0 {
+		return "you must specify a payload, e.g. `payload-status 4.17.0-0.nightly-2024-06-01-123456`"
+	}
+	msg, err := jobManager.PayloadStatus(version)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func Watch(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	spec := strings.TrimSpace(properties.StringParam("version", ""))
+	if len(spec) == 0 {
+		return "you must specify a release stream to watch, e.g. `watch 4.17-nightly`"
+	}
+	msg, err := jobManager.AddVersionWatch(event.User, event.Channel, spec)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func Unwatch(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	spec := strings.TrimSpace(properties.StringParam("version", ""))
+	if len(spec) == 0 {
+		return "you must specify the release stream to stop watching, e.g. `unwatch 4.17-nightly`"
+	}
+	msg, err := jobManager.RemoveVersionWatch(event.User, spec)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func Versions(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	msg, err := jobManager.SupportedVersions()
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func History(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	msg, err := jobManager.JobHistory(event.User)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func ScaleWorkers(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	assignment := strings.TrimSpace(properties.StringParam("assignment", ""))
+	parts := strings.SplitN(assignment, "=", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) != "workers" {
+		return "specify the desired worker count as `scale workers=<n>`"
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return "the worker count must be a number, e.g. `scale workers=5`"
+	}
+	msg, err := jobManager.ScaleWorkers(event.User, count)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func AddMachinePool(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	assignment := strings.TrimSpace(properties.StringParam("assignment", ""))
+	parts := strings.SplitN(assignment, "=", 2)
+	if len(parts) != 2 || len(strings.TrimSpace(parts[1])) == 0 {
+		return "specify the pool as `add machinepool type=<instance>` or `add machinepool gpu=<count>x<model>`, e.g. `add machinepool type=m5.4xlarge`"
+	}
+	value := strings.TrimSpace(parts[1])
+	var msg string
+	var err error
+	switch strings.TrimSpace(parts[0]) {
+	case "type":
+		msg, err = jobManager.AddMachinePool(event.User, value)
+	case "gpu":
+		msg, err = jobManager.AddGpuPool(event.User, value)
+	default:
+		return "specify the pool as `add machinepool type=<instance>` or `add machinepool gpu=<count>x<model>`, e.g. `add machinepool type=m5.4xlarge`"
+	}
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func Upgrade(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	target := strings.TrimSpace(properties.StringParam("version_or_pullspec", ""))
+	if len(target) == 0 {
+		return "you must specify the version or release pullspec to upgrade to, e.g. `upgrade to 4.17.5`"
+	}
+	params, err := utils.ParamsFromAnnotation(properties.StringParam("options", ""))
+	if err != nil {
+		return fmt.Sprintf("options could not be parsed: %v", err)
+	}
+	var updateChannel string
+	var force bool
+	for opt, value := range params {
+		switch opt {
+		case "":
+		case "channel":
+			updateChannel = value
+		case "force":
+			force = true
+		default:
+			return fmt.Sprintf("unrecognized upgrade option: %s, supported options are `channel=<name>` and `force`", opt)
+		}
+	}
+	msg, err := jobManager.RunClusterUpgrade(event.User, event.Channel, target, updateChannel, force)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func UpgradeAbort(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	msg, err := jobManager.RunClusterUpgradeAbort(event.User, event.Channel)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func EtcdBackup(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	msg, err := jobManager.RunEtcdBackup(event.User, event.Channel)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func Snapshot(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	msg, err := jobManager.SnapshotCluster(event.User)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func SnapshotList(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	msg, err := jobManager.ListClusterSnapshots(event.User)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func Relaunch(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	jobName := strings.TrimSpace(properties.StringParam("job_id", ""))
+	if len(jobName) == 0 {
+		return "you must provide the name of a recently destroyed job to relaunch"
+	}
+	msg, err := jobManager.RelaunchJob(event.User, event.Channel, jobName)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func AliasSet(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	name := properties.StringParam("name", "")
+	expansion := utils.StripLinks(properties.StringParam("expansion", ""))
+	msg, err := jobManager.SetUserAlias(event.User, name, expansion)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func AliasList(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	msg, err := jobManager.ListUserAliases(event.User)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func AliasRemove(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	name := properties.StringParam("name", "")
+	msg, err := jobManager.RemoveUserAlias(event.User, strings.TrimSpace(name))
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func SSHKeySet(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	key := utils.StripLinks(properties.StringParam("key", ""))
+	msg, err := jobManager.SetUserSSHKey(event.User, key)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func SSHKeyRemove(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	msg, err := jobManager.RemoveUserSSHKey(event.User)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func PrefSet(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	assignment := properties.StringParam("assignment", "")
+	parts := strings.SplitN(assignment, "=", 2)
+	if len(parts) != 2 {
+		return "preferences are set as `set <name>=<value>`, e.g. `set platform=gcp`"
+	}
+	msg, err := jobManager.SetUserPreference(event.User, parts[0], parts[1])
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func PrefUnset(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	name := properties.StringParam("name", "")
+	msg, err := jobManager.UnsetUserPreference(event.User, strings.TrimSpace(name))
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func PrefList(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	msg, err := jobManager.ListUserPreferences(event.User)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func Compare(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	jobA, err := ParseImageInput(properties.StringParam("jobA", ""))
+	if err != nil {
+		return err.Error()
+	}
+	jobB, err := ParseImageInput(properties.StringParam("jobB", ""))
+	if err != nil {
+		return err.Error()
+	}
+	if len(jobA) != 1 || len(jobB) != 1 {
+		return "compare takes exactly two job names"
+	}
+	msg, err := jobManager.CompareJobRuns(jobA[0], jobB[0])
 	if err != nil {
 		return err.Error()
 	}
@@ -89,7 +676,7 @@ func Done(client *slack.Client, jobManager manager.JobManager, event *slackevent
 }
 
 func Refresh(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
-	msg, err := jobManager.SyncJobForUser(event.User)
+	msg, err := jobManager.SyncJobForUser(clusterOwner(client, jobManager, event.User))
 	if err != nil {
 		return err.Error()
 	}
@@ -119,7 +706,7 @@ func Auth(client *slack.Client, jobManager manager.JobManager, event *slackevent
 		NotifyRosa(client, cluster, password)
 		return ""
 	}
-	job, err := jobManager.GetLaunchJob(event.User)
+	job, err := jobManager.GetLaunchJob(clusterOwner(client, jobManager, event.User))
 	if err != nil {
 		return err.Error()
 	}
@@ -128,6 +715,23 @@ func Auth(client *slack.Client, jobManager manager.JobManager, event *slackevent
 	return " "
 }
 
+func AuthTo(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	destination := strings.TrimSpace(properties.StringParam("destination", ""))
+	msg, err := jobManager.DeliverCredentials(clusterOwner(client, jobManager, event.User), destination)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func AuthToken(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	msg, err := jobManager.MintClusterToken(clusterOwner(client, jobManager, event.User))
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
 func TestUpgrade(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
 	userName := GetUserName(client, event.User)
 	from, err := ParseImageInput(properties.StringParam("from", ""))
@@ -159,6 +763,7 @@ func TestUpgrade(client *slack.Client, jobManager manager.JobManager, event *sla
 		OriginalMessage: event.Text,
 		User:            event.User,
 		UserName:        userName,
+		CorrelationID:   utils.CorrelationID(event.ClientMsgID, event.User, event.TimeStamp),
 		Inputs:          [][]string{from, to},
 		Type:            manager.JobTypeUpgrade,
 		Channel:         event.Channel,
@@ -206,6 +811,7 @@ func Test(client *slack.Client, jobManager manager.JobManager, event *slackevent
 		OriginalMessage: event.Text,
 		User:            event.User,
 		UserName:        userName,
+		CorrelationID:   utils.CorrelationID(event.ClientMsgID, event.User, event.TimeStamp),
 		Inputs:          [][]string{from},
 		Type:            manager.JobTypeTest,
 		Channel:         event.Channel,
@@ -219,6 +825,115 @@ func Test(client *slack.Client, jobManager manager.JobManager, event *slackevent
 	return msg
 }
 
+// Conformance starts a conformance run against the caller's launched
+// cluster; the outcome arrives asynchronously through the conformance
+// notifier.
+func Conformance(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	suite := properties.StringParam("suite", "")
+	msg, err := jobManager.RunConformance(event.User, event.Channel, suite)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+// Chaos starts a disruption scenario against the caller's launched cluster;
+// the recovery result arrives asynchronously through the chaos notifier.
+func Chaos(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	scenario := properties.StringParam("scenario", "")
+	if len(scenario) == 0 {
+		return fmt.Sprintf("you must specify a scenario: %s", strings.Join(CodeSlice(manager.SupportedChaosScenarios()), ", "))
+	}
+	msg, err := jobManager.RunChaosScenario(event.User, event.Channel, scenario)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+// TimeSkew shifts the clocks on the caller's cluster; confirmation arrives
+// asynchronously through the chaos notifier.
+func TimeSkew(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	offset := properties.StringParam("offset", "")
+	if len(offset) == 0 {
+		return "you must specify a clock offset such as `48h`, `-30m`, or `370d`, or `reset` to re-enable NTP"
+	}
+	msg, err := jobManager.RunTimeSkew(event.User, event.Channel, offset)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+// CertRotation forces a certificate rotation on the caller's cluster; the
+// outcome arrives asynchronously through the chaos notifier.
+func CertRotation(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	msg, err := jobManager.RunCertRotation(event.User, event.Channel)
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+func TestMatrix(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	userName := GetUserName(client, event.User)
+	from, err := ParseImageInput(properties.StringParam("pullrequest", ""))
+	if err != nil {
+		return err.Error()
+	}
+	if len(from) == 0 {
+		return "you must specify at least one pull request to build the test payload"
+	}
+
+	platformsRaw, err := ParseImageInput(strings.TrimPrefix(strings.TrimSpace(properties.StringParam("platforms", "")), "platforms="))
+	if err != nil {
+		return err.Error()
+	}
+	if len(platformsRaw) == 0 {
+		return fmt.Sprintf("you must specify at least one platform: %s", strings.Join(CodeSlice(manager.SupportedPlatforms), ", "))
+	}
+	platforms := sets.NewString()
+	for _, platform := range platformsRaw {
+		if !utils.Contains(manager.SupportedPlatforms, platform) {
+			return fmt.Sprintf("unsupported platform %s, supported platforms are: %s", platform, strings.Join(CodeSlice(manager.SupportedPlatforms), ", "))
+		}
+		platforms.Insert(platform)
+	}
+
+	// validate every leg before starting any so the matrix is treated as a single
+	// logical request - either the whole fan-out is accepted or none of it is
+	requests := make([]*manager.JobRequest, 0, platforms.Len())
+	for _, platform := range platforms.List() {
+		req := &manager.JobRequest{
+			OriginalMessage: event.Text,
+			User:            event.User,
+			UserName:        userName,
+			CorrelationID:   utils.CorrelationID(event.ClientMsgID, event.User, event.TimeStamp),
+			Inputs:          [][]string{from},
+			Type:            manager.JobTypeTest,
+			Channel:         event.Channel,
+			Platform:        platform,
+			JobParams:       map[string]string{"test": "e2e"},
+			Architecture:    "amd64",
+		}
+		if err := jobManager.CheckValidJobConfiguration(req); err != nil {
+			return fmt.Sprintf("unable to run the matrix on %s: %v", platform, err)
+		}
+		requests = append(requests, req)
+	}
+
+	lines := make([]string, 0, len(requests)+1)
+	lines = append(lines, fmt.Sprintf("started a test matrix for %s across %d platforms:", strings.Join(from, ","), len(requests)))
+	for _, req := range requests {
+		msg, err := jobManager.LaunchJobForUser(req)
+		if err != nil {
+			msg = err.Error()
+		}
+		lines = append(lines, fmt.Sprintf("• %s: %s", req.Platform, msg))
+	}
+	return strings.Join(lines, "\n")
+}
+
 func CatalogBuild(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
 	userName := GetUserName(client, event.User)
 	from, err := ParseImageInput(properties.StringParam("pullrequest", ""))
@@ -247,6 +962,7 @@ func CatalogBuild(client *slack.Client, jobManager manager.JobManager, event *sl
 		OriginalMessage: event.Text,
 		User:            event.User,
 		UserName:        userName,
+		CorrelationID:   utils.CorrelationID(event.ClientMsgID, event.User, event.TimeStamp),
 		Inputs:          [][]string{from},
 		Type:            manager.JobTypeCatalog,
 		Channel:         event.Channel,
@@ -279,6 +995,7 @@ func Build(client *slack.Client, jobManager manager.JobManager, event *slackeven
 		OriginalMessage: event.Text,
 		User:            event.User,
 		UserName:        userName,
+		CorrelationID:   utils.CorrelationID(event.ClientMsgID, event.User, event.TimeStamp),
 		Inputs:          [][]string{from},
 		Type:            manager.JobTypeBuild,
 		Channel:         event.Channel,
@@ -296,6 +1013,134 @@ func Version(client *slack.Client, jobManager manager.JobManager, event *slackev
 	return fmt.Sprintf("Running `%s` from https://github.com/openshift/ci-chat-bot", botversion.Get().String())
 }
 
+// LaunchMicroShift provisions a VM running MicroShift through the workflow
+// the administrators named in the workflow config, giving edge teams a
+// self-service path alongside the regular cluster launches.
+func LaunchMicroShift(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	workflowConfig := jobManager.GetWorkflowConfig()
+	workflowConfig.Mutex.RLock()
+	name := workflowConfig.MicroshiftWorkflow
+	workflowConfig.Mutex.RUnlock()
+	if len(name) == 0 {
+		return "this deployment has no MicroShift workflow configured; ask the bot administrators to set `microshiftWorkflow` in the workflow config"
+	}
+	userName := GetUserName(client, event.User)
+	from, err := ParseImageInput(properties.StringParam("version", ""))
+	if err != nil {
+		return err.Error()
+	}
+	if len(from) == 0 {
+		from = []string{"nightly"}
+	}
+	platform, architecture, err := GetPlatformArchFromWorkflowConfig(workflowConfig, name)
+	if err != nil {
+		return err.Error()
+	}
+	jobParams, err := BuildJobParams(properties.StringParam("options", ""))
+	if err != nil {
+		return err.Error()
+	}
+	jobParams, err = ResolveEnvPresetParams(jobManager, jobParams)
+	if err != nil {
+		return err.Error()
+	}
+	jobParams = InjectUserSSHKey(jobManager, event.User, jobParams)
+
+	msg, err := jobManager.LaunchJobForUser(&manager.JobRequest{
+		OriginalMessage: event.Text,
+		User:            event.User,
+		UserName:        userName,
+		CorrelationID:   utils.CorrelationID(event.ClientMsgID, event.User, event.TimeStamp),
+		Inputs:          [][]string{from},
+		Type:            manager.JobTypeWorkflowLaunch,
+		Channel:         event.Channel,
+		Platform:        platform,
+		JobParams:       jobParams,
+		Architecture:    architecture,
+		WorkflowName:    name,
+	})
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
+// PerfScale runs a predefined performance/scale profile: the profile's
+// workflow installs an appropriately sized cluster, drives the workload, and
+// the run result is reported back when the job completes.
+func PerfScale(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	workflowConfig := jobManager.GetWorkflowConfig()
+	name := properties.StringParam("profile", "")
+	workflowConfig.Mutex.RLock()
+	profile, ok := workflowConfig.PerfscaleProfiles[name]
+	profiles := make([]string, 0, len(workflowConfig.PerfscaleProfiles))
+	for profileName, entry := range workflowConfig.PerfscaleProfiles {
+		line := fmt.Sprintf("`%s`", profileName)
+		if len(entry.Description) > 0 {
+			line += " - " + entry.Description
+		}
+		profiles = append(profiles, line)
+	}
+	workflowConfig.Mutex.RUnlock()
+	if len(profiles) == 0 {
+		return "this deployment has no perfscale profiles configured; ask the bot administrators to set `perfscaleProfiles` in the workflow config"
+	}
+	if !ok {
+		sort.Strings(profiles)
+		return fmt.Sprintf("you must specify one of the configured profiles:\n• %s", strings.Join(profiles, "\n• "))
+	}
+
+	userName := GetUserName(client, event.User)
+	version := properties.StringParam("version", "")
+	if len(version) == 0 {
+		version = profile.Version
+	}
+	from, err := ParseImageInput(version)
+	if err != nil {
+		return err.Error()
+	}
+	if len(from) == 0 {
+		from = []string{"nightly"}
+	}
+	platform, architecture, err := GetPlatformArchFromWorkflowConfig(workflowConfig, profile.Workflow)
+	if err != nil {
+		return err.Error()
+	}
+	jobParams := make(map[string]string, len(profile.Parameters))
+	for key, value := range profile.Parameters {
+		jobParams[key] = value
+	}
+	jobParams = InjectUserSSHKey(jobManager, event.User, jobParams)
+
+	msg, err := jobManager.LaunchJobForUser(&manager.JobRequest{
+		OriginalMessage: event.Text,
+		User:            event.User,
+		UserName:        userName,
+		CorrelationID:   utils.CorrelationID(event.ClientMsgID, event.User, event.TimeStamp),
+		Inputs:          [][]string{from},
+		Type:            manager.JobTypeWorkflowTest,
+		Channel:         event.Channel,
+		Platform:        platform,
+		JobParams:       jobParams,
+		Architecture:    architecture,
+		WorkflowName:    profile.Workflow,
+	})
+	if err != nil {
+		return err.Error()
+	}
+	var links []string
+	if len(profile.GrafanaURL) > 0 {
+		links = append(links, fmt.Sprintf("<%s|Grafana>", profile.GrafanaURL))
+	}
+	if len(profile.ElasticsearchURL) > 0 {
+		links = append(links, fmt.Sprintf("<%s|Elasticsearch>", profile.ElasticsearchURL))
+	}
+	if len(links) > 0 {
+		msg = fmt.Sprintf("%s\nthe `%s` workload publishes its results to %s, and the run summary will be reported here when the job completes", msg, name, strings.Join(links, " and "))
+	}
+	return msg
+}
+
 func WorkflowLaunch(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
 	workflowConfig := jobManager.GetWorkflowConfig()
 	userName := GetUserName(client, event.User)
@@ -321,11 +1166,32 @@ func WorkflowLaunch(client *slack.Client, jobManager manager.JobManager, event *
 	if err != nil {
 		return err.Error()
 	}
+	jobParams, err = ResolveEnvPresetParams(jobManager, jobParams)
+	if err != nil {
+		return err.Error()
+	}
+	jobParams, err = MergeAttachedParams(client, event, jobParams)
+	if err != nil {
+		return err.Error()
+	}
+	jobParams, err = ResolveSecretParams(jobManager, jobParams)
+	if err != nil {
+		return err.Error()
+	}
+	jobParams, err = ResolvePullSecretParam(jobManager, jobParams)
+	if err != nil {
+		return err.Error()
+	}
+	jobParams = InjectUserSSHKey(jobManager, event.User, jobParams)
+	if msg := checkPriorityParam(client, event.User, jobParams); len(msg) > 0 {
+		return msg
+	}
 
 	msg, err := jobManager.LaunchJobForUser(&manager.JobRequest{
 		OriginalMessage: event.Text,
 		User:            event.User,
 		UserName:        userName,
+		CorrelationID:   utils.CorrelationID(event.ClientMsgID, event.User, event.TimeStamp),
 		Inputs:          [][]string{from},
 		Type:            manager.JobTypeWorkflowLaunch,
 		Channel:         event.Channel,
@@ -365,11 +1231,29 @@ func WorkflowTest(client *slack.Client, jobManager manager.JobManager, event *sl
 	if err != nil {
 		return err.Error()
 	}
+	jobParams, err = ResolveEnvPresetParams(jobManager, jobParams)
+	if err != nil {
+		return err.Error()
+	}
+	jobParams, err = MergeAttachedParams(client, event, jobParams)
+	if err != nil {
+		return err.Error()
+	}
+	jobParams, err = ResolveSecretParams(jobManager, jobParams)
+	if err != nil {
+		return err.Error()
+	}
+	jobParams, err = ResolvePullSecretParam(jobManager, jobParams)
+	if err != nil {
+		return err.Error()
+	}
+	jobParams = InjectUserSSHKey(jobManager, event.User, jobParams)
 
 	msg, err := jobManager.LaunchJobForUser(&manager.JobRequest{
 		OriginalMessage: event.Text,
 		User:            event.User,
 		UserName:        userName,
+		CorrelationID:   utils.CorrelationID(event.ClientMsgID, event.User, event.TimeStamp),
 		Inputs:          [][]string{from},
 		Type:            manager.JobTypeWorkflowTest,
 		Channel:         event.Channel,
@@ -417,11 +1301,29 @@ func WorkflowUpgrade(client *slack.Client, jobManager manager.JobManager, event
 	if err != nil {
 		return err.Error()
 	}
+	jobParams, err = ResolveEnvPresetParams(jobManager, jobParams)
+	if err != nil {
+		return err.Error()
+	}
+	jobParams, err = MergeAttachedParams(client, event, jobParams)
+	if err != nil {
+		return err.Error()
+	}
+	jobParams, err = ResolveSecretParams(jobManager, jobParams)
+	if err != nil {
+		return err.Error()
+	}
+	jobParams, err = ResolvePullSecretParam(jobManager, jobParams)
+	if err != nil {
+		return err.Error()
+	}
+	jobParams = InjectUserSSHKey(jobManager, event.User, jobParams)
 
 	msg, err := jobManager.LaunchJobForUser(&manager.JobRequest{
 		OriginalMessage: event.Text,
 		User:            event.User,
 		UserName:        userName,
+		CorrelationID:   utils.CorrelationID(event.ClientMsgID, event.User, event.TimeStamp),
 		Inputs:          [][]string{from, to},
 		Type:            manager.JobTypeWorkflowUpgrade,
 		Channel:         event.Channel,
@@ -452,6 +1354,11 @@ func RosaCreate(client *slack.Client, jobManager manager.JobManager, event *slac
 	if err != nil {
 		return err.Error()
 	}
+	if len(rawDuration) == 0 {
+		if lifetime, ok := jobManager.GetUserPreferences(event.User)["lifetime"]; ok {
+			rawDuration = []string{lifetime}
+		}
+	}
 	var duration time.Duration
 	if len(rawDuration) != 0 {
 		duration, err = time.ParseDuration(rawDuration[0])
@@ -506,6 +1413,18 @@ func RosaDescribe(client *slack.Client, jobManager manager.JobManager, event *sl
 	return msg
 }
 
+func RosaQuota(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	return jobManager.RosaQuota()
+}
+
+func RosaWhoami(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	msg, err := jobManager.RosaWhoami()
+	if err != nil {
+		return err.Error()
+	}
+	return msg
+}
+
 func MceCreate(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
 	from, err := ParseImageInput(properties.StringParam("version", ""))
 	if err != nil {
@@ -535,6 +1454,11 @@ func MceCreate(client *slack.Client, jobManager manager.JobManager, event *slack
 	if err != nil {
 		return err.Error()
 	}
+	if len(rawDuration) == 0 {
+		if lifetime, ok := jobManager.GetUserPreferences(event.User)["lifetime"]; ok {
+			rawDuration = []string{lifetime}
+		}
+	}
 	var duration time.Duration
 	if len(rawDuration) != 0 {
 		duration, err = time.ParseDuration(rawDuration[0])
@@ -576,7 +1500,7 @@ func MceList(client *slack.Client, jobManager manager.JobManager, event *slackev
 		return err.Error()
 	}
 	if len(all) > 0 && all[0] == "all" {
-		return jobManager.ListManagedClusters("")
+		return jobManager.ListManagedClusters("", GetUserTimezone(client, event.User))
 	}
-	return jobManager.ListManagedClusters(event.User)
+	return jobManager.ListManagedClusters(event.User, GetUserTimezone(client, event.User))
 }
@@ -0,0 +1,147 @@
+package slack
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+	"gopkg.in/yaml.v2"
+
+	"github.com/openshift/ci-chat-bot/pkg/manager"
+)
+
+const (
+	// maxAttachedParamFileBytes bounds the size of a parameter file the bot is
+	// willing to download from Slack
+	maxAttachedParamFileBytes = 64 * 1024
+	// maxAttachedParamFiles bounds the number of attachments processed per message
+	maxAttachedParamFiles = 3
+)
+
+// attachedParamKey matches the environment-variable style keys accepted from
+// attached parameter files
+var attachedParamKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// MergeAttachedParams downloads `.env` or YAML parameter files attached to the
+// message via the Slack Files API and merges their KEY=VALUE contents into
+// jobParams. Parameters the user passes inline take precedence over file
+// contents, so an attachment can serve as a base configuration.
+func MergeAttachedParams(client *slack.Client, event *slackevents.MessageEvent, jobParams map[string]string) (map[string]string, error) {
+	if len(event.Files) == 0 {
+		return jobParams, nil
+	}
+	if len(event.Files) > maxAttachedParamFiles {
+		return nil, fmt.Errorf("at most %d parameter files may be attached to a single message", maxAttachedParamFiles)
+	}
+	merged := make(map[string]string)
+	for _, file := range event.Files {
+		if isInstallConfigAttachment(file.Name) {
+			// consumed whole by ExtractInstallConfigPatch, not a parameter file
+			continue
+		}
+		if file.Size > maxAttachedParamFileBytes {
+			return nil, fmt.Errorf("attached file %s is too large; parameter files may be at most %dKB", file.Name, maxAttachedParamFileBytes/1024)
+		}
+		var buf bytes.Buffer
+		if err := client.GetFile(file.URLPrivateDownload, &buf); err != nil {
+			return nil, fmt.Errorf("failed to download attached file %s: %w", file.Name, err)
+		}
+		params, err := parseAttachedParamFile(file.Name, buf.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range params {
+			merged[k] = v
+		}
+	}
+	if invalid := invalidParamKeys(merged); len(invalid) > 0 {
+		return nil, fmt.Errorf("attached parameter files contain invalid keys: %s", strings.Join(CodeSlice(invalid), ", "))
+	}
+	for k, v := range jobParams {
+		merged[k] = v
+	}
+	return merged, nil
+}
+
+// installConfigCodeBlock matches a fenced code block in a launch message, so
+// an install-config patch can be pasted inline instead of attached.
+var installConfigCodeBlock = regexp.MustCompile("(?s)```(.*?)```")
+
+// isInstallConfigAttachment reports whether an attached file carries an
+// install-config patch rather than launch parameters.
+func isInstallConfigAttachment(name string) bool {
+	return strings.Contains(name, "install-config") && (strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml"))
+}
+
+// ExtractInstallConfigPatch pulls a user-supplied install-config patch out of
+// the message - an attached install-config file, or a fenced code block in
+// the message text - and stores it under manager.InstallConfigParam. The
+// patch is validated against the resolved version when the job is built.
+func ExtractInstallConfigPatch(client *slack.Client, event *slackevents.MessageEvent, jobParams map[string]string) (map[string]string, error) {
+	for _, file := range event.Files {
+		if !isInstallConfigAttachment(file.Name) {
+			continue
+		}
+		if file.Size > maxAttachedParamFileBytes {
+			return nil, fmt.Errorf("attached file %s is too large; install-config patches may be at most %dKB", file.Name, maxAttachedParamFileBytes/1024)
+		}
+		var buf bytes.Buffer
+		if err := client.GetFile(file.URLPrivateDownload, &buf); err != nil {
+			return nil, fmt.Errorf("failed to download attached file %s: %w", file.Name, err)
+		}
+		jobParams[manager.InstallConfigParam] = buf.String()
+		return jobParams, nil
+	}
+	if match := installConfigCodeBlock.FindStringSubmatch(event.Text); match != nil {
+		patch := strings.TrimSpace(match[1])
+		if len(patch) > 0 {
+			jobParams[manager.InstallConfigParam] = patch
+		}
+	}
+	return jobParams, nil
+}
+
+// parseAttachedParamFile interprets an attachment as either a dotenv file with
+// one KEY=VALUE per line or a YAML mapping of string to string, based on the
+// file extension.
+func parseAttachedParamFile(name string, data []byte) (map[string]string, error) {
+	switch {
+	case strings.HasSuffix(name, ".env"):
+		params := make(map[string]string)
+		for i, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if len(line) == 0 || strings.HasPrefix(line, "#") {
+				continue
+			}
+			parts := strings.SplitN(line, "=", 2)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("attached file %s: line %d is not in the form of KEY=VALUE", name, i+1)
+			}
+			params[strings.TrimSpace(parts[0])] = strings.Trim(strings.TrimSpace(parts[1]), `"`)
+		}
+		return params, nil
+	case strings.HasSuffix(name, ".yaml"), strings.HasSuffix(name, ".yml"):
+		params := make(map[string]string)
+		if err := yaml.Unmarshal(data, &params); err != nil {
+			return nil, fmt.Errorf("attached file %s could not be parsed as a YAML mapping of parameter names to values: %w", name, err)
+		}
+		return params, nil
+	default:
+		return nil, fmt.Errorf("attached file %s is not a supported parameter file; attach a `.env`, `.yaml`, or `.yml` file", name)
+	}
+}
+
+func invalidParamKeys(params map[string]string) []string {
+	var invalid []string
+	for k := range params {
+		if !attachedParamKey.MatchString(k) {
+			invalid = append(invalid, k)
+		}
+	}
+	sort.Strings(invalid)
+	return invalid
+}
@@ -0,0 +1,80 @@
+package slack
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+
+	"github.com/openshift/ci-chat-bot/pkg/manager"
+)
+
+// The cluster-ready notification is the message users act on most, so it is
+// rendered as Block Kit - console and API links, version, and an expiry
+// countdown as fields, with link buttons for the console and the job logs -
+// instead of a wall of text. The plain rendering from the "ready" message
+// template is still attached as the message text, so clients without block
+// support (and the override mechanism in templates.go) keep working.
+
+var kubeconfigServerRe = regexp.MustCompile(`(?m)^\s*server:\s*(https://\S+)`)
+
+// consoleURL extracts the console link the install placed on the first line
+// of the password snippet.
+func consoleURL(job *manager.Job) string {
+	line := job.PasswordSnippet
+	if idx := strings.Index(line, "\n"); idx >= 0 {
+		line = line[:idx]
+	}
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, "https://") {
+		return line
+	}
+	return ""
+}
+
+// apiServerURL extracts the API server the credentials point at.
+func apiServerURL(job *manager.Job) string {
+	if matches := kubeconfigServerRe.FindStringSubmatch(job.Credentials); len(matches) == 2 {
+		return matches[1]
+	}
+	return ""
+}
+
+// clusterReadyBlocks renders the ready notification as Block Kit.
+func clusterReadyBlocks(job *manager.Job) []slack.Block {
+	fields := []*slack.TextBlockObject{}
+	if console := consoleURL(job); len(console) > 0 {
+		fields = append(fields, slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("*Console:*\n<%s>", console), false, false))
+	}
+	if api := apiServerURL(job); len(api) > 0 {
+		fields = append(fields, slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("*API:*\n`%s`", api), false, false))
+	}
+	if len(job.Inputs) > 0 && len(job.Inputs[0].Version) > 0 {
+		fields = append(fields, slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("*Version:*\n%s", job.Inputs[0].Version), false, false))
+	}
+	if len(job.Platform) > 0 {
+		fields = append(fields, slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("*Platform:*\n%s", job.Platform), false, false))
+	}
+	fields = append(fields, slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("*Expires:*\nin ~%d minutes", time.Until(job.ExpiresAt)/time.Minute), false, false))
+
+	blocks := []slack.Block{
+		slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType, "Your cluster is ready", false, false)),
+		slack.NewSectionBlock(nil, fields, nil),
+	}
+
+	buttons := []slack.BlockElement{}
+	if console := consoleURL(job); len(console) > 0 {
+		buttons = append(buttons, slack.NewButtonBlockElement("cluster-console", job.Name,
+			slack.NewTextBlockObject(slack.PlainTextType, "Open console", false, false)).WithURL(console).WithStyle(slack.StylePrimary))
+	}
+	if len(job.URL) > 0 {
+		buttons = append(buttons, slack.NewButtonBlockElement("cluster-logs", job.Name,
+			slack.NewTextBlockObject(slack.PlainTextType, "Job logs", false, false)).WithURL(job.URL))
+	}
+	if len(buttons) > 0 {
+		blocks = append(blocks, slack.NewActionBlock("cluster-ready-actions", buttons...))
+	}
+	return blocks
+}
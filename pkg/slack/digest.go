@@ -0,0 +1,71 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift/ci-chat-bot/pkg/manager"
+	"github.com/slack-go/slack"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog"
+)
+
+// Users juggling version watches and queued launches can end up with a noisy
+// DM. `set notifications=digest` batches the non-critical notifications -
+// queue progress, version watch pings, teardown confirmations - into a
+// periodic digest instead. Critical notices (cluster ready, failure, expiry)
+// are always delivered immediately regardless of the preference.
+
+// digestInterval is how often batched notifications are delivered.
+const digestInterval = 30 * time.Minute
+
+var digests = struct {
+	sync.Mutex
+	byUser map[string]*userDigest
+}{byUser: map[string]*userDigest{}}
+
+type userDigest struct {
+	channel string
+	entries []string
+}
+
+// digestMode reports whether the user opted into batched notifications.
+func digestMode(jobManager manager.JobManager, user string) bool {
+	return jobManager.GetUserPreferences(user)["notifications"] == "digest"
+}
+
+// enqueueDigest parks a non-critical notification for the next digest.
+func enqueueDigest(user, channel, message string) {
+	digests.Lock()
+	defer digests.Unlock()
+	digest, ok := digests.byUser[user]
+	if !ok {
+		digest = &userDigest{}
+		digests.byUser[user] = digest
+	}
+	digest.channel = channel
+	digest.entries = append(digest.entries, message)
+}
+
+// StartDigestLoop periodically delivers the batched notifications.
+func (b *Bot) StartDigestLoop(s *slack.Client) {
+	go wait.Forever(func() { b.flushDigests(s) }, digestInterval)
+}
+
+func (b *Bot) flushDigests(s *slack.Client) {
+	digests.Lock()
+	due := digests.byUser
+	digests.byUser = map[string]*userDigest{}
+	digests.Unlock()
+	for user, digest := range due {
+		if len(digest.entries) == 0 {
+			continue
+		}
+		message := fmt.Sprintf("<@%s> your notification digest (%d update(s)):\n• %s", user, len(digest.entries), strings.Join(digest.entries, "\n• "))
+		if _, _, err := s.PostMessage(digest.channel, slack.MsgOptionText(message, false)); err != nil {
+			klog.Warningf("Failed to post notification digest to channel %s: %v", digest.channel, err)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+// Package deprovision watches Slack user_change events for account
+// deactivations and cleans up after the departed user: admins are notified,
+// their running clusters are put on a short fuse, and any launch parked in
+// the quota queue is revoked. The heavy lifting lives in the job manager's
+// DeprovisionUser; this handler only recognizes the event.
+package deprovision
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/slack-go/slack/slackevents"
+
+	"github.com/openshift/ci-chat-bot/pkg/manager"
+	"github.com/openshift/ci-chat-bot/pkg/slack/events"
+)
+
+func Handle(jobManager manager.JobManager) events.PartialHandler {
+	return events.PartialHandlerFunc("user-deprovision",
+		func(callback *slackevents.EventsAPIEvent, logger *logrus.Entry) (handled bool, err error) {
+			if callback.Type != slackevents.CallbackEvent {
+				return false, nil
+			}
+			event, ok := callback.InnerEvent.Data.(*slackevents.UserChangeEvent)
+			if !ok {
+				return false, nil
+			}
+			// user_change also fires for profile edits; only a
+			// deactivation triggers the clean-up
+			if !event.User.Deleted || event.User.IsBot {
+				return true, nil
+			}
+			msg, err := jobManager.DeprovisionUser(event.User.ID)
+			if err != nil {
+				return true, err
+			}
+			if len(msg) > 0 {
+				logger.Infof("deprovisioned deactivated user %s: %s", event.User.ID, msg)
+			}
+			return true, nil
+		})
+}
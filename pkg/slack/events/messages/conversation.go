@@ -0,0 +1,76 @@
+package messages
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/openshift/ci-chat-bot/pkg/manager"
+)
+
+// Follow-up messages in a DM naturally refer to "it" - "extend it by 2
+// hours", "destroy it" - and used to bounce off the parser. A handful of
+// fixed phrasings are rewritten into the commands they stand for, resolving
+// "it" to the cluster the user most recently requested. This is deliberate
+// keyword matching, not NLP: anything outside the known phrasings still gets
+// the normal unknown-command hint.
+
+// destroyPhrases are follow-ups that stand for `done`.
+var destroyPhrases = map[string]struct{}{
+	"destroy it":              {},
+	"delete it":               {},
+	"kill it":                 {},
+	"tear it down":            {},
+	"shut it down":            {},
+	"i'm done with it":        {},
+	"im done with it":         {},
+	"get rid of it":           {},
+	"i don't need it":         {},
+	"i dont need it":          {},
+	"i don't need it anymore": {},
+	"i dont need it anymore":  {},
+}
+
+// statusPhrases are follow-ups that stand for `auth`, which reports the
+// cluster's state and delivers its credentials once ready.
+var statusPhrases = map[string]struct{}{
+	"is it ready":       {},
+	"is it up":          {},
+	"is it done":        {},
+	"how is it doing":   {},
+	"how's it doing":    {},
+	"hows it doing":     {},
+	"what's its status": {},
+	"whats its status":  {},
+}
+
+// extendPhrase matches "extend it by 2 hours", "give it 30 more minutes",
+// "keep it for 2h", and similar.
+var extendPhrase = regexp.MustCompile(`^(?:extend it(?: by| for)?|give it|keep it(?: for)?) (\d+) ?(?:more )?(hours?|hrs?|h|minutes?|mins?|m)$`)
+
+// resolveConversationalCommand rewrites a recognized follow-up into the
+// command it stands for, resolving "it" to the user's most recent cluster
+// where the command needs a name.
+func resolveConversationalCommand(jobManager manager.JobManager, user, text string) (string, bool) {
+	normalized := strings.ToLower(strings.TrimSpace(text))
+	normalized = strings.TrimRight(normalized, ".!?")
+	normalized = strings.Join(strings.Fields(normalized), " ")
+	if _, ok := destroyPhrases[normalized]; ok {
+		return "done", true
+	}
+	if _, ok := statusPhrases[normalized]; ok {
+		return "auth", true
+	}
+	if match := extendPhrase.FindStringSubmatch(normalized); match != nil {
+		job, err := jobManager.GetLaunchJob(user)
+		if err != nil || job == nil {
+			return "", false
+		}
+		unit := "h"
+		if strings.HasPrefix(match[2], "m") {
+			unit = "m"
+		}
+		return fmt.Sprintf("extend %s %s%s", job.Name, match[1], unit), true
+	}
+	return "", false
+}
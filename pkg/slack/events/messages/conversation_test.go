@@ -0,0 +1,77 @@
+package messages
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/openshift/ci-chat-bot/pkg/manager"
+)
+
+// stubJobManager embeds the JobManager interface and overrides only the
+// lookup the conversational rewrite needs.
+type stubJobManager struct {
+	manager.JobManager
+	job *manager.Job
+}
+
+func (m *stubJobManager) GetLaunchJob(user string) (*manager.Job, error) {
+	if m.job == nil {
+		return nil, fmt.Errorf("you have no running cluster")
+	}
+	return m.job, nil
+}
+
+func TestResolveConversationalCommand(t *testing.T) {
+	withCluster := &stubJobManager{job: &manager.Job{Name: "chat-bot-2026-08-30-120000.0001"}}
+	withoutCluster := &stubJobManager{}
+	testCases := []struct {
+		name       string
+		jobManager manager.JobManager
+		text       string
+		expected   string
+		rewritten  bool
+	}{{
+		name:       "destroy follow-up becomes done",
+		jobManager: withCluster,
+		text:       "destroy it!",
+		expected:   "done",
+		rewritten:  true,
+	}, {
+		name:       "status follow-up becomes auth",
+		jobManager: withCluster,
+		text:       "Is it ready?",
+		expected:   "auth",
+		rewritten:  true,
+	}, {
+		name:       "extend follow-up resolves the cluster name",
+		jobManager: withCluster,
+		text:       "extend it by 2 hours",
+		expected:   "extend chat-bot-2026-08-30-120000.0001 2h",
+		rewritten:  true,
+	}, {
+		name:       "minutes phrasing uses the minute unit",
+		jobManager: withCluster,
+		text:       "give it 30 more minutes",
+		expected:   "extend chat-bot-2026-08-30-120000.0001 30m",
+		rewritten:  true,
+	}, {
+		name:       "extend without a cluster is not rewritten",
+		jobManager: withoutCluster,
+		text:       "extend it by 2 hours",
+	}, {
+		name:       "ordinary commands are untouched",
+		jobManager: withCluster,
+		text:       "launch 4.18 aws",
+	}}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rewritten, ok := resolveConversationalCommand(testCase.jobManager, "U1", testCase.text)
+			if ok != testCase.rewritten {
+				t.Fatalf("expected rewritten=%t, got %t (%q)", testCase.rewritten, ok, rewritten)
+			}
+			if ok && rewritten != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, rewritten)
+			}
+		})
+	}
+}
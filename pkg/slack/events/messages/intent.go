@@ -0,0 +1,84 @@
+package messages
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+
+	"github.com/openshift/ci-chat-bot/pkg/manager"
+	"github.com/openshift/ci-chat-bot/pkg/slack/intent"
+	"github.com/openshift/ci-chat-bot/pkg/slack/parser"
+)
+
+// A free-form request that matched no command used to dead-end at the
+// unknown-command hint. It now falls through to the pluggable intent layer
+// (pkg/slack/intent): when a matcher reads a structured command out of the
+// message, the interpretation is shown to the user and held until they
+// confirm with `yes`, so the bot never executes a guess.
+
+// intentConfirmWindow is how long an interpreted command waits for its
+// confirmation before it is forgotten.
+const intentConfirmWindow = 5 * time.Minute
+
+var pendingIntents = struct {
+	sync.Mutex
+	byUser map[string]pendingIntent
+}{byUser: map[string]pendingIntent{}}
+
+type pendingIntent struct {
+	command string
+	expires time.Time
+}
+
+// takePendingIntent removes and returns the user's unexpired pending command.
+func takePendingIntent(user string) (string, bool) {
+	pendingIntents.Lock()
+	defer pendingIntents.Unlock()
+	pending, ok := pendingIntents.byUser[user]
+	if !ok {
+		return "", false
+	}
+	delete(pendingIntents.byUser, user)
+	if time.Now().After(pending.expires) {
+		return "", false
+	}
+	return pending.command, true
+}
+
+func setPendingIntent(user, command string) {
+	pendingIntents.Lock()
+	defer pendingIntents.Unlock()
+	pendingIntents.byUser[user] = pendingIntent{command: command, expires: time.Now().Add(intentConfirmWindow)}
+}
+
+// handleFreeFormIntent interprets messages that matched no command and runs
+// the confirmation exchange around the interpretation. It reports handled
+// when it consumed the message.
+func handleFreeFormIntent(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, botCommands []parser.BotCommand, allowed bool) (bool, error) {
+	text := strings.ToLower(strings.TrimSpace(event.Text))
+	text = strings.TrimRight(text, ".!")
+	switch text {
+	case "yes", "y", "yes please", "do it", "confirm":
+		command, ok := takePendingIntent(event.User)
+		if !ok {
+			return false, nil
+		}
+		event.Text = command
+		return matchCommand(client, jobManager, event, botCommands, allowed)
+	case "no", "cancel", "nevermind", "never mind":
+		if _, ok := takePendingIntent(event.User); !ok {
+			return false, nil
+		}
+		return true, postResponse(client, event, "okay, I won't run it - rephrase your request or see `help`")
+	}
+	command, ok := intent.Interpret(event.Text)
+	if !ok {
+		return false, nil
+	}
+	setPendingIntent(event.User, command)
+	return true, postResponse(client, event, fmt.Sprintf("I read that as `%s` - reply `yes` to run it, or `no` to cancel", command))
+}
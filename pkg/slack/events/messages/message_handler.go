@@ -6,9 +6,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/openshift/ci-chat-bot/pkg/i18n"
 	"github.com/openshift/ci-chat-bot/pkg/manager"
 	"github.com/openshift/ci-chat-bot/pkg/slack/events"
+	"github.com/openshift/ci-chat-bot/pkg/slack/modals/onboarding"
 	"github.com/openshift/ci-chat-bot/pkg/slack/parser"
+	"github.com/openshift/ci-chat-bot/pkg/utils"
 	"github.com/sirupsen/logrus"
 	"github.com/slack-go/slack"
 	"github.com/slack-go/slack/slackevents"
@@ -37,8 +40,15 @@ func Handle(client *slack.Client, manager manager.JobManager, botCommands []pars
 				}
 			}
 			mceConfig.Mutex.RUnlock()
-			if strings.TrimSpace(event.Text) == "help" {
-				help(client, event, botCommands, allowed)
+			// normalize punctuation substituted by rich-text editors before any matching
+			// so commands pasted from docs behave like hand-typed ones
+			event.Text = utils.NormalizeSmartPunctuation(event.Text)
+			// a command may be sent as a fenced code block with one option per
+			// line; flatten it before matching
+			event.Text, _ = utils.FlattenFencedCommand(event.Text)
+			trimmed := strings.TrimSpace(event.Text)
+			if trimmed == "help" || strings.HasPrefix(trimmed, "help ") {
+				help(client, event, botCommands, allowed, strings.TrimSpace(strings.TrimPrefix(trimmed, "help")), userLocale(manager, event.User))
 				return true, nil
 			}
 			// do not respond to bots
@@ -53,32 +63,84 @@ func Handle(client *slack.Client, manager manager.JobManager, botCommands []pars
 				}
 				return true, nil
 			}
-			// do not respond if the event SubType is message_changed or file_share( in cases a link is posted and a preview is
-			// added afterwards and when an attachment is included)
-			if event.SubType == "message_changed" || event.SubType == "file_share" {
+			// do not respond if the event SubType is message_changed (in case a link is posted and a preview is
+			// added afterwards); a file_share is only handled when it carries a command in
+			// its text, so attached parameter files can accompany a launch
+			if event.SubType == "message_changed" {
 				return true, nil
 			}
-			for _, command := range botCommands {
-				if command.IsPrivate() && !allowed {
-					continue
+			if event.SubType == "file_share" && len(trimmed) == 0 {
+				return true, nil
+			}
+			// first contact: show the onboarding notice in place of the
+			// command; launches stay blocked in the job manager until the
+			// user presses the accept button
+			if !manager.HasAcknowledgedTerms(event.User) {
+				if err := onboarding.Post(client, event.Channel, event.User); err != nil {
+					return false, fmt.Errorf("failed to post the onboarding notice: %w", err)
+				}
+				return true, nil
+			}
+			if handled, err := matchCommand(client, manager, event, botCommands, allowed); handled || err != nil {
+				return handled, err
+			}
+			// no command matched directly; expand the user's aliases before giving up
+			// so that aliases can never shadow a built-in command
+			if expanded, ok := manager.ExpandUserAlias(event.User, event.Text); ok {
+				event.Text = expanded
+				if handled, err := matchCommand(client, manager, event, botCommands, allowed); handled || err != nil {
+					return handled, err
 				}
-				properties, match := command.Match(event.Text)
-				if match {
-					response := command.Execute(client, manager, event, properties)
-					if err := postResponse(client, event, response); err != nil {
-						return false, fmt.Errorf("failed all attempts to post the response to the requested action: %s", event.Text)
-					}
-					return true, nil
+			}
+			// follow-ups like "extend it by 2 hours" resolve "it" to the
+			// user's most recent cluster; see conversation.go
+			if rewritten, ok := resolveConversationalCommand(manager, event.User, event.Text); ok {
+				event.Text = rewritten
+				if handled, err := matchCommand(client, manager, event, botCommands, allowed); handled || err != nil {
+					return handled, err
 				}
 			}
-			if err := postResponse(client, event, "unrecognized command, msg me `help` for a list of all commands"); err != nil {
+			// free-form requests fall through to the intent layer, which
+			// always confirms its interpretation first; see intent.go
+			if handled, err := handleFreeFormIntent(client, manager, event, botCommands, allowed); handled || err != nil {
+				return handled, err
+			}
+			if err := postResponse(client, event, parseFailureHint(botCommands, allowed, event.Text, userLocale(manager, event.User))); err != nil {
 				return false, fmt.Errorf("failed all attempts to post the response to the requested action: %s", event.Text)
 			}
 			return true, nil
 		})
 }
 
+func matchCommand(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, botCommands []parser.BotCommand, allowed bool) (bool, error) {
+	for _, command := range botCommands {
+		if command.IsPrivate() && !allowed {
+			continue
+		}
+		properties, match := command.Match(event.Text)
+		if match {
+			response := command.Execute(client, jobManager, event, properties)
+			// an empty response means the command (or a middleware) chose to
+			// stay silent, e.g. suppressed repeat throttling warnings
+			if len(response) == 0 {
+				return true, nil
+			}
+			if err := postResponse(client, event, response); err != nil {
+				return false, fmt.Errorf("failed all attempts to post the response to the requested action: %s", event.Text)
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func postResponse(client *slack.Client, event *slackevents.MessageEvent, response string) error {
+	logger := logrus.WithFields(logrus.Fields{
+		"user":           event.User,
+		"channel":        event.Channel,
+		"event":          event.Text,
+		"correlation_id": utils.CorrelationID(event.ClientMsgID, event.User, event.TimeStamp),
+	})
 	var lastErr error
 	ctx := context.TODO()
 	err := wait.PollUntilContextTimeout(ctx, 5*time.Second, 20*time.Second, true, func(ctx context.Context) (bool, error) {
@@ -87,69 +149,155 @@ func postResponse(client *slack.Client, event *slackevents.MessageEvent, respons
 			lastErr = err
 			return false, nil
 		}
-		klog.Infof("Posted response to UserID: %s (event: `%s`) at %s", event.User, event.Text, responseTimestamp)
+		logger.WithField("response_timestamp", responseTimestamp).Info("posted response")
 		return true, nil
 	})
 	if err != nil {
-		klog.Errorf("Failed to post response to UserID: %s; (event: `%s`) at %d; %v", event.User, event.Text, (time.Now()).Unix(), err)
+		logger.WithError(lastErr).Error("failed to post response")
 		return lastErr
 	}
 	return nil
 }
 
-func help(client *slack.Client, event *slackevents.MessageEvent, botCommands []parser.BotCommand, allowPrivate bool) {
-	helpMessage := ""
-	for _, command := range botCommands {
-		if command.IsPrivate() && !allowPrivate {
-			continue
-		}
-		tokens := command.Tokenize()
+// userLocale returns the message catalog locale the user selected with
+// `set lang=<code>`, or the default locale
+func userLocale(jobManager manager.JobManager, user string) string {
+	if locale, ok := jobManager.GetUserPreferences(user)["lang"]; ok {
+		return locale
+	}
+	return i18n.DefaultLocale
+}
 
-		// # <command>
-		helpMessage += "> *"
-		for _, token := range tokens {
-			if !token.IsParameter() {
-				helpMessage += token.Word + " "
-			}
+// commandKeywords returns the leading non-parameter words that identify a command,
+// e.g. "rosa create" for `rosa create <version> <duration>`
+func commandKeywords(command parser.BotCommand) string {
+	var words []string
+	for _, token := range command.Tokenize() {
+		if token.IsParameter() {
+			break
 		}
-		helpMessage += "*\n"
+		words = append(words, token.Word)
+	}
+	return strings.Join(words, " ")
+}
 
-		// ## Usage
-		// ```
-		// usage
-		// ```
-		helpMessage += "*Usage*\n"
-		helpMessage += "```\n"
-		for _, token := range tokens {
+// renderCommandHelp renders the usage, description, and example sections for a
+// single command from its registered metadata
+func renderCommandHelp(command parser.BotCommand) string {
+	tokens := command.Tokenize()
+	helpMessage := ""
+
+	// # <command>
+	helpMessage += "> *"
+	for _, token := range tokens {
+		if !token.IsParameter() {
 			helpMessage += token.Word + " "
 		}
+	}
+	helpMessage += "*\n"
+
+	// ## Usage
+	// ```
+	// usage
+	// ```
+	helpMessage += "*Usage*\n"
+	helpMessage += "```\n"
+	for _, token := range tokens {
+		helpMessage += token.Word + " "
+	}
+	helpMessage += "```\n"
+
+	// ## Description
+	// description...
+	if len(command.Definition().Description) > 0 {
+		helpMessage += "*Description*\n"
+		helpMessage += command.Definition().Description
+		helpMessage += "\n"
+	}
+
+	// ## Example
+	// ```
+	// example
+	// ```
+	if len(command.Definition().Example) > 0 {
+		helpMessage += "*Example*\n"
 		helpMessage += "```\n"
+		helpMessage += command.Definition().Example
+		helpMessage += "```\n"
+	}
+	return helpMessage
+}
 
-		// ## Description
-		// description...
-		if len(command.Definition().Description) > 0 {
-			helpMessage += "*Description*\n"
-			helpMessage += command.Definition().Description
-			helpMessage += "\n"
+func help(client *slack.Client, event *slackevents.MessageEvent, botCommands []parser.BotCommand, allowPrivate bool, topic, locale string) {
+	helpMessage := ""
+	var matched int
+	for _, command := range botCommands {
+		if command.IsPrivate() && !allowPrivate {
+			continue
 		}
-
-		// ## Example
-		// ```
-		// example
-		// ```
-		if len(command.Definition().Example) > 0 {
-			helpMessage += "*Example*\n"
-			helpMessage += "```\n"
-			helpMessage += command.Definition().Example
-			helpMessage += "```\n"
+		if len(topic) > 0 {
+			keywords := commandKeywords(command)
+			if keywords != topic && !strings.HasPrefix(keywords, topic+" ") {
+				continue
+			}
 		}
+		matched++
+		helpMessage += renderCommandHelp(command)
+	}
+	if len(topic) > 0 && matched == 0 {
+		helpMessage = i18n.T(locale, "help-no-command", topic)
+	}
+	if len(topic) == 0 {
+		// Adding pointer to our FAQ...
+		helpMessage += i18n.T(locale, "help-additional-links")
+		helpMessage += i18n.T(locale, "help-faq")
+		helpMessage += i18n.T(locale, "help-forum")
 	}
-	// Adding pointer to our FAQ...
-	helpMessage += "*Additional Links*\n"
-	helpMessage += "Please check out our <https://github.com/openshift/ci-chat-bot/blob/master/docs/FAQ.md|Frequently Asked Questions> for more information.\n"
-	helpMessage += "You can also reach out to us in <https://redhat-internal.slack.com/archives/CNHC2DK2M|#forum-ocp-crt> for more information.\n"
 	_, _, err := client.PostMessage(event.Channel, slack.MsgOptionText(helpMessage, false))
 	if err != nil {
 		klog.Warningf("Failed to post the help message")
 	}
 }
+
+// parseFailureHint builds the reply for a message that matched no command. When the
+// first word identifies a known command, the command's own metadata is rendered as a
+// hint instead of the generic unknown-command reply.
+func parseFailureHint(botCommands []parser.BotCommand, allowPrivate bool, text, locale string) string {
+	fields := strings.Fields(text)
+	if len(fields) > 0 {
+		var usages []string
+		for _, command := range botCommands {
+			if command.IsPrivate() && !allowPrivate {
+				continue
+			}
+			keywords := commandKeywords(command)
+			if keywords == fields[0] || strings.HasPrefix(keywords, fields[0]+" ") {
+				usages = append(usages, command.Usage())
+			}
+		}
+		if len(usages) > 0 {
+			return i18n.T(locale, "unparsed-command", fields[0], strings.Join(usages, "\n"), fields[0])
+		}
+		// the first word isn't a command at all; suggest the closest ones
+		keywordSet := make(map[string]struct{})
+		var keywords []string
+		for _, command := range botCommands {
+			if command.IsPrivate() && !allowPrivate {
+				continue
+			}
+			words := strings.Fields(commandKeywords(command))
+			if len(words) == 0 {
+				continue
+			}
+			keyword := words[0]
+			if _, ok := keywordSet[keyword]; !ok {
+				keywordSet[keyword] = struct{}{}
+				keywords = append(keywords, keyword)
+			}
+		}
+		if suggestions := utils.ClosestMatches(fields[0], keywords, 2); len(suggestions) > 0 {
+			return i18n.T(locale, "unrecognized-command-suggest", fields[0], suggestions[0])
+		}
+	}
+	return i18n.T(locale, "unrecognized-command")
+}
@@ -0,0 +1,58 @@
+// Package reactions turns emoji reactions on the bot's own messages into
+// command shortcuts, so users can act on a notification without typing:
+// reacting with a wastebasket runs `done`, which asks for `done confirm`
+// before anything is torn down, and an alarm clock extends the cluster by
+// two hours. Only reactions on messages the bot posted are considered, and
+// the shortcut runs as the reacting user with the same ownership checks as
+// the typed command.
+package reactions
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+
+	"github.com/openshift/ci-chat-bot/pkg/manager"
+	botslack "github.com/openshift/ci-chat-bot/pkg/slack"
+	"github.com/openshift/ci-chat-bot/pkg/slack/events"
+)
+
+func Handle(client *slack.Client, jobManager manager.JobManager) events.PartialHandler {
+	var once sync.Once
+	var botUserID string
+	return events.PartialHandlerFunc("reaction-shortcut",
+		func(callback *slackevents.EventsAPIEvent, logger *logrus.Entry) (handled bool, err error) {
+			if callback.Type != slackevents.CallbackEvent {
+				return false, nil
+			}
+			event, ok := callback.InnerEvent.Data.(*slackevents.ReactionAddedEvent)
+			if !ok {
+				return false, nil
+			}
+			once.Do(func() {
+				identity, err := client.AuthTest()
+				if err != nil {
+					logger.WithError(err).Warn("Failed to resolve the bot's own user ID; ignoring reactions.")
+					return
+				}
+				botUserID = identity.UserID
+			})
+			// only reactions on the bot's own messages are shortcuts; a
+			// wastebasket on a colleague's joke should not tear a cluster down
+			if len(botUserID) == 0 || event.ItemUser != botUserID || event.User == botUserID {
+				return true, nil
+			}
+			reply := botslack.HandleReaction(client, jobManager, event.Reaction, event.User)
+			if len(reply) == 0 {
+				return true, nil
+			}
+			logger.Infof("reaction :%s: from %s ran a shortcut", event.Reaction, event.User)
+			if _, _, err := client.PostMessage(event.Item.Channel, slack.MsgOptionText(fmt.Sprintf("<@%s> %s", event.User, reply), false)); err != nil {
+				return true, err
+			}
+			return true, nil
+		})
+}
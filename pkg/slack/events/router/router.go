@@ -10,13 +10,17 @@ import (
 	"github.com/slack-go/slack"
 
 	"github.com/openshift/ci-chat-bot/pkg/slack/events"
+	"github.com/openshift/ci-chat-bot/pkg/slack/events/deprovision"
 	"github.com/openshift/ci-chat-bot/pkg/slack/events/messages"
+	"github.com/openshift/ci-chat-bot/pkg/slack/events/reactions"
 )
 
 // ForEvents returns a Handler that appropriately routes
 // event callbacks for the handlers we know about
 func ForEvents(client *slack.Client, manager manager.JobManager, botCommands []slackCommandParser.BotCommand, filer jira.IssueFiler) events.Handler {
 	return events.MultiHandler(
+		reactions.Handle(client, manager),
+		deprovision.Handle(manager),
 		messages.Handle(client, manager, botCommands),
 		mention.Handler(client),
 		workflowSubmissionEvents.Handler(client, filer),
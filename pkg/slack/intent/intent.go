@@ -0,0 +1,115 @@
+// Package intent maps free-form requests ("I need a 4.16 GCP cluster for 3
+// hours") to the structured commands they stand for. Matchers are pluggable
+// the same way command plugins are: a fork registers its own (for example an
+// embedding-based classifier) from an init function, and registered matchers
+// take precedence over the built-in keyword matcher. The message handler
+// always confirms an interpreted command with the user before executing it.
+package intent
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/openshift/ci-chat-bot/pkg/manager"
+	"github.com/openshift/ci-chat-bot/pkg/utils"
+)
+
+// Matcher interprets a free-form message.
+type Matcher interface {
+	// Interpret returns the structured command the message stands for, or
+	// ok=false when the message carries no intent the matcher recognizes.
+	Interpret(text string) (command string, ok bool)
+}
+
+var (
+	lock     sync.Mutex
+	matchers []Matcher
+)
+
+// Register adds a matcher ahead of the built-in keyword matcher. It is
+// intended to be called from an init function.
+func Register(m Matcher) {
+	lock.Lock()
+	defer lock.Unlock()
+	matchers = append(matchers, m)
+}
+
+// Interpret runs the registered matchers in order and falls back to the
+// built-in keyword matcher.
+func Interpret(text string) (string, bool) {
+	lock.Lock()
+	registered := matchers
+	lock.Unlock()
+	for _, m := range registered {
+		if command, ok := m.Interpret(text); ok {
+			return command, ok
+		}
+	}
+	return keywordMatcher{}.Interpret(text)
+}
+
+// launchVerbs are the cues that a free-form message is asking for a cluster.
+var launchVerbs = []string{"i need", "i want", "give me", "can i get", "can i have", "could i get", "spin up", "set up", "stand up", "launch me"}
+
+// platformSynonyms maps the words users write to supported platform names.
+var platformSynonyms = map[string]string{
+	"google":     "gcp",
+	"amazon":     "aws",
+	"baremetal":  "metal",
+	"bare-metal": "metal",
+	"hypershift": "hypershift-hosted",
+}
+
+// versionPattern finds a major.minor(.patch) version in free text.
+var versionPattern = regexp.MustCompile(`\b(4\.\d+(?:\.\d+)?)\b`)
+
+// keywordMatcher recognizes launch intent from keywords alone: a launch cue
+// plus the word "cluster", with the version, platform, and architecture
+// picked out of the text. It deliberately stays dumb - anything it cannot
+// read precisely enough is left for the unknown-command hint.
+type keywordMatcher struct{}
+
+func (keywordMatcher) Interpret(text string) (string, bool) {
+	normalized := strings.ToLower(strings.Join(strings.Fields(text), " "))
+	if !strings.Contains(normalized, "cluster") {
+		return "", false
+	}
+	var cued bool
+	for _, verb := range launchVerbs {
+		if strings.Contains(normalized, verb) {
+			cued = true
+			break
+		}
+	}
+	if !cued {
+		return "", false
+	}
+	version := "nightly"
+	if match := versionPattern.FindStringSubmatch(normalized); match != nil {
+		version = match[1]
+	}
+	var options []string
+	for _, word := range strings.Fields(normalized) {
+		word = strings.Trim(word, ".,!?")
+		if canonical, ok := platformSynonyms[word]; ok {
+			word = canonical
+		}
+		if utils.Contains(manager.SupportedPlatforms, word) || utils.Contains(manager.SupportedArchitectures, word) {
+			if !utils.Contains(options, word) {
+				options = append(options, word)
+			}
+		}
+	}
+	if len(options) == 0 && version == "nightly" {
+		// nothing concrete was extracted; a bare "I need a cluster" is too
+		// vague to put a command in front of the user
+		return "", false
+	}
+	command := fmt.Sprintf("launch %s", version)
+	if len(options) > 0 {
+		command = fmt.Sprintf("%s %s", command, strings.Join(options, ","))
+	}
+	return command, true
+}
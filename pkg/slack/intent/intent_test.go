@@ -0,0 +1,47 @@
+package intent
+
+import "testing"
+
+func TestKeywordMatcherInterpret(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected string
+		ok       bool
+	}{{
+		name:     "version platform and duration",
+		text:     "I need a 4.16 GCP cluster for 3 hours",
+		expected: "launch 4.16 gcp",
+		ok:       true,
+	}, {
+		name:     "platform synonym and architecture",
+		text:     "can I get an arm64 cluster on Google please?",
+		expected: "launch nightly arm64,gcp",
+		ok:       true,
+	}, {
+		name:     "version only",
+		text:     "spin up a 4.12.17 cluster",
+		expected: "launch 4.12.17",
+		ok:       true,
+	}, {
+		name: "no launch cue",
+		text: "the 4.16 gcp cluster is broken",
+	}, {
+		name: "too vague to interpret",
+		text: "I need a cluster",
+	}, {
+		name: "ordinary chatter",
+		text: "thanks, that worked",
+	}}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			command, ok := keywordMatcher{}.Interpret(testCase.text)
+			if ok != testCase.ok {
+				t.Fatalf("expected ok=%t, got %t (%q)", testCase.ok, ok, command)
+			}
+			if ok && command != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, command)
+			}
+		})
+	}
+}
@@ -7,8 +7,10 @@ import (
 	"github.com/openshift/ci-chat-bot/pkg/slack/interactions"
 	"github.com/openshift/ci-chat-bot/pkg/slack/modals"
 	"github.com/openshift/ci-chat-bot/pkg/slack/modals/done"
+	"github.com/openshift/ci-chat-bot/pkg/slack/modals/expiry"
 	"github.com/openshift/ci-chat-bot/pkg/slack/modals/launch/steps"
 	"github.com/openshift/ci-chat-bot/pkg/slack/modals/list"
+	"github.com/openshift/ci-chat-bot/pkg/slack/modals/onboarding"
 	"github.com/openshift/ci-chat-bot/pkg/slack/modals/refresh"
 	"github.com/openshift/ci-chat-bot/pkg/slack/modals/stepsFromApp"
 	"github.com/sirupsen/logrus"
@@ -35,6 +37,9 @@ func ForModals(client *slack.Client, jobmanager manager.JobManager, httpclient *
 		list.Register(client, jobmanager),
 		done.Register(client, jobmanager),
 		refresh.Register(client, jobmanager),
+		expiry.RegisterKeepArtifacts(client, jobmanager),
+		expiry.RegisterExtend(client, jobmanager),
+		onboarding.Register(client, jobmanager),
 	}
 
 	for _, entry := range toRegister {
@@ -66,7 +71,12 @@ func (r *modalRouter) Handle(callback *slack.InteractionCallback, logger *logrus
 		return nil, r.viewForShortcut(callback, logger)
 	case slack.InteractionTypeBlockActions:
 		if isMessageButtonPress(callback) {
-			return nil, r.viewForButton(callback, logger)
+			// buttons whose action ID has a registered handler (e.g. the
+			// expiry grace-window actions) are handled directly; the rest
+			// open the modal named by the button value
+			if _, registered := r.handlersByIDAndType[modals.Identifier(callback.ActionCallback.BlockActions[0].ActionID)]; !registered {
+				return nil, r.viewForButton(callback, logger)
+			}
 		}
 		return r.delegate(callback, logger)
 	case slack.InteractionTypeViewSubmission:
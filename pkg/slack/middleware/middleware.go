@@ -0,0 +1,133 @@
+// Package middleware lets cross-cutting concerns - auditing, metrics, rate
+// limiting, auth, tracing - be layered around bot command dispatch without
+// patching each handler. Deployments compose a chain once at startup with
+// Wrap and can insert their own middleware alongside the built-ins.
+package middleware
+
+import (
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/openshift/ci-chat-bot/pkg/manager"
+	"github.com/openshift/ci-chat-bot/pkg/slack/parser"
+	"github.com/openshift/ci-chat-bot/pkg/tracing"
+	"github.com/openshift/ci-chat-bot/pkg/utils"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+)
+
+// Middleware wraps a command handler with additional behavior. The command is
+// provided so middleware can label logs and metrics without parsing the
+// message text again.
+type Middleware func(command parser.BotCommand, next parser.CommandHandler) parser.CommandHandler
+
+// Chain applies middlewares around a handler; the first middleware becomes
+// the outermost layer.
+func Chain(command parser.BotCommand, handler parser.CommandHandler, middlewares ...Middleware) parser.CommandHandler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](command, handler)
+	}
+	return handler
+}
+
+// wrappedCommand delegates everything to the underlying command except
+// execution, which runs through the middleware chain.
+type wrappedCommand struct {
+	parser.BotCommand
+	handler parser.CommandHandler
+}
+
+func (c *wrappedCommand) Execute(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+	return c.handler(client, jobManager, event, properties)
+}
+
+// Wrap returns commands whose execution runs through the middleware chain.
+func Wrap(commands []parser.BotCommand, middlewares ...Middleware) []parser.BotCommand {
+	if len(middlewares) == 0 {
+		return commands
+	}
+	wrapped := make([]parser.BotCommand, 0, len(commands))
+	for _, command := range commands {
+		wrapped = append(wrapped, &wrappedCommand{
+			BotCommand: command,
+			handler:    Chain(command, command.Execute, middlewares...),
+		})
+	}
+	return wrapped
+}
+
+// commandKeywords returns the leading non-parameter words identifying a
+// command, e.g. "rosa create" for `rosa create <version> <duration>`.
+func commandKeywords(command parser.BotCommand) string {
+	var words []string
+	for _, token := range command.Tokenize() {
+		if token.IsParameter() {
+			break
+		}
+		words = append(words, token.Word)
+	}
+	return strings.Join(words, " ")
+}
+
+// Audit logs who ran which command and how long it took. Every line carries
+// the command's correlation ID so it can be matched against the ProwJob and
+// its artifacts.
+func Audit() Middleware {
+	return func(command parser.BotCommand, next parser.CommandHandler) parser.CommandHandler {
+		keywords := commandKeywords(command)
+		return func(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+			started := time.Now()
+			response := next(client, jobManager, event, properties)
+			logrus.WithFields(logrus.Fields{
+				"command":        keywords,
+				"user":           event.User,
+				"channel":        event.Channel,
+				"correlation_id": utils.CorrelationID(event.ClientMsgID, event.User, event.TimeStamp),
+				"duration":       time.Since(started).Round(time.Millisecond).String(),
+			}).Info("command handled")
+			return response
+		}
+	}
+}
+
+// Tracing emits a span per command execution, labeled with the command
+// keywords and the requesting user, so launches can be traced end to end.
+func Tracing() Middleware {
+	return func(command parser.BotCommand, next parser.CommandHandler) parser.CommandHandler {
+		keywords := commandKeywords(command)
+		return func(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+			endSpan := tracing.StartSpan("command "+keywords,
+				attribute.String("command", keywords),
+				attribute.String("user", event.User),
+				attribute.String("channel", event.Channel),
+			)
+			response := next(client, jobManager, event, properties)
+			endSpan(nil)
+			return response
+		}
+	}
+}
+
+var commandExecutionsMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "ci_chat_bot_command_executions_total",
+	Help: "Number of bot command executions, by command.",
+}, []string{"command"})
+
+func init() {
+	prometheus.MustRegister(commandExecutionsMetric)
+}
+
+// Metrics counts command executions, labeled by command keywords.
+func Metrics() Middleware {
+	return func(command parser.BotCommand, next parser.CommandHandler) parser.CommandHandler {
+		keywords := commandKeywords(command)
+		return func(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+			commandExecutionsMetric.WithLabelValues(keywords).Inc()
+			return next(client, jobManager, event, properties)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/klog"
+
+	"github.com/openshift/ci-chat-bot/pkg/manager"
+	"github.com/openshift/ci-chat-bot/pkg/slack/parser"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+)
+
+const (
+	// DefaultRateLimit is how many commands per minute a user may run on
+	// average before being throttled.
+	DefaultRateLimit = 10
+	// DefaultRateBurst is how many commands a user may run back to back
+	// before the average limit kicks in.
+	DefaultRateBurst = 5
+
+	// rateLimiterIdleExpiry is how long an idle user's limiter is kept
+	// before being dropped to keep the map from growing without bound.
+	rateLimiterIdleExpiry = time.Hour
+)
+
+// userRateLimiters tracks one token bucket per slack user.
+type userRateLimiters struct {
+	lock           sync.Mutex
+	perMinute      int
+	burst          int
+	limiters       map[string]*rate.Limiter
+	lastSeen       map[string]time.Time
+	lastCleanup    time.Time
+	warnedRecently map[string]time.Time
+}
+
+func (l *userRateLimiters) limiterFor(user string) *rate.Limiter {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	now := time.Now()
+	if now.Sub(l.lastCleanup) > rateLimiterIdleExpiry {
+		for id, seen := range l.lastSeen {
+			if now.Sub(seen) > rateLimiterIdleExpiry {
+				delete(l.limiters, id)
+				delete(l.lastSeen, id)
+				delete(l.warnedRecently, id)
+			}
+		}
+		l.lastCleanup = now
+	}
+	limiter, ok := l.limiters[user]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(float64(l.perMinute)/60), l.burst)
+		l.limiters[user] = limiter
+	}
+	l.lastSeen[user] = now
+	return limiter
+}
+
+// shouldWarn reports whether the user should receive a throttling message;
+// repeated bursts only get one reply per minute so the bot does not add to
+// the flood.
+func (l *userRateLimiters) shouldWarn(user string) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if warned, ok := l.warnedRecently[user]; ok && time.Since(warned) < time.Minute {
+		return false
+	}
+	l.warnedRecently[user] = time.Now()
+	return true
+}
+
+// RateLimit throttles each user to perMinute commands on average with the
+// given burst allowance, so a runaway script cannot monopolize the bot or
+// the build clusters. Throttled commands are answered with a polite message
+// instead of being executed.
+func RateLimit(perMinute, burst int) Middleware {
+	limiters := &userRateLimiters{
+		perMinute:      perMinute,
+		burst:          burst,
+		limiters:       map[string]*rate.Limiter{},
+		lastSeen:       map[string]time.Time{},
+		warnedRecently: map[string]time.Time{},
+		lastCleanup:    time.Now(),
+	}
+	return func(command parser.BotCommand, next parser.CommandHandler) parser.CommandHandler {
+		return func(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+			if !limiters.limiterFor(event.User).Allow() {
+				klog.Warningf("throttling user %s: more than %d commands/minute (burst %d)", event.User, perMinute, burst)
+				if !limiters.shouldWarn(event.User) {
+					return ""
+				}
+				return fmt.Sprintf("you're sending commands faster than I can handle them - please slow down to at most %d commands per minute and try again shortly", perMinute)
+			}
+			return next(client, jobManager, event, properties)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slack-go/slack/slackevents"
+
+	"github.com/openshift/ci-chat-bot/pkg/manager"
+	"github.com/openshift/ci-chat-bot/pkg/slack/parser"
+	"github.com/slack-go/slack"
+)
+
+func TestRateLimit(t *testing.T) {
+	command := parser.NewBotCommand("ping", &parser.CommandDefinition{
+		Handler: func(client *slack.Client, jobManager manager.JobManager, event *slackevents.MessageEvent, properties *parser.Properties) string {
+			return "pong"
+		},
+	}, false)
+	handler := RateLimit(60, 2)(command, command.Definition().Handler)
+	event := &slackevents.MessageEvent{User: "U1", Channel: "D1"}
+	for i := 0; i < 2; i++ {
+		if response := handler(nil, nil, event, nil); response != "pong" {
+			t.Fatalf("call %d: got %q, expected the command to run", i+1, response)
+		}
+	}
+	if response := handler(nil, nil, event, nil); !strings.Contains(response, "slow down") {
+		t.Errorf("got %q, expected a throttling message once the burst is exhausted", response)
+	}
+	if response := handler(nil, nil, event, nil); response != "" {
+		t.Errorf("got %q, expected repeat throttling warnings to be suppressed", response)
+	}
+	// an unrelated user is not affected by the first user's burst
+	other := &slackevents.MessageEvent{User: "U2", Channel: "D2"}
+	if response := handler(nil, nil, other, nil); response != "pong" {
+		t.Errorf("got %q, expected another user's command to run", response)
+	}
+}
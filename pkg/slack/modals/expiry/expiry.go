@@ -0,0 +1,57 @@
+// Package expiry handles the button presses on the grace-window notice the
+// bot posts when a cluster expires, letting the owner archive the cluster's
+// artifacts or extend it before the teardown proceeds.
+package expiry
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/openshift/ci-chat-bot/pkg/manager"
+	"github.com/openshift/ci-chat-bot/pkg/slack/interactions"
+	"github.com/openshift/ci-chat-bot/pkg/slack/modals"
+	"github.com/sirupsen/logrus"
+	"github.com/slack-go/slack"
+)
+
+const (
+	// KeepArtifactsIdentifier is the action ID of the "Keep artifacts" button.
+	KeepArtifactsIdentifier = "expiry-keep-artifacts"
+	// ExtendIdentifier is the action ID of the "Extend 2 hours" button.
+	ExtendIdentifier = "expiry-extend"
+
+	// extension is how much life the "Extend 2 hours" button buys.
+	extension = 2 * time.Hour
+)
+
+// RegisterKeepArtifacts wires the "Keep artifacts" button to the job manager.
+func RegisterKeepArtifacts(client *slack.Client, jobmanager manager.JobManager) *modals.FlowWithViewAndFollowUps {
+	return modals.ForView(KeepArtifactsIdentifier, slack.ModalViewRequest{}).WithFollowUps(map[slack.InteractionType]interactions.Handler{
+		slack.InteractionTypeBlockActions: respond(KeepArtifactsIdentifier, client, jobmanager.KeepArtifacts),
+	})
+}
+
+// RegisterExtend wires the "Extend 2 hours" button to the job manager.
+func RegisterExtend(client *slack.Client, jobmanager manager.JobManager) *modals.FlowWithViewAndFollowUps {
+	return modals.ForView(ExtendIdentifier, slack.ModalViewRequest{}).WithFollowUps(map[slack.InteractionType]interactions.Handler{
+		slack.InteractionTypeBlockActions: respond(ExtendIdentifier, client, func(requester, name string) (string, error) {
+			return jobmanager.ExtendJobExpiry(requester, name, extension)
+		}),
+	})
+}
+
+// respond invokes the action against the cluster named by the button value
+// and posts the outcome back to the channel the notice was in.
+func respond(identifier string, client *slack.Client, action func(requester, name string) (string, error)) interactions.Handler {
+	return interactions.HandlerFunc(identifier, func(callback *slack.InteractionCallback, logger *logrus.Entry) ([]byte, error) {
+		name := callback.ActionCallback.BlockActions[0].Value
+		msg, err := action(callback.User.ID, name)
+		if err != nil {
+			msg = err.Error()
+		}
+		if _, _, err := client.PostMessage(callback.Channel.ID, slack.MsgOptionText(fmt.Sprintf("<@%s> %s", callback.User.ID, msg), false)); err != nil {
+			logger.WithError(err).Warn("Failed to post the expiry action response.")
+		}
+		return nil, nil
+	})
+}
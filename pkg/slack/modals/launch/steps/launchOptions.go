@@ -10,6 +10,7 @@ import (
 	"github.com/openshift/ci-chat-bot/pkg/slack/interactions"
 	"github.com/openshift/ci-chat-bot/pkg/slack/modals"
 	"github.com/openshift/ci-chat-bot/pkg/slack/modals/launch"
+	"github.com/openshift/ci-chat-bot/pkg/utils"
 	"github.com/sirupsen/logrus"
 	"github.com/slack-go/slack"
 )
@@ -49,6 +50,7 @@ func processLaunchOptionsStep(updater modals.ViewUpdater, jobmanager manager.Job
 			OriginalMessage: launchCommand,
 			User:            callback.User.ID,
 			UserName:        callback.User.Name,
+			CorrelationID:   utils.CorrelationID("", callback.User.ID, callback.TriggerID),
 			Inputs:          [][]string{launchInputs},
 			Type:            manager.JobTypeInstall,
 			Platform:        platform,
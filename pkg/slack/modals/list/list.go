@@ -4,10 +4,11 @@ import (
 	"encoding/json"
 
 	"github.com/openshift/ci-chat-bot/pkg/manager"
+	"github.com/openshift/ci-chat-bot/pkg/slack"
 	"github.com/openshift/ci-chat-bot/pkg/slack/interactions"
 	"github.com/openshift/ci-chat-bot/pkg/slack/modals"
 	"github.com/sirupsen/logrus"
-	"github.com/slack-go/slack"
+	slackClient "github.com/slack-go/slack"
 )
 
 const (
@@ -15,16 +16,17 @@ const (
 	filterByVersion  = "filter_by_version"
 	filterByPlatform = "filter_by_platform"
 	filterByUser     = "filter_by_user"
+	filterByTag      = "filter_by_tag"
 )
 
-func Register(client *slack.Client, jobmanager manager.JobManager) *modals.FlowWithViewAndFollowUps {
-	return modals.ForView(Identifier, View()).WithFollowUps(map[slack.InteractionType]interactions.Handler{
-		slack.InteractionTypeViewSubmission: processNextForFirstStep(client, jobmanager),
+func Register(client *slackClient.Client, jobmanager manager.JobManager) *modals.FlowWithViewAndFollowUps {
+	return modals.ForView(Identifier, View()).WithFollowUps(map[slackClient.InteractionType]interactions.Handler{
+		slackClient.InteractionTypeViewSubmission: processNextForFirstStep(client, jobmanager),
 	})
 }
 
-func processNextForFirstStep(updater *slack.Client, jobmanager manager.JobManager) interactions.Handler {
-	return interactions.HandlerFunc("list", func(callback *slack.InteractionCallback, logger *logrus.Entry) (output []byte, err error) {
+func processNextForFirstStep(updater *slackClient.Client, jobmanager manager.JobManager) interactions.Handler {
+	return interactions.HandlerFunc("list", func(callback *slackClient.InteractionCallback, logger *logrus.Entry) (output []byte, err error) {
 		go func() {
 			inputs := modals.CallBackInputAll(callback)
 			var filters manager.ListFilters
@@ -36,10 +38,12 @@ func processNextForFirstStep(updater *slack.Client, jobmanager manager.JobManage
 					filters.Version = input
 				case filterByUser:
 					filters.Requestor = input
+				case filterByTag:
+					filters.Tag = input
 				}
 			}
-			runningJobs := jobmanager.ListJobs(callback.User.ID, filters)
-			overwriteView := func(view slack.ModalViewRequest) {
+			runningJobs := jobmanager.ListJobs(callback.User.ID, slack.GetUserTimezone(updater, callback.User.ID), filters)
+			overwriteView := func(view slackClient.ModalViewRequest) {
 				// don't pass a hash, so we overwrite the View always
 				response, err := updater.UpdateView(view, "", "", callback.View.ID)
 				if err != nil {
@@ -49,8 +53,8 @@ func processNextForFirstStep(updater *slack.Client, jobmanager manager.JobManage
 			}
 			overwriteView(SubmissionView(runningJobs))
 		}()
-		response, err := json.Marshal(&slack.ViewSubmissionResponse{
-			ResponseAction: slack.RAUpdate,
+		response, err := json.Marshal(&slackClient.ViewSubmissionResponse{
+			ResponseAction: slackClient.RAUpdate,
 			View:           PrepareNextStepView(),
 		})
 		if err != nil {
@@ -45,6 +45,16 @@ func View() slackClient.ModalViewRequest {
 					Placeholder: &slackClient.TextBlockObject{Type: slackClient.PlainTextType, Text: "Enter a version..."},
 				},
 			},
+			&slackClient.InputBlock{
+				Type:     slackClient.MBTInput,
+				BlockID:  filterByTag,
+				Optional: true,
+				Label:    &slackClient.TextBlockObject{Type: slackClient.PlainTextType, Text: "Filter by tag"},
+				Element: &slackClient.PlainTextInputBlockElement{
+					Type:        slackClient.METPlainTextInput,
+					Placeholder: &slackClient.TextBlockObject{Type: slackClient.PlainTextType, Text: "Enter a tag..."},
+				},
+			},
 			&slackClient.SectionBlock{
 				Type:    slackClient.MBTSection,
 				Text:    &slackClient.TextBlockObject{Type: slackClient.MarkdownType, Text: "*Filter by User*"},
@@ -0,0 +1,58 @@
+// Package onboarding posts the first-contact notice - usage rules, quota,
+// and cost expectations - and records the user's acknowledgment when they
+// press its accept button. The message handler shows the notice in place of
+// a new user's first command; launches stay blocked in the job manager until
+// the acknowledgment lands.
+package onboarding
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/slack-go/slack"
+
+	"github.com/openshift/ci-chat-bot/pkg/manager"
+	"github.com/openshift/ci-chat-bot/pkg/slack/interactions"
+	"github.com/openshift/ci-chat-bot/pkg/slack/modals"
+)
+
+// Identifier is the action ID of the accept button on the onboarding notice.
+const Identifier = "onboarding-acknowledge"
+
+// Register wires the accept button to the job manager's terms acknowledgment.
+func Register(client *slack.Client, jobmanager manager.JobManager) *modals.FlowWithViewAndFollowUps {
+	return modals.ForView(Identifier, slack.ModalViewRequest{}).WithFollowUps(map[slack.InteractionType]interactions.Handler{
+		slack.InteractionTypeBlockActions: acknowledge(client, jobmanager),
+	})
+}
+
+func acknowledge(client *slack.Client, jobmanager manager.JobManager) interactions.Handler {
+	return interactions.HandlerFunc(Identifier, func(callback *slack.InteractionCallback, logger *logrus.Entry) ([]byte, error) {
+		msg, err := jobmanager.AcknowledgeTerms(callback.User.ID)
+		if err != nil {
+			msg = err.Error()
+		}
+		if _, _, err := client.PostMessage(callback.Channel.ID, slack.MsgOptionText(fmt.Sprintf("<@%s> %s", callback.User.ID, msg), false)); err != nil {
+			logger.WithError(err).Warn("Failed to post the onboarding acknowledgment response.")
+		}
+		return nil, nil
+	})
+}
+
+// Post sends the onboarding notice with the accept button to the channel.
+func Post(client *slack.Client, channel, user string) error {
+	welcome := fmt.Sprintf("<@%s> welcome! Before I launch anything for you, please read the ground rules:", user)
+	rules := "• clusters are for short-lived testing and self-destruct after a few hours; run `done` the moment you are finished\n" +
+		"• you get one cluster at a time and platform capacity is shared, so launches may queue behind other users\n" +
+		"• every cluster costs real cloud money billed against your group's monthly budget - see `cost report`\n" +
+		"• never store anything on a test cluster that you cannot afford to lose"
+	blocks := []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, welcome, false, false), nil, nil),
+		slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, rules, false, false), nil, nil),
+		slack.NewActionBlock("onboarding-actions",
+			slack.NewButtonBlockElement(Identifier, "acknowledge", slack.NewTextBlockObject(slack.PlainTextType, "I agree", false, false)),
+		),
+	}
+	_, _, err := client.PostMessage(channel, slack.MsgOptionText(welcome, false), slack.MsgOptionBlocks(blocks...))
+	return err
+}
@@ -18,11 +18,15 @@ type Token struct {
 	Type int
 }
 
+// CommandHandler is the signature shared by command handlers and the
+// middleware that wraps them.
+type CommandHandler func(client *slack.Client, manager manager.JobManager, event *slackevents.MessageEvent, properties *Properties) string
+
 // CommandDefinition structure contains definition of the bot command
 type CommandDefinition struct {
 	Description string
 	Example     string
-	Handler     func(client *slack.Client, manager manager.JobManager, event *slackevents.MessageEvent, properties *Properties) string
+	Handler     CommandHandler
 }
 
 // BotCommand interface
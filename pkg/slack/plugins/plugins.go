@@ -0,0 +1,91 @@
+// Package plugins holds a compile-time registry that lets downstream forks
+// add organization-specific commands (e.g. internal lab provisioning) without
+// modifying the core dispatch code. A fork implements Plugin in its own
+// package, calls Register from an init function, and links the package in
+// with a blank import from its main package; the registered commands are then
+// parsed and dispatched exactly like built-in ones.
+package plugins
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/openshift/ci-chat-bot/pkg/slack/parser"
+	"github.com/slack-go/slack"
+)
+
+// Plugin contributes commands to the bot.
+type Plugin interface {
+	// Name identifies the plugin in logs and duplicate-registration errors.
+	Name() string
+	// Commands returns the commands the plugin adds to the bot. Handlers
+	// receive the shared manager.JobManager, so plugins participate in the
+	// manager lifecycle the same way built-in commands do.
+	Commands() []parser.BotCommand
+}
+
+var (
+	lock       sync.Mutex
+	registry   []Plugin
+	names      = make(map[string]struct{})
+	adminCheck func(client *slack.Client, user string) bool
+)
+
+// Register adds a plugin to the registry. It is intended to be called from an
+// init function and panics on a duplicate name so a misconfigured fork fails
+// at startup instead of silently shadowing commands.
+func Register(p Plugin) {
+	lock.Lock()
+	defer lock.Unlock()
+	if _, ok := names[p.Name()]; ok {
+		panic(fmt.Sprintf("command plugin %q registered twice", p.Name()))
+	}
+	names[p.Name()] = struct{}{}
+	registry = append(registry, p)
+}
+
+// Commands returns the commands of every registered plugin, in registration
+// order so help output is stable.
+func Commands() []parser.BotCommand {
+	lock.Lock()
+	defer lock.Unlock()
+	var commands []parser.BotCommand
+	for _, p := range registry {
+		commands = append(commands, p.Commands()...)
+	}
+	return commands
+}
+
+// Names returns the registered plugin names, sorted.
+func Names() []string {
+	lock.Lock()
+	defer lock.Unlock()
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// SetAdminCheck installs the admin channel membership check. The slack
+// package calls this at startup so plugin handlers can gate commands on it
+// without an import cycle.
+func SetAdminCheck(fn func(client *slack.Client, user string) bool) {
+	lock.Lock()
+	defer lock.Unlock()
+	adminCheck = fn
+}
+
+// IsAdmin reports whether the user may run admin-gated plugin commands. It
+// returns false until the bot has installed the check.
+func IsAdmin(client *slack.Client, user string) bool {
+	lock.Lock()
+	fn := adminCheck
+	lock.Unlock()
+	if fn == nil {
+		return false
+	}
+	return fn(client, user)
+}
@@ -0,0 +1,47 @@
+package plugins
+
+import (
+	"testing"
+
+	"github.com/openshift/ci-chat-bot/pkg/slack/parser"
+	"github.com/slack-go/slack"
+)
+
+type fakePlugin struct {
+	name     string
+	commands []parser.BotCommand
+}
+
+func (p *fakePlugin) Name() string                  { return p.name }
+func (p *fakePlugin) Commands() []parser.BotCommand { return p.commands }
+
+func TestRegister(t *testing.T) {
+	first := parser.NewBotCommand("first <arg>", &parser.CommandDefinition{}, false)
+	second := parser.NewBotCommand("second", &parser.CommandDefinition{}, true)
+	Register(&fakePlugin{name: "one", commands: []parser.BotCommand{first}})
+	Register(&fakePlugin{name: "two", commands: []parser.BotCommand{second}})
+
+	if commands := Commands(); len(commands) != 2 {
+		t.Errorf("expected 2 commands, got %d", len(commands))
+	}
+	if names := Names(); len(names) != 2 || names[0] != "one" || names[1] != "two" {
+		t.Errorf("unexpected plugin names: %v", names)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected duplicate registration to panic")
+		}
+	}()
+	Register(&fakePlugin{name: "one"})
+}
+
+func TestIsAdminDefaultsClosed(t *testing.T) {
+	if IsAdmin(nil, "anyone") {
+		t.Errorf("expected IsAdmin to return false before a check is installed")
+	}
+	SetAdminCheck(func(_ *slack.Client, _ string) bool { return true })
+	if !IsAdmin(nil, "anyone") {
+		t.Errorf("expected IsAdmin to defer to the installed check")
+	}
+}
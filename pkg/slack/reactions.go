@@ -0,0 +1,45 @@
+package slack
+
+import (
+	"time"
+
+	"github.com/slack-go/slack"
+
+	"github.com/openshift/ci-chat-bot/pkg/manager"
+)
+
+// reactionExtension is how much an alarm-clock reaction pushes back the
+// cluster's expiry.
+const reactionExtension = 2 * time.Hour
+
+// HandleReaction maps an emoji reaction on a bot message to the command it
+// is a shortcut for and runs it as the reacting user, with the same
+// ownership checks as the typed command: a wastebasket runs `done`, which
+// prompts for `done confirm` rather than terminating outright, and an alarm
+// clock extends the reactor's own cluster, no matter whose message carried
+// the reaction. The returned reply is empty when the emoji is not a
+// shortcut.
+func HandleReaction(client *slack.Client, jobManager manager.JobManager, reaction, userID string) string {
+	owner := clusterOwner(client, jobManager, userID)
+	switch reaction {
+	case "wastebasket", "put_litter_in_its_place":
+		// a reaction is even easier to tap by accident than `done` is to
+		// type, so it gets the same confirmation prompt, never the teardown
+		if _, err := jobManager.GetLaunchJob(owner); err != nil {
+			return err.Error()
+		}
+		return doneConfirmPrompt
+	case "alarm_clock", "hourglass_flowing_sand":
+		job, err := jobManager.GetLaunchJob(owner)
+		if err != nil {
+			return err.Error()
+		}
+		msg, err := jobManager.ExtendJobExpiry(owner, job.Name, reactionExtension)
+		if err != nil {
+			return err.Error()
+		}
+		return msg
+	default:
+		return ""
+	}
+}
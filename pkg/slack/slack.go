@@ -3,20 +3,25 @@ package slack
 import (
 	"bytes"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"regexp"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	clustermgmtv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift/ci-chat-bot/pkg/manager"
+	"github.com/openshift/ci-chat-bot/pkg/slack/modals/expiry"
 	"github.com/openshift/ci-chat-bot/pkg/slack/parser"
+	"github.com/openshift/ci-chat-bot/pkg/slack/plugins"
 	"github.com/openshift/ci-chat-bot/pkg/utils"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/slack-go/slack"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog"
 	clusterv1 "open-cluster-management.io/api/cluster/v1"
 	prowapiv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
@@ -27,6 +32,7 @@ type Bot struct {
 	BotSigningSecret string
 	GracePeriod      time.Duration
 	Port             int
+	AdminChannel     string
 	userID           string
 }
 
@@ -103,26 +109,246 @@ func (b *Bot) MceResponder(s *slack.Client) func(*clusterv1.ManagedCluster, *hiv
 	}
 }
 
-func NewBot(botToken, botSigningSecret string, graceperiod time.Duration, port int, workflowConfig *manager.WorkflowConfig) *Bot {
-	return &Bot{
+func (b *Bot) ReaperResponder(s *slack.Client) func([]string) {
+	return func(reclaimed []string) {
+		if len(b.AdminChannel) == 0 {
+			klog.Infof("no admin channel configured, not reporting %d reclaimed resource(s)", len(reclaimed))
+			return
+		}
+		message := renderMessage("reaper", reaperMessageData{Count: len(reclaimed), Resources: strings.Join(reclaimed, "\n• ")})
+		_, _, err := s.PostMessage(b.AdminChannel, slack.MsgOptionText(message, false))
+		if err != nil {
+			klog.Warningf("Failed to post reaper report to admin channel: %v", err)
+		}
+	}
+}
+
+// VersionWatchResponder notifies a user that a release stream they are
+// watching gained a new accepted payload.
+func (b *Bot) VersionWatchResponder(s *slack.Client, jobManager manager.JobManager) func(user, channel, spec, version string) {
+	return func(user, channel, spec, version string) {
+		message := renderMessage("version-watch", versionWatchMessageData{User: user, Spec: spec, Version: version})
+		if digestMode(jobManager, user) {
+			enqueueDigest(user, channel, message)
+			return
+		}
+		_, _, err := s.PostMessage(channel, slack.MsgOptionText(message, false))
+		if err != nil {
+			klog.Warningf("Failed to post version watch notification: %v", err)
+		}
+	}
+}
+
+// adminChannelID is recorded at startup so command handlers can authorize
+// admin-only commands by membership in the admin channel.
+var adminChannelID string
+
+// IsBotAdmin reports whether the user is a member of the configured admin
+// channel. When no admin channel is configured, nobody is an admin.
+func IsBotAdmin(client *slack.Client, userID string) bool {
+	if len(adminChannelID) == 0 {
+		return false
+	}
+	return IsChannelMember(client, adminChannelID, userID)
+}
+
+// IsChannelMember reports whether the user is a member of the channel.
+func IsChannelMember(client *slack.Client, channelID, userID string) bool {
+	params := &slack.GetUsersInConversationParameters{ChannelID: channelID, Limit: 200}
+	for {
+		members, cursor, err := client.GetUsersInConversation(params)
+		if err != nil {
+			klog.Warningf("Failed to list members of channel %s: %v", channelID, err)
+			return false
+		}
+		for _, member := range members {
+			if member == userID {
+				return true
+			}
+		}
+		if len(cursor) == 0 {
+			return false
+		}
+		params.Cursor = cursor
+	}
+}
+
+func (b *Bot) TeardownResponder(s *slack.Client, jobManager manager.JobManager) func(user, channel, jobName string, duration time.Duration) {
+	return func(user, channel, jobName string, duration time.Duration) {
+		if len(channel) == 0 {
+			return
+		}
+		message := renderMessage("destroyed", destroyedMessageData{Name: jobName, Minutes: int(duration / time.Minute)})
+		if len(user) > 0 && !manager.IsSpokeOwner(user) {
+			if digestMode(jobManager, user) {
+				enqueueDigest(user, channel, message)
+				return
+			}
+			if _, isTeam := manager.TeamChannel(user); !isTeam {
+				message = fmt.Sprintf("<@%s> %s", user, message)
+			}
+		}
+		if _, _, err := s.PostMessage(channel, slack.MsgOptionText(message, false)); err != nil {
+			klog.Warningf("Failed to post teardown notification to channel %s: %v", channel, err)
+		}
+	}
+}
+
+// ExpiryResponder warns a cluster's owner that the expiry grace window has
+// opened, offering the keep-artifacts and extend actions as buttons; the
+// presses are handled in pkg/slack/modals/expiry.
+func (b *Bot) ExpiryResponder(s *slack.Client) func(job manager.Job, deadline time.Time) {
+	return func(job manager.Job, deadline time.Time) {
+		if len(job.RequestedChannel) == 0 {
+			return
+		}
+		message := fmt.Sprintf("your cluster `%s` has expired and will be destroyed at %s - keep its artifacts or extend it below", job.Name, deadline.Format(time.Kitchen))
+		if len(job.RequestedBy) > 0 && !manager.IsSpokeOwner(job.RequestedBy) {
+			if _, isTeam := manager.TeamChannel(job.RequestedBy); !isTeam {
+				message = fmt.Sprintf("<@%s> %s", job.RequestedBy, message)
+			}
+		}
+		blocks := []slack.Block{
+			slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, message, false, false), nil, nil),
+			slack.NewActionBlock("expiry-actions",
+				slack.NewButtonBlockElement(expiry.KeepArtifactsIdentifier, job.Name, slack.NewTextBlockObject(slack.PlainTextType, "Keep artifacts", false, false)),
+				slack.NewButtonBlockElement(expiry.ExtendIdentifier, job.Name, slack.NewTextBlockObject(slack.PlainTextType, "Extend 2 hours", false, false)),
+			),
+		}
+		if _, _, err := s.PostMessage(job.RequestedChannel, slack.MsgOptionText(message, false), slack.MsgOptionBlocks(blocks...)); err != nil {
+			klog.Warningf("Failed to post expiry notification to channel %s: %v", job.RequestedChannel, err)
+		}
+	}
+}
+
+func (b *Bot) QueueResponder(s *slack.Client, jobManager manager.JobManager) func(user, channel, message string) {
+	return func(user, channel, message string) {
+		if len(channel) == 0 {
+			return
+		}
+		if len(user) > 0 && digestMode(jobManager, user) {
+			enqueueDigest(user, channel, message)
+			return
+		}
+		if len(user) > 0 {
+			if _, isTeam := manager.TeamChannel(user); !isTeam {
+				message = fmt.Sprintf("<@%s> %s", user, message)
+			}
+		}
+		if _, _, err := s.PostMessage(channel, slack.MsgOptionText(message, false)); err != nil {
+			klog.Warningf("Failed to post queue notification to channel %s: %v", channel, err)
+		}
+	}
+}
+
+// ChaosResponder posts a chaos scenario's recovery result back to the
+// requester; results are always delivered immediately since the user is
+// waiting on them.
+func (b *Bot) ChaosResponder(s *slack.Client) func(user, channel, message string) {
+	return func(user, channel, message string) {
+		if len(channel) == 0 {
+			return
+		}
+		if len(user) > 0 {
+			if _, isTeam := manager.TeamChannel(user); !isTeam {
+				message = fmt.Sprintf("<@%s> %s", user, message)
+			}
+		}
+		if _, _, err := s.PostMessage(channel, slack.MsgOptionText(message, false)); err != nil {
+			klog.Warningf("Failed to post chaos notification to channel %s: %v", channel, err)
+		}
+	}
+}
+
+// ConformanceResponder posts a conformance run's outcome back to the
+// requester; like chaos results, outcomes are always delivered immediately.
+func (b *Bot) ConformanceResponder(s *slack.Client) func(user, channel, message string) {
+	return func(user, channel, message string) {
+		if len(channel) == 0 {
+			return
+		}
+		if len(user) > 0 {
+			if _, isTeam := manager.TeamChannel(user); !isTeam {
+				message = fmt.Sprintf("<@%s> %s", user, message)
+			}
+		}
+		if _, _, err := s.PostMessage(channel, slack.MsgOptionText(message, false)); err != nil {
+			klog.Warningf("Failed to post conformance notification to channel %s: %v", channel, err)
+		}
+	}
+}
+
+// BackupResponder posts an etcd backup's outcome and download link back to
+// the requester; like chaos results, outcomes are always delivered
+// immediately.
+func (b *Bot) BackupResponder(s *slack.Client) func(user, channel, message string) {
+	return func(user, channel, message string) {
+		if len(channel) == 0 {
+			return
+		}
+		if len(user) > 0 {
+			if _, isTeam := manager.TeamChannel(user); !isTeam {
+				message = fmt.Sprintf("<@%s> %s", user, message)
+			}
+		}
+		if _, _, err := s.PostMessage(channel, slack.MsgOptionText(message, false)); err != nil {
+			klog.Warningf("Failed to post backup notification to channel %s: %v", channel, err)
+		}
+	}
+}
+
+func (b *Bot) AdminResponder(s *slack.Client) func(string) {
+	return func(message string) {
+		if len(b.AdminChannel) == 0 {
+			klog.Infof("no admin channel configured, dropping admin notification: %s", message)
+			return
+		}
+		_, _, err := s.PostMessage(b.AdminChannel, slack.MsgOptionText(message, false))
+		if err != nil {
+			klog.Warningf("Failed to post admin notification: %v", err)
+		}
+	}
+}
+
+func NewBot(botToken, botSigningSecret string, graceperiod time.Duration, port int, workflowConfig *manager.WorkflowConfig, adminChannel string) *Bot {
+	bot := &Bot{
 		BotToken:         botToken,
 		BotSigningSecret: botSigningSecret,
 		GracePeriod:      graceperiod,
 		Port:             port,
+		AdminChannel:     adminChannel,
 		userID:           "unknown",
 	}
+	adminChannelID = adminChannel
+	plugins.SetAdminCheck(IsBotAdmin)
+	if names := plugins.Names(); len(names) > 0 {
+		klog.Infof("Loaded command plugins: %s", strings.Join(names, ", "))
+	}
+	return bot
 }
 
 func (b *Bot) SupportedCommands() []parser.BotCommand {
-	return []parser.BotCommand{
+	commands := []parser.BotCommand{
+		// registered before the generic launch so `microshift` is not
+		// parsed as a version
+		parser.NewBotCommand("launch microshift <version> <options>", &parser.CommandDefinition{
+			Description: "Launch a VM running MicroShift at the given OpenShift version and deliver its kubeconfig. The version accepts the same forms as `launch` and defaults to the newest nightly; options are passed to the MicroShift workflow as `workflow-launch` parameters.",
+			Example:     "launch microshift 4.18",
+			Handler:     LaunchMicroShift,
+		}, false),
 		parser.NewBotCommand("launch <image_or_version_or_prs> <options>", &parser.CommandDefinition{
-			Description: fmt.Sprintf("Launch an OpenShift cluster using a known image, version, or PR(s). You may omit both arguments. Arguments can be specified as any number of comma-delimited values. Use `nightly` for the latest OCP build, `ci` for the the latest CI build, provide a version directly from any listed on https://amd64.ocp.releases.ci.openshift.org, a stream name (4.18.0-0.ci, 4.18.0-0.nightly, etc), a major/minor `X.Y` to load the \"next stable\" version, from nightly, for that version (`4.18`), `<org>/<repo>#<pr>` to launch from any combination of PRs, or an image for the first argument. Options is a comma-delimited list of variations including platform (%s), architecture (%s), and variant (%s).",
+			Description: fmt.Sprintf("Launch an OpenShift cluster using a known image, version, or PR(s). You may omit both arguments. Arguments can be specified as any number of comma-delimited values. Use `nightly` for the latest OCP build, `ci` for the the latest CI build, provide a version directly from any listed on https://amd64.ocp.releases.ci.openshift.org, a stream name (4.18.0-0.ci, 4.18.0-0.nightly, etc), a major/minor `X.Y` to load the \"next stable\" version, from nightly, for that version (`4.18`), `X.Y-accepted` or `accepted` for the most recent nightly that passed all blocking jobs, `okd` or `okd-X.Y` for the newest OKD build of a release (using OKD-compatible install workflows), `<org>/<repo>#<pr>` to launch from any combination of PRs, or an image for the first argument. Options is a comma-delimited list of variations including platform (%s), architecture (%s), and variant (%s). You may also pass `preset=<name>` to expand a named options preset defined by the bot administrators, or `team=#channel` to launch a cluster owned by a channel you are a member of: any member may then run `auth`, `refresh`, and `done`, expiry notices go to the channel, and the cluster does not count against your personal quota. To enable gated features at install time, pass `featureset=TechPreviewNoUpgrade` or `featuregates=GateA;GateB` (semicolon-separated, `=true` assumed). Pass `capabilities=None+marketplace;console` to install with a trimmed capability set: the part before `+` is the baselineCapabilitySet and the rest are additionalEnabledCapabilities (semicolon-separated). Pass `encryption=aescbc` (or `aesgcm`) to enable etcd encryption at rest, and `audit-profile=WriteRequestBodies` (or `AllRequestBodies`, `None`) to select the API server audit profile. Pass `network=OVNKubernetes` (or another supported plugin) to select the cluster network type, or `ipv6`/`dual-stack` on `metal` to install single-stack IPv6 or dual-stack clusters. On `metal`, pass `assisted` to use the assisted-installer workflows, or `devscripts=KEY=value;KEY=value` to append entries to the dev-scripts config. Pass `idp=htpasswd` or `idp=<https issuer URL>` to configure an identity provider so your credentials are a regular user rather than kubeadmin. Pass `tag=perf;ovn` to attach labels that `list` shows and can filter by. To customize the generated install-config (custom CIDRs, etcd encryption, capability trimming), attach an `install-config.yaml` patch file or paste the patch as a code block in the launch message: a YAML mapping is merged into the config, a list of `{op, path, value}` entries is applied as a JSON6902 patch, and fields are validated against the requested version before anything launches. Pass `same-cluster-as=<job name>` to run your launch on the same build cluster as an existing job (named in `list`) so the two clusters can reach each other's services. Pass `spokes=<1-3>` to launch a hub cluster plus that many spoke clusters in one request: the group shares a build cluster and expiry, and `done` tears it all down. When the platform is out of capacity and your request is queued, `priority=batch` lets every other queued request go first and `priority=release-blocker` (admins only) claims freed capacity ahead of normal requests. Pass `gpu=<count>x<model>` (e.g. `gpu=1xA10G`) to add a GPU machine pool with the NVIDIA enablement operators once the cluster is up, or `windows` (on `azure`/`gcp`) to enable hybrid networking, the Windows Machine Config Operator, and a Windows machine set for Windows-container testing.",
 				strings.Join(CodeSlice(manager.SupportedPlatforms), ", "),
 				strings.Join(CodeSlice(manager.SupportedArchitectures), ", "),
 				strings.Join(CodeSlice(manager.SupportedParameters), ", ")),
 			Example: "launch 4.18,openshift/installer#7160,openshift/machine-config-operator#3688 gcp,techpreview",
 			Handler: LaunchCluster,
 		}, false),
+		parser.NewBotCommand("explain <image_or_version_or_prs> <options>", &parser.CommandDefinition{
+			Description: "Describe in plain language what a `launch` with the same arguments would do - which prow job, platform, build cluster, and estimated duration the launch string maps to - without starting anything.",
+			Example:     "explain 4.18 gcp,techpreview",
+			Handler:     Explain,
+		}, false),
 		parser.NewBotCommand("rosa create <version> <duration>", &parser.CommandDefinition{
 			Description: "Launch an cluster in ROSA. Only GA Openshift versions are supported at the moment.",
 			Example:     "rosa create 4.18 3h",
@@ -133,6 +359,14 @@ func (b *Bot) SupportedCommands() []parser.BotCommand {
 			Example:     "rosa lookup 4.18",
 			Handler:     RosaLookup,
 		}, false),
+		parser.NewBotCommand("rosa quota", &parser.CommandDefinition{
+			Description: "Show how much of the shared ROSA account capacity is currently in use.",
+			Handler:     RosaQuota,
+		}, false),
+		parser.NewBotCommand("rosa whoami", &parser.CommandDefinition{
+			Description: "Show the OCM account and AWS identity the bot provisions ROSA clusters with.",
+			Handler:     RosaWhoami,
+		}, false),
 		parser.NewBotCommand("rosa describe <cluster>", &parser.CommandDefinition{
 			Description: "Display the details of the specified ROSA cluster.",
 			Example:     "rosa describe s9h9g-9b6nj-x94",
@@ -142,10 +376,111 @@ func (b *Bot) SupportedCommands() []parser.BotCommand {
 			Description: "See who is hogging all the clusters.",
 			Handler:     List,
 		}, false),
+		parser.NewBotCommand("done confirm", &parser.CommandDefinition{
+			Description: "Confirm termination of your running cluster.",
+			Handler:     DoneConfirm,
+		}, false),
+		parser.NewBotCommand("done all", &parser.CommandDefinition{
+			Description: "Terminate every cluster you have running, including ROSA and MCE clusters.",
+			Handler:     DoneAll,
+		}, false),
+		parser.NewBotCommand("done <target>", &parser.CommandDefinition{
+			Description: "(admins only) Terminate another user's cluster by @mention or job name. Restricted to members of the admin channel.",
+			Example:     "done @someone",
+			Handler:     DoneFor,
+		}, true),
 		parser.NewBotCommand("done", &parser.CommandDefinition{
-			Description: "Terminate the running cluster",
+			Description: "Terminate the running cluster. Asks for confirmation before anything is destroyed.",
 			Handler:     Done,
 		}, false),
+		parser.NewBotCommand("extend <name_or_group> <duration>", &parser.CommandDefinition{
+			Description: "Extend the expiry of one of your clusters by the given duration (up to 12h). Passing a group ID (the hub job name shown by `list`) extends every cluster in the group together.",
+			Example:     "extend chat-bot-2024-01-02-030405.6789 2h",
+			Handler:     Extend,
+		}, false),
+		parser.NewBotCommand("admin list-all", &parser.CommandDefinition{
+			Description: "(admins only) List every cluster the bot manages with owner, inputs, and expiry.",
+			Handler:     AdminListAll,
+		}, true),
+		parser.NewBotCommand("admin done-platform <platform>", &parser.CommandDefinition{
+			Description: "(admins only) Tear down every running cluster on a platform, e.g. during a cloud provider outage.",
+			Example:     "admin done-platform vsphere",
+			Handler:     AdminDonePlatform,
+		}, true),
+		parser.NewBotCommand("admin extend-all <duration>", &parser.CommandDefinition{
+			Description: "(admins only) Push back the expiry of every running cluster by a duration of up to 24h.",
+			Example:     "admin extend-all 2h",
+			Handler:     AdminExtendAll,
+		}, true),
+		parser.NewBotCommand("admin deprecation-report", &parser.CommandDefinition{
+			Description: "(admins only) Show how often each deprecated version has been requested since the bot started, to judge when a stream can be dropped.",
+			Handler:     AdminDeprecationReport,
+		}, true),
+		parser.NewBotCommand("admin message-owners <text>", &parser.CommandDefinition{
+			Description: "(admins only) Send a message to the owner of every running cluster in the channel they launched from.",
+			Example:     "admin message-owners AWS clusters will be recycled at 17:00 UTC",
+			Handler:     AdminMessageOwners,
+		}, true),
+		parser.NewBotCommand("broadcast <message>", &parser.CommandDefinition{
+			Description: "(admins only) Notify every user with an active or starting cluster. The message may start with `platform=` and `version=` tokens to narrow the audience.",
+			Example:     "broadcast platform=vsphere vSphere maintenance at 17:00 UTC, clusters will be torn down",
+			Handler:     Broadcast,
+		}, true),
+		parser.NewBotCommand("image info <image_or_version> <component>", &parser.CommandDefinition{
+			Description: "Show the source repository, commit, and pull requests one component image of a release payload was built from, resolved from the image's labels.",
+			Example:     "image info 4.18.0-0.nightly-2026-08-29-120000 installer",
+			Handler:     ImageInfo,
+		}, false),
+		parser.NewBotCommand("inspect <image_or_version> <components>", &parser.CommandDefinition{
+			Description: "Show which commits selected component images of a release payload were built from, so you can confirm a PR's commit made it into the payload before launching it. Components is an optional comma-delimited list of payload tag names.",
+			Example:     "inspect 4.18.0-0.nightly-2026-08-29-120000 installer,machine-config-operator",
+			Handler:     Inspect,
+		}, false),
+		parser.NewBotCommand("cost team", &parser.CommandDefinition{
+			Description: "Show how much of your group's monthly cluster cost budget has been consumed.",
+			Handler:     CostTeam,
+		}, false),
+		parser.NewBotCommand("exempt <reason>", &parser.CommandDefinition{
+			Description: "Exempt your running cluster from tonight's scheduled automatic teardown. A reason is required and is reported to the bot administrators.",
+			Example:     "exempt running an overnight scale test for TRT",
+			Handler:     Exempt,
+		}, false),
+		parser.NewBotCommand("versions", &parser.CommandDefinition{
+			Description: "List the minor versions that can currently be launched per architecture, from release controller state.",
+			Handler:     Versions,
+		}, false),
+		parser.NewBotCommand("payload-status <version>", &parser.CommandDefinition{
+			Description: "Summarize the release-gating job results for a payload from the release controller, rendered as pass/fail with links.",
+			Example:     "payload-status 4.17.0-0.nightly-2024-06-01-123456",
+			Handler:     PayloadStatus,
+		}, false),
+		parser.NewBotCommand("watch <version>", &parser.CommandDefinition{
+			Description: "Get notified when a new accepted payload appears for a release stream. The stream defaults to nightly.",
+			Example:     "watch 4.17-nightly",
+			Handler:     Watch,
+		}, false),
+		parser.NewBotCommand("unwatch <version>", &parser.CommandDefinition{
+			Description: "Stop watching a release stream.",
+			Example:     "unwatch 4.17-nightly",
+			Handler:     Unwatch,
+		}, false),
+		parser.NewBotCommand("history", &parser.CommandDefinition{
+			Description: "List your recent launches with versions, platforms, outcomes, and artifact links.",
+			Handler:     History,
+		}, false),
+		parser.NewBotCommand("relaunch <job_id>", &parser.CommandDefinition{
+			Description: "Re-create a cluster identical to one that was recently destroyed. Metadata of destroyed clusters is kept for a week; find the job name in the teardown notification or `list` output.",
+			Example:     "relaunch chat-bot-2026-08-01-abcdef.0",
+			Handler:     Relaunch,
+		}, false),
+		parser.NewBotCommand("snapshot list", &parser.CommandDefinition{
+			Description: "List your saved cluster configuration snapshots.",
+			Handler:     SnapshotList,
+		}, false),
+		parser.NewBotCommand("snapshot", &parser.CommandDefinition{
+			Description: "Capture the significant configuration of your running cluster - resolved release versions, platform, architecture, and launch parameters including an attached install-config patch - into a reusable bundle. Recreate an equivalent cluster later with `launch from-snapshot=<id>`; snapshots are kept for 90 days.",
+			Handler:     Snapshot,
+		}, false),
 		parser.NewBotCommand("refresh", &parser.CommandDefinition{
 			Description: "If the cluster is currently marked as failed, retry fetching its credentials in case of an error.",
 			Handler:     Refresh,
@@ -154,6 +489,15 @@ func (b *Bot) SupportedCommands() []parser.BotCommand {
 			Description: "Send the credentials for the cluster you most recently requested",
 			Handler:     Auth,
 		}, false),
+		parser.NewBotCommand("auth to <destination>", &parser.CommandDefinition{
+			Description: "Write the credentials for the cluster you most recently requested to a machine-readable location instead of Slack, for pipelines that consume bot-launched clusters. The destination is `secret:<name>` for a Kubernetes Secret in the namespace designated by the administrators, or `vault:<path>` for a Vault path under the allowed prefix.",
+			Example:     "auth to secret:my-pipeline-credentials",
+			Handler:     AuthTo,
+		}, false),
+		parser.NewBotCommand("auth token", &parser.CommandDefinition{
+			Description: "Mint a short-lived token bound to your identity on the cluster you most recently requested, as an alternative to the long-lived kubeadmin credentials. Requires the administrators to have configured an OIDC issuer.",
+			Handler:     AuthToken,
+		}, false),
 		parser.NewBotCommand("test upgrade <from> <to> <options>", &parser.CommandDefinition{
 			Description: fmt.Sprintf("Run the upgrade tests between two release images. The arguments may be a pull spec of a release image or tags from https://amd64.ocp.releases.ci.openshift.org. You may change the upgrade test by passing `test=NAME` in options with one of %s", strings.Join(CodeSlice(manager.SupportedUpgradeTests), ", ")),
 			Example:     "test upgrade 4.17 4.18 aws",
@@ -164,6 +508,54 @@ func (b *Bot) SupportedCommands() []parser.BotCommand {
 			Example:     "test e2e 4.18 vsphere",
 			Handler:     Test,
 		}, false),
+		parser.NewBotCommand("backup", &parser.CommandDefinition{
+			Description: "Take an etcd backup of your cluster and upload the snapshot to the artifact store, so state right before a risky operation is preserved. I will post the download link when it finishes.",
+			Handler:     EtcdBackup,
+		}, false),
+		parser.NewBotCommand("conformance <suite>", &parser.CommandDefinition{
+			Description: fmt.Sprintf("Run the conformance suite against your launched cluster - with the PRs, feature gates, and install tweaks it is already running - as a job on the build cluster, and report the pass/fail result here with a link to the full output and results tarball. The suite is one of %s and defaults to `openshift`.", strings.Join(CodeSlice(manager.SupportedConformanceSuites()), ", ")),
+			Example:     "conformance kubernetes",
+			Handler:     Conformance,
+		}, false),
+		parser.NewBotCommand("chaos <scenario>", &parser.CommandDefinition{
+			Description: fmt.Sprintf("Run a predefined disruption scenario against your launched cluster and report how long recovery took. Supported scenarios:\n%sOnly one scenario runs against a cluster at a time, and each one is recoverable by the platform without manual intervention.", manager.ChaosScenarioDescriptions()),
+			Example:     "chaos etcd-member-kill",
+			Handler:     Chaos,
+		}, false),
+		parser.NewBotCommand("time-skew <offset>", &parser.CommandDefinition{
+			Description: "Shift the clock on every node of your launched cluster by the given offset (e.g. `48h`, `-30m`, `370d`) using privileged debug pods, so time-dependent behavior can be tested without waiting. `time-skew reset` re-enables NTP so the clocks converge back to real time.",
+			Example:     "time-skew 48h",
+			Handler:     TimeSkew,
+		}, false),
+		parser.NewBotCommand("scale <assignment>", &parser.CommandDefinition{
+			Description: "Grow or shrink your cluster by redistributing the worker count across its worker machine sets, without crafting MachineSet manifests. New machines take several minutes to provision and join.",
+			Example:     "scale workers=5",
+			Handler:     ScaleWorkers,
+		}, false),
+		parser.NewBotCommand("add machinepool <assignment>", &parser.CommandDefinition{
+			Description: fmt.Sprintf("Add a machine pool of a particular instance type to your cluster by cloning one of its worker machine sets, or a GPU pool as `gpu=<count>x<model>` (models: %s) which also subscribes the NFD and NVIDIA GPU operators. Pools are left alone by `scale workers`. Supported on AWS, GCP, and Azure clusters; the same GPU spec can be passed at launch as `gpu=1xA10G`.", strings.Join(CodeSlice(manager.SupportedGpuModels()), ", ")),
+			Example:     "add machinepool type=m5.4xlarge",
+			Handler:     AddMachinePool,
+		}, false),
+		parser.NewBotCommand("upgrade abort", &parser.CommandDefinition{
+			Description: "Abort an in-flight in-place upgrade by rolling the cluster back to the last completed release, then report whether it returned to a healthy state. Best effort - rollbacks across minor versions are not supported by the platform.",
+			Handler:     UpgradeAbort,
+		}, false),
+		parser.NewBotCommand("upgrade to <version_or_pullspec> <options>", &parser.CommandDefinition{
+			Description: "Upgrade your running cluster in place by patching its ClusterVersion, then watch the rollout and report completion or degraded operators. Pass a version from the current update channel or a release pullspec; supported options are `channel=<name>` to switch the update channel first and `force` to skip release verification.",
+			Example:     "upgrade to 4.17.5 channel=stable-4.17",
+			Handler:     Upgrade,
+		}, false),
+		parser.NewBotCommand("cert-rotation", &parser.CommandDefinition{
+			Description: "Fast-forward your launched cluster's clock past the client certificate lifetime to force a full certificate rotation, and report whether the cluster recovers - the on-demand reproduction for cert-rotation bugs that otherwise need months of real time.",
+			Example:     "cert-rotation",
+			Handler:     CertRotation,
+		}, false),
+		parser.NewBotCommand("test-matrix <pullrequest> <platforms>", &parser.CommandDefinition{
+			Description: fmt.Sprintf("Run the e2e test suite once per requested platform using a payload built from the same pull request(s). Platforms is a comma-delimited list of platforms (%s), optionally prefixed with `platforms=`. One test job is started per platform against your quota as a single request, and each job reports its result back to this channel as it completes.", strings.Join(CodeSlice(manager.SupportedPlatforms), ", ")),
+			Example:     "test-matrix openshift/installer#7160 platforms=aws,gcp,azure",
+			Handler:     TestMatrix,
+		}, false),
 		parser.NewBotCommand("build <pullrequest>", &parser.CommandDefinition{
 			Description: "Create a new release image from one or more pull requests. The successful build location will be sent to you when it completes and then preserved for 12 hours.  To obtain a pull secret use `oc registry login --to /path/to/pull-secret` after using `oc login` to login to the relevant CI cluster.",
 			Example:     "build openshift/operator-framework-olm#68,operator-framework/operator-marketplace#396",
@@ -179,11 +571,21 @@ func (b *Bot) SupportedCommands() []parser.BotCommand {
 			Example:     "workflow-test openshift-e2e-gcp 4.18",
 			Handler:     WorkflowTest,
 		}, false),
+		parser.NewBotCommand("perfscale <profile> <version>", &parser.CommandDefinition{
+			Description: "Run a predefined performance/scale profile: the bot launches an appropriately sized cluster via the profile's workflow, drives its kube-burner or ingress-perf style workload, and reports the run result here with links to the Grafana dashboards and Elasticsearch index the workload publishes into. Run `perfscale` without arguments to list the profiles the bot administrators have configured. The version defaults to the profile's configured release, or the newest nightly.",
+			Example:     "perfscale node-density 4.18",
+			Handler:     PerfScale,
+		}, false),
 		parser.NewBotCommand("workflow-upgrade <name> <from_image_or_version_or_prs> <to_image_or_version_or_prs> <parameters>", &parser.CommandDefinition{
 			Description: "Run a custom upgrade using the requested workflow from an image or release or built PRs to a specified version/image/pr from https://amd64.ocp.releases.ci.openshift.org. ",
 			Example:     "workflow-upgrade openshift-upgrade-azure-ovn 4.17 4.18 azure",
 			Handler:     WorkflowUpgrade,
 		}, false),
+		parser.NewBotCommand("compare <jobA> <jobB>", &parser.CommandDefinition{
+			Description: "Diff the junit results of two completed bot-triggered test jobs, summarizing new failures, fixed tests, and flakes. This is useful for validating whether a PR fixes a regression. Job names are the prow job run names reported by the `list` command.",
+			Example:     "compare chat-bot-e2e-aws-1234 chat-bot-e2e-aws-5678",
+			Handler:     Compare,
+		}, false),
 		parser.NewBotCommand("version", &parser.CommandDefinition{
 			Description: "Report the version of the bot",
 			Handler:     Version,
@@ -198,6 +600,43 @@ func (b *Bot) SupportedCommands() []parser.BotCommand {
 			Example:     "catalog build openshift/aws-efs-csi-driver-operator#75 aws-efs-csi-driver-operator-bundle",
 			Handler:     CatalogBuild,
 		}, false),
+		parser.NewBotCommand("alias list", &parser.CommandDefinition{
+			Description: "List the personal command aliases you have defined.",
+			Handler:     AliasList,
+		}, false),
+		parser.NewBotCommand("alias rm <name>", &parser.CommandDefinition{
+			Description: "Remove one of your personal command aliases.",
+			Example:     "alias rm mydev",
+			Handler:     AliasRemove,
+		}, false),
+		parser.NewBotCommand("alias <name> = <expansion>", &parser.CommandDefinition{
+			Description: "Define a personal alias that expands to a full command before matching. Any extra arguments you pass when invoking the alias are appended to the expansion.",
+			Example:     "alias mydev = launch 4.16 aws,single-node,fips",
+			Handler:     AliasSet,
+		}, false),
+		parser.NewBotCommand("set ssh-key <key>", &parser.CommandDefinition{
+			Description: "Register your SSH public key with the bot. Future launches inject it into the install config so you can SSH to cluster nodes for debugging.",
+			Example:     "set ssh-key ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIB6... me@laptop",
+			Handler:     SSHKeySet,
+		}, false),
+		parser.NewBotCommand("unset ssh-key", &parser.CommandDefinition{
+			Description: "Remove your registered SSH public key so future launches no longer inject it.",
+			Handler:     SSHKeyRemove,
+		}, false),
+		parser.NewBotCommand("set <assignment>", &parser.CommandDefinition{
+			Description: "Set a personal default (`platform`, `architecture`, `lifetime`, `lang`, or `notifications`) applied when your launch arguments omit it. `notifications=digest` batches non-critical notices into a periodic digest; cluster ready, failure, and expiry notices stay immediate. See your current defaults with `prefs`.",
+			Example:     "set platform=gcp",
+			Handler:     PrefSet,
+		}, false),
+		parser.NewBotCommand("unset <name>", &parser.CommandDefinition{
+			Description: "Remove one of your personal defaults.",
+			Example:     "unset platform",
+			Handler:     PrefUnset,
+		}, false),
+		parser.NewBotCommand("prefs", &parser.CommandDefinition{
+			Description: "List the personal defaults you have set.",
+			Handler:     PrefList,
+		}, false),
 		parser.NewBotCommand("mce create <version> <duration> <platform>", &parser.CommandDefinition{
 			Description: "Create a new cluster using Hive and MCE.",
 			Example:     "mce create 4.16.7 6h aws",
@@ -223,6 +662,8 @@ func (b *Bot) SupportedCommands() []parser.BotCommand {
 			Handler:     MceImageSets,
 		}, true),
 	}
+	// commands contributed by plugins registered by downstream forks
+	return append(commands, plugins.Commands()...)
 }
 
 func GetUserName(client *slack.Client, userID string) string {
@@ -238,6 +679,38 @@ func GetUserName(client *slack.Client, userID string) string {
 	return ""
 }
 
+// userTimezones caches the timezone reported by Slack for each user so list
+// and notification rendering does not hit the API on every message
+var userTimezones = struct {
+	sync.Mutex
+	locations map[string]*time.Location
+}{locations: map[string]*time.Location{}}
+
+// GetUserTimezone returns the user's Slack-reported timezone, falling back to
+// UTC when it cannot be determined.
+func GetUserTimezone(client *slack.Client, userID string) *time.Location {
+	userTimezones.Lock()
+	location, ok := userTimezones.locations[userID]
+	userTimezones.Unlock()
+	if ok {
+		return location
+	}
+	user, err := client.GetUserInfo(userID)
+	if err != nil {
+		klog.Warningf("Failed to get the User Info for UserID: %s, %v", userID, err)
+		return time.UTC
+	}
+	location, err = time.LoadLocation(user.TZ)
+	if err != nil {
+		klog.Warningf("Failed to load timezone %q for UserID: %s, %v", user.TZ, userID, err)
+		location = time.UTC
+	}
+	userTimezones.Lock()
+	userTimezones.locations[userID] = location
+	userTimezones.Unlock()
+	return location
+}
+
 func VerifiedBody(request *http.Request, signingSecret string) ([]byte, bool) {
 	verifier, err := slack.NewSecretsVerifier(request.Header, signingSecret)
 	if err != nil {
@@ -267,6 +740,42 @@ func VerifiedBody(request *http.Request, signingSecret string) ([]byte, bool) {
 	return body, true
 }
 
+// ExpandLaunchPreset replaces a `preset=<name>` option with the admin-defined
+// options list of the same name from the bot's workflow config. Options the
+// user passes alongside the preset are preserved.
+func ExpandLaunchPreset(workflowConfig *manager.WorkflowConfig, options string) (string, error) {
+	params, err := utils.ParamsFromAnnotation(options)
+	if err != nil {
+		return "", fmt.Errorf("options could not be parsed: %w", err)
+	}
+	name, ok := params["preset"]
+	if !ok {
+		return options, nil
+	}
+	workflowConfig.Mutex.RLock()
+	defer workflowConfig.Mutex.RUnlock()
+	preset, ok := workflowConfig.Presets[name]
+	if !ok {
+		if len(workflowConfig.Presets) == 0 {
+			return "", fmt.Errorf("no presets are currently defined by the bot administrators")
+		}
+		presets := make([]string, 0, len(workflowConfig.Presets))
+		for preset := range workflowConfig.Presets {
+			presets = append(presets, preset)
+		}
+		sort.Strings(presets)
+		return "", fmt.Errorf("preset %s is not defined, available presets: %s", name, strings.Join(CodeSlice(presets), ", "))
+	}
+	expanded := strings.Split(preset, ",")
+	for _, part := range strings.Split(options, ",") {
+		if part == "" || strings.HasPrefix(strings.TrimSpace(part), "preset=") {
+			continue
+		}
+		expanded = append(expanded, part)
+	}
+	return strings.Join(expanded, ","), nil
+}
+
 func GetPlatformArchFromWorkflowConfig(workflowConfig *manager.WorkflowConfig, name string) (string, string, error) {
 	platform := ""
 	architecture := "amd64"
@@ -278,6 +787,9 @@ func GetPlatformArchFromWorkflowConfig(workflowConfig *manager.WorkflowConfig, n
 			workflows = append(workflows, w)
 		}
 		sort.Strings(workflows)
+		if suggestions := utils.ClosestMatches(name, workflows, 3); len(suggestions) > 0 {
+			return "", "", fmt.Errorf("workflow %s not in workflow list ( https://github.com/openshift/release/blob/master/core-services/ci-chat-bot/workflows-config.yaml ). Did you mean %s? Please add %s to the workflows list before retrying this command, or use a workflow from: %s", name, strings.Join(CodeSlice(suggestions), ", "), name, strings.Join(workflows, ", "))
+		}
 		return "", "", fmt.Errorf("workflow %s not in workflow list ( https://github.com/openshift/release/blob/master/core-services/ci-chat-bot/workflows-config.yaml ). Please add %s to the workflows list before retrying this command, or use a workflow from: %s", name, name, strings.Join(workflows, ", "))
 	} else {
 		platform = workflow.Platform
@@ -333,6 +845,216 @@ func BuildJobParams(params string) (map[string]string, error) {
 	return jobParams, nil
 }
 
+// ResolveEnvPresetParams expands an `env-preset=<name>` job parameter into the
+// KEY=VALUE pairs published for that preset in the step registry. Parameters the
+// user passes explicitly take precedence over the preset contents.
+func ResolveEnvPresetParams(jobManager manager.JobManager, jobParams map[string]string) (map[string]string, error) {
+	name, ok := jobParams["env-preset"]
+	if !ok {
+		return jobParams, nil
+	}
+	preset, err := jobManager.ResolveEnvPreset(name)
+	if err != nil {
+		return nil, err
+	}
+	merged := make(map[string]string, len(preset)+len(jobParams))
+	for k, v := range preset {
+		merged[k] = v
+	}
+	for k, v := range jobParams {
+		if k == "env-preset" {
+			continue
+		}
+		merged[k] = v
+	}
+	return merged, nil
+}
+
+// ResolveSecretParams expands a `secret=<name>` job parameter into the
+// KEY=VALUE contents of the admin-registered secret of that name in the bot's
+// namespace. Parameters the user passes explicitly take precedence over the
+// secret contents.
+func ResolveSecretParams(jobManager manager.JobManager, jobParams map[string]string) (map[string]string, error) {
+	name, ok := jobParams["secret"]
+	if !ok {
+		return jobParams, nil
+	}
+	secret, err := jobManager.ResolveJobParamSecret(name)
+	if err != nil {
+		return nil, err
+	}
+	merged := make(map[string]string, len(secret)+len(jobParams))
+	for k, v := range secret {
+		merged[k] = v
+	}
+	for k, v := range jobParams {
+		if k == "secret" {
+			continue
+		}
+		merged[k] = v
+	}
+	return merged, nil
+}
+
+// PullSecretParam is the multistage parameter that the install steps read an
+// additional pull secret from when building the install config.
+const PullSecretParam = "ADDITIONAL_PULL_SECRET"
+
+// ResolvePullSecretParam expands a `pull-secret=<name>` option into the
+// ADDITIONAL_PULL_SECRET job parameter from the admin-registered secret of
+// that name, so launches can pull images from private or mirrored registries.
+func ResolvePullSecretParam(jobManager manager.JobManager, jobParams map[string]string) (map[string]string, error) {
+	name, ok := jobParams["pull-secret"]
+	if !ok {
+		return jobParams, nil
+	}
+	secret, err := jobManager.ResolveJobParamSecret(name)
+	if err != nil {
+		return nil, err
+	}
+	content, ok := secret[".dockerconfigjson"]
+	if !ok {
+		content, ok = secret["config.json"]
+	}
+	if !ok {
+		return nil, fmt.Errorf("secret `%s` does not contain a `.dockerconfigjson` or `config.json` key, so it cannot be used as a pull secret", name)
+	}
+	var config struct {
+		Auths map[string]interface{} `json:"auths"`
+	}
+	if err := json.Unmarshal([]byte(content), &config); err != nil || len(config.Auths) == 0 {
+		return nil, fmt.Errorf("secret `%s` is not a valid docker config with an `auths` entry", name)
+	}
+	merged := make(map[string]string, len(jobParams))
+	for k, v := range jobParams {
+		if k == "pull-secret" {
+			continue
+		}
+		merged[k] = v
+	}
+	merged[PullSecretParam] = content
+	return merged, nil
+}
+
+// ApplyDefaultLaunchOptions appends the user's preferred platform and
+// architecture to the launch options when the options do not already name one,
+// so `set platform=gcp` makes a bare `launch` behave like `launch gcp`.
+func ApplyDefaultLaunchOptions(jobManager manager.JobManager, user, options string) string {
+	preferences := jobManager.GetUserPreferences(user)
+	if len(preferences) == 0 {
+		return options
+	}
+	var hasPlatform, hasArchitecture bool
+	for _, opt := range strings.Split(options, ",") {
+		opt = strings.TrimSpace(opt)
+		if utils.Contains(manager.SupportedPlatforms, opt) {
+			hasPlatform = true
+		}
+		if utils.Contains(manager.SupportedArchitectures, opt) {
+			hasArchitecture = true
+		}
+	}
+	var defaults []string
+	if platform, ok := preferences["platform"]; ok && !hasPlatform {
+		defaults = append(defaults, platform)
+	}
+	if architecture, ok := preferences["architecture"]; ok && !hasArchitecture {
+		defaults = append(defaults, architecture)
+	}
+	if len(defaults) == 0 {
+		return options
+	}
+	if len(options) == 0 {
+		return strings.Join(defaults, ",")
+	}
+	return options + "," + strings.Join(defaults, ",")
+}
+
+// SSHKeyParam is the multistage parameter the install steps read an extra
+// authorized SSH public key from.
+const SSHKeyParam = "SSH_PUB_KEY"
+
+// InjectUserSSHKey adds the user's registered SSH public key to the job
+// parameters so the install config authorizes it on cluster nodes. A key the
+// user passes explicitly takes precedence over the registered one.
+func InjectUserSSHKey(jobManager manager.JobManager, user string, jobParams map[string]string) map[string]string {
+	if _, ok := jobParams[SSHKeyParam]; ok {
+		return jobParams
+	}
+	key, ok := jobManager.GetUserSSHKey(user)
+	if !ok {
+		return jobParams
+	}
+	if jobParams == nil {
+		jobParams = make(map[string]string)
+	}
+	jobParams[SSHKeyParam] = key
+	return jobParams
+}
+
+// applySnapshot merges a saved snapshot into a launch request when the user
+// passed `from-snapshot=<id>`. Anything typed explicitly - versions or PRs, a
+// platform or architecture token, or any option - wins over the snapshot's
+// captured value.
+func applySnapshot(jobManager manager.JobManager, user, options string, inputs [][]string, platform, architecture string, params map[string]string) ([][]string, string, string, map[string]string, error) {
+	id, ok := params[manager.FromSnapshotParam]
+	if !ok {
+		return inputs, platform, architecture, params, nil
+	}
+	delete(params, manager.FromSnapshotParam)
+	snapshot, err := jobManager.GetClusterSnapshot(user, strings.TrimSpace(id))
+	if err != nil {
+		return nil, "", "", nil, err
+	}
+	if len(inputs) == 0 {
+		inputs = snapshot.Inputs
+	}
+	// ParseOptions has already applied the platform default, so look at the
+	// raw options to tell whether the user actually asked for one before
+	// letting the snapshot's value through
+	explicitPlatform, explicitArchitecture := false, false
+	if raw, err := utils.ParamsFromAnnotation(options); err == nil {
+		for opt := range raw {
+			if utils.Contains(manager.SupportedPlatforms, opt) {
+				explicitPlatform = true
+			}
+			if utils.Contains(manager.SupportedArchitectures, opt) {
+				explicitArchitecture = true
+			}
+		}
+	}
+	if !explicitPlatform && len(snapshot.Platform) > 0 {
+		platform = snapshot.Platform
+	}
+	if !explicitArchitecture && len(snapshot.Architecture) > 0 {
+		architecture = snapshot.Architecture
+	}
+	for key, value := range snapshot.JobParams {
+		if _, ok := params[key]; !ok {
+			params[key] = value
+		}
+	}
+	return inputs, platform, architecture, params, nil
+}
+
+// checkPriorityParam validates the `priority` launch option and restricts the
+// release-blocker class to bot admins, so routine work cannot claim release
+// debugging capacity by accident. The empty return means the launch may
+// proceed.
+func checkPriorityParam(client *slack.Client, user string, jobParams map[string]string) string {
+	priority, ok := jobParams[manager.PriorityParam]
+	if !ok {
+		return ""
+	}
+	if err := manager.ValidatePriority(priority); err != nil {
+		return err.Error()
+	}
+	if priority == manager.PriorityReleaseBlocker && !IsBotAdmin(client, user) {
+		return "only members of the admin channel may launch with `priority=release-blocker`; ask an admin to launch it for you or drop the option"
+	}
+	return ""
+}
+
 const (
 	markdownLink = `^<(.*)\|(.*)>$`
 )
@@ -346,39 +1068,67 @@ func parseParameterValue(value string) string {
 	return value
 }
 
+// launchProgress renders how far along a launch is. When the manager has
+// history for this platform and version, elapsed time is shown against the
+// typical setup time so users can judge whether the install is on track.
+func launchProgress(job *manager.Job) string {
+	elapsed := time.Since(job.RequestedAt) / time.Minute
+	if job.TypicalDuration > 0 {
+		return fmt.Sprintf("%d/%d min typical for this platform and version", elapsed, job.TypicalDuration/time.Minute)
+	}
+	return fmt.Sprintf("launched %d minutes ago", elapsed)
+}
+
+// restartNote explains a delayed launch when the install pod was evicted or
+// restarted, so "still starting" updates carry a revised expectation instead
+// of the original estimate.
+func restartNote(job *manager.Job) string {
+	if job.PodRestarts == 0 {
+		return ""
+	}
+	return fmt.Sprintf("; the install pod was evicted or restarted %d time(s) and the install started over, so expect the cluster to take longer than usual", job.PodRestarts)
+}
+
 func NotifyJob(client *slack.Client, job *manager.Job) {
 	switch job.Mode {
 	case manager.JobTypeLaunch, manager.JobTypeWorkflowLaunch:
+		// spoke members of a hub/spoke group get compact messages, and no
+		// intermediate progress updates, so a group launch does not repeat
+		// the full boilerplate once per cluster
+		isSpoke := len(job.GroupID) > 0 && job.GroupID != job.Name
+		subject := "your cluster"
+		if isSpoke {
+			subject = fmt.Sprintf("spoke cluster `%s` in your group", job.Name)
+		}
 		switch {
-		case len(job.Failure) > 0 && len(job.URL) > 0:
-			message := fmt.Sprintf("your cluster failed to launch: %s (<%s|logs>)", job.Failure, job.URL)
-			_, _, err := client.PostMessage(job.RequestedChannel, slack.MsgOptionText(message, false))
-			if err != nil {
-				klog.Warningf("Failed to post the message: %s\nto the channel: %s.", message, job.RequestedChannel)
-			}
 		case len(job.Failure) > 0:
-			message := fmt.Sprintf("your cluster failed to launch: %s", job.Failure)
-			_, _, err := client.PostMessage(job.RequestedChannel, slack.MsgOptionText(message, false))
-			if err != nil {
-				klog.Warningf("Failed to post the message: %s\nto the channel: %s.", message, job.RequestedChannel)
-			}
-		case len(job.Credentials) == 0 && len(job.URL) > 0:
-			message := fmt.Sprintf("cluster is still starting (launched %d minutes ago, <%s|logs>)", time.Since(job.RequestedAt)/time.Minute, job.URL)
+			message := renderMessage("failed", launchMessageData{Name: job.Name, Subject: subject, Failure: job.Failure, URL: job.URL})
 			_, _, err := client.PostMessage(job.RequestedChannel, slack.MsgOptionText(message, false))
 			if err != nil {
 				klog.Warningf("Failed to post the message: %s\nto the channel: %s.", message, job.RequestedChannel)
 			}
 		case len(job.Credentials) == 0:
-			message := fmt.Sprintf("cluster is still starting (launched %d minutes ago)", time.Since(job.RequestedAt)/time.Minute)
+			if isSpoke {
+				return
+			}
+			message := renderMessage("starting", launchMessageData{Name: job.Name, Subject: subject, URL: job.URL, Progress: launchProgress(job), RestartNote: restartNote(job)})
 			_, _, err := client.PostMessage(job.RequestedChannel, slack.MsgOptionText(message, false))
 			if err != nil {
 				klog.Warningf("Failed to post the message: %s\nto the channel: %s.", message, job.RequestedChannel)
 			}
 		default:
-			comment := fmt.Sprintf(
-				"Your cluster is ready, it will be shut down automatically in ~%d minutes.",
-				time.Until(job.ExpiresAt)/time.Minute,
-			)
+			comment := renderMessage("ready", launchMessageData{Name: job.Name, Subject: subject, ExpiresInMinutes: int(time.Until(job.ExpiresAt) / time.Minute)})
+			if isSpoke {
+				comment = renderMessage("spoke-ready", launchMessageData{Name: job.Name, Subject: subject})
+			} else {
+				// the rich rendering goes out first; its plain text doubles as
+				// the fallback for clients without block support
+				if _, _, err := client.PostMessage(job.RequestedChannel, slack.MsgOptionText(comment, false), slack.MsgOptionBlocks(clusterReadyBlocks(job)...)); err != nil {
+					klog.Warningf("Failed to post the cluster-ready blocks to channel %s: %v", job.RequestedChannel, err)
+				} else {
+					comment = "Credentials for your cluster:"
+				}
+			}
 			if len(job.PasswordSnippet) > 0 {
 				comment += "\n" + job.PasswordSnippet
 			}
@@ -405,40 +1155,21 @@ func NotifyJob(client *slack.Client, job *manager.Job) {
 	}
 
 	if !incomplete {
-		if len(job.URL) > 0 {
-			if failure {
-				message := fmt.Sprintf("job <%s | %s> failed", job.URL, job.OriginalMessage)
-				_, _, err := client.PostMessage(job.RequestedChannel, slack.MsgOptionText(message, false))
-				if err != nil {
-					klog.Warningf("Failed to post the message: %s\nto the channel: %s.", message, job.RequestedChannel)
-				}
-				return
-			}
-			if success {
-				message := fmt.Sprintf("job <%s | %s> succeeded", job.URL, job.OriginalMessage)
-				_, _, err := client.PostMessage(job.RequestedChannel, slack.MsgOptionText(message, false))
-				if err != nil {
-					klog.Warningf("Failed to post the message: %s\nto the channel: %s.", message, job.RequestedChannel)
-				}
-				return
-			}
-		} else {
-			if failure {
-				message := fmt.Sprintf("job %s failed, but no details could be retrieved", job.OriginalMessage)
-				_, _, err := client.PostMessage(job.RequestedChannel, slack.MsgOptionText(message, false))
-				if err != nil {
-					klog.Warningf("Failed to post the message: %s\nto the channel: %s.", message, job.RequestedChannel)
-				}
-				return
+		if failure {
+			message := renderMessage("job-failed", jobMessageData{OriginalMessage: job.OriginalMessage, URL: job.URL})
+			_, _, err := client.PostMessage(job.RequestedChannel, slack.MsgOptionText(message, false))
+			if err != nil {
+				klog.Warningf("Failed to post the message: %s\nto the channel: %s.", message, job.RequestedChannel)
 			}
-			if success {
-				message := fmt.Sprintf("job %s succeded, but no details could be retrieved", job.OriginalMessage)
-				_, _, err := client.PostMessage(job.RequestedChannel, slack.MsgOptionText(message, false))
-				if err != nil {
-					klog.Warningf("Failed to post the message: %s\nto the channel: %s.", message, job.RequestedChannel)
-				}
-				return
+			return
+		}
+		if success {
+			message := renderMessage("job-succeeded", jobMessageData{OriginalMessage: job.OriginalMessage, URL: job.URL})
+			_, _, err := client.PostMessage(job.RequestedChannel, slack.MsgOptionText(message, false))
+			if err != nil {
+				klog.Warningf("Failed to post the message: %s\nto the channel: %s.", message, job.RequestedChannel)
 			}
+			return
 		}
 	}
 
@@ -538,9 +1269,41 @@ func ParseOptions(options string, inputs [][]string, jobType manager.JobType) (s
 			delete(params, opt)
 		case opt == "":
 			delete(params, opt)
+		case opt == "dual-stack":
+			// accepted as a spelling of the dualstack variant
+			params["dualstack"] = params[opt]
+			delete(params, opt)
+		case opt == manager.InstallConfigParam:
+			return "", "", nil, fmt.Errorf("the install-config patch does not fit in an option value; attach it as an `install-config.yaml` file or paste it as a code block in the launch message")
 		case utils.Contains(manager.SupportedParameters, opt):
 			// do nothing
+		case opt == "secret":
+			// expanded by ResolveSecretParams after parsing; not a job variant
+		case opt == "pull-secret":
+			// expanded by ResolvePullSecretParam after parsing; not a job variant
+		case opt == manager.ForceLaunchParam:
+			// consumed by the nightly payload health gate; not a job variant
+		case opt == manager.TeamParam:
+			// consumed by the launch handler to assign channel ownership; not a job variant
+		case opt == manager.CostOverrideParam:
+			// consumed by the cost budget gate; not a job variant
+		case opt == manager.SameClusterParam:
+			// consumed by the scheduler to co-locate with an existing job; not a job variant
+		case opt == manager.SpokesParam:
+			// consumed by the launch flow to provision a hub/spoke group; not a job variant
+		case opt == manager.PriorityParam:
+			// consumed by the quota queue for ordering; not a job variant
+		case opt == manager.FromSnapshotParam:
+			// consumed by the launch flow to replay a saved snapshot; not a job variant
+		case opt == manager.GpuParam:
+			// consumed by the bot to add a GPU machine pool after launch; not a job variant
+		case opt == manager.WindowsParam:
+			// consumed by the bot to set up Windows workers after launch; not a job variant
 		default:
+			candidates := append(append(append([]string{}, manager.SupportedPlatforms...), manager.SupportedArchitectures...), manager.SupportedParameters...)
+			if suggestions := utils.ClosestMatches(opt, candidates, 2); len(suggestions) > 0 {
+				return "", "", nil, fmt.Errorf("unrecognized option: %s (did you mean `%s`?)", opt, suggestions[0])
+			}
 			return "", "", nil, fmt.Errorf("unrecognized option: %s", opt)
 		}
 	}
@@ -588,6 +1351,16 @@ func ParseOptions(options string, inputs [][]string, jobType manager.JobType) (s
 	if architecture != "multi" && platform == "hypershift-hosted" {
 		return "", "", nil, fmt.Errorf("The hypershift-hosted platform requires a multiarch image. See: https://docs.ci.openshift.org/docs/architecture/ci-operator/#testing-with-a-cluster-from-hypershift")
 	}
+	for param, platforms := range manager.IPStackPlatforms {
+		if _, ok := params[param]; ok && !platforms.Has(platform) {
+			return "", "", nil, fmt.Errorf("`%s` is not supported on platform %s, supported platforms: %s", param, platform, strings.Join(sets.List(platforms), ", "))
+		}
+	}
+	for _, param := range []string{"assisted", manager.DevscriptsConfigParam} {
+		if _, ok := params[param]; ok && platform != "metal" {
+			return "", "", nil, fmt.Errorf("`%s` is only supported on the metal platform", param)
+		}
+	}
 	return platform, architecture, params, nil
 }
 
@@ -649,7 +1422,7 @@ func NotifyMce(client *slack.Client, cluster *clusterv1.ManagedCluster, clusterD
 			klog.Errorf("Failed to parse expiry time: %v", err)
 			message += "."
 		} else {
-			message += fmt.Sprintf(", it will be shut down automatically in ~%d minutes.", time.Until(parsedExpiryTime)/time.Minute)
+			message += fmt.Sprintf(", it will be shut down automatically in ~%d minutes (%s).", time.Until(parsedExpiryTime)/time.Minute, utils.LocalTimeString(parsedExpiryTime, GetUserTimezone(client, cluster.Annotations[utils.UserTag])))
 		}
 		requestTime := cluster.Annotations[utils.RequestTimeTag]
 		parsedRequestTime, err := time.Parse(time.RFC3339, string(requestTime))
@@ -694,7 +1467,7 @@ func NotifyRosa(client *slack.Client, cluster *clustermgmtv1.Cluster, password s
 			klog.Errorf("Failed to parse time: %v", err)
 			message += "."
 		} else {
-			message += fmt.Sprintf(", it will be shut down automatically in ~%d minutes.", time.Until(parsedExpiryTime)/time.Minute)
+			message += fmt.Sprintf(", it will be shut down automatically in ~%d minutes (%s).", time.Until(parsedExpiryTime)/time.Minute, utils.LocalTimeString(parsedExpiryTime, GetUserTimezone(client, cluster.AWS().Tags()[utils.UserTag])))
 		}
 		if console, ok := cluster.GetConsole(); ok {
 			message += "\n" + console.URL()
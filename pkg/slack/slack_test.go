@@ -3,6 +3,8 @@ package slack
 import (
 	"maps"
 	"testing"
+
+	"github.com/openshift/ci-chat-bot/pkg/manager"
 )
 
 func TestBuildJobParams(t *testing.T) {
@@ -87,6 +89,58 @@ func TestBuildJobParams(t *testing.T) {
 	}
 }
 
+func TestExpandLaunchPreset(t *testing.T) {
+	t.Parallel()
+	workflowConfig := &manager.WorkflowConfig{
+		Presets: map[string]string{
+			"perf-scale": "gcp,xlarge",
+		},
+	}
+	testCases := []struct {
+		name        string
+		options     string
+		expected    string
+		errorString string
+	}{
+		{
+			name:     "NoOptions",
+			options:  "",
+			expected: "",
+		},
+		{
+			name:     "NoPreset",
+			options:  "aws,fips",
+			expected: "aws,fips",
+		},
+		{
+			name:     "PresetOnly",
+			options:  "preset=perf-scale",
+			expected: "gcp,xlarge",
+		},
+		{
+			name:     "PresetWithExtraOptions",
+			options:  "preset=perf-scale,techpreview",
+			expected: "gcp,xlarge,techpreview",
+		},
+		{
+			name:        "UnknownPreset",
+			options:     "preset=unknown",
+			errorString: "preset unknown is not defined, available presets: `perf-scale`",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ExpandLaunchPreset(workflowConfig, tc.options)
+			if got != tc.expected {
+				t.Errorf("got %q, expected %q", got, tc.expected)
+			}
+			if err != nil && err.Error() != tc.errorString {
+				t.Errorf("got error %q, expected error %q", err, tc.errorString)
+			}
+		})
+	}
+}
+
 func TestParseParameterValue(t *testing.T) {
 	t.Parallel()
 	testCases := []struct {
@@ -119,3 +173,58 @@ func TestParseParameterValue(t *testing.T) {
 		})
 	}
 }
+
+func TestParseAttachedParamFile(t *testing.T) {
+	testCases := []struct {
+		name        string
+		fileName    string
+		data        string
+		expected    map[string]string
+		errorString string
+	}{
+		{
+			name:     "DotEnv",
+			fileName: "params.env",
+			data:     "# dev-scripts overrides\nNUM_WORKERS=3\nIP_STACK=\"v6\"\n",
+			expected: map[string]string{"NUM_WORKERS": "3", "IP_STACK": "v6"},
+		},
+		{
+			name:     "Yaml",
+			fileName: "params.yaml",
+			data:     "NUM_WORKERS: \"3\"\nIP_STACK: v6\n",
+			expected: map[string]string{"NUM_WORKERS": "3", "IP_STACK": "v6"},
+		},
+		{
+			name:        "MalformedDotEnvLine",
+			fileName:    "params.env",
+			data:        "NUM_WORKERS\n",
+			errorString: "attached file params.env: line 1 is not in the form of KEY=VALUE",
+		},
+		{
+			name:        "UnsupportedExtension",
+			fileName:    "params.txt",
+			data:        "NUM_WORKERS=3",
+			errorString: "attached file params.txt is not a supported parameter file; attach a `.env`, `.yaml`, or `.yml` file",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			params, err := parseAttachedParamFile(tc.fileName, []byte(tc.data))
+			if err != nil {
+				if tc.errorString == "" {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if err.Error() != tc.errorString {
+					t.Fatalf("got error %q, expected %q", err.Error(), tc.errorString)
+				}
+				return
+			}
+			if tc.errorString != "" {
+				t.Fatalf("expected error %q, got none", tc.errorString)
+			}
+			if !maps.Equal(params, tc.expected) {
+				t.Errorf("got %v, expected %v", params, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package slack
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+
+	"k8s.io/klog"
+	"sigs.k8s.io/yaml"
+)
+
+// Deployments of the bot outside the OpenShift CI organization want to adjust
+// the tone, links, and branding of what the bot posts without forking it.
+// Every notification the bot sends on its own initiative - cluster ready,
+// failed, still starting, destroyed, job results - is a Go template
+// (similar to SlackReporter.ReportTemplate in the prow config) that can be
+// overridden from a YAML file of name -> template text, validated at load
+// time against sample data so a typo fails startup rather than a
+// notification.
+
+// launchMessageData is the context for the ready, spoke-ready, failed, and
+// starting templates.
+type launchMessageData struct {
+	// Name is the cluster's job name.
+	Name string
+	// Subject is "your cluster" or names the spoke cluster in a group.
+	Subject string
+	// Failure is the error that stopped the launch (failed only).
+	Failure string
+	// URL links to the job logs; may be empty.
+	URL string
+	// Progress describes elapsed versus typical setup time (starting only).
+	Progress string
+	// RestartNote explains install pod restarts; usually empty (starting only).
+	RestartNote string
+	// ExpiresInMinutes is how long until the cluster is torn down (ready only).
+	ExpiresInMinutes int
+}
+
+// jobMessageData is the context for the job-succeeded and job-failed
+// templates.
+type jobMessageData struct {
+	// OriginalMessage is the command that started the job.
+	OriginalMessage string
+	// URL links to the job logs; may be empty.
+	URL string
+}
+
+// destroyedMessageData is the context for the destroyed template.
+type destroyedMessageData struct {
+	// Name is the cluster's job name.
+	Name string
+	// Minutes is how long the deprovision took.
+	Minutes int
+}
+
+// versionWatchMessageData is the context for the version-watch template.
+type versionWatchMessageData struct {
+	// User is the Slack user ID of the watcher.
+	User string
+	// Spec is the watched version spec, Version the new payload.
+	Spec, Version string
+}
+
+// reaperMessageData is the context for the reaper template.
+type reaperMessageData struct {
+	// Count is the number of reclaimed resources, Resources the bulleted list.
+	Count     int
+	Resources string
+}
+
+// defaultMessageTexts holds the stock text for every overridable
+// notification.
+var defaultMessageTexts = map[string]string{
+	"ready":         "Your cluster is ready, it will be shut down automatically in ~{{.ExpiresInMinutes}} minutes.",
+	"spoke-ready":   "Spoke cluster `{{.Name}}` in your group is ready; it shares the group's expiry and is torn down with it.",
+	"failed":        "{{.Subject}} failed to launch: {{.Failure}}{{if .URL}} (<{{.URL}}|logs>){{end}}",
+	"starting":      "cluster is still starting ({{.Progress}}{{if .URL}}, <{{.URL}}|logs>{{end}}){{.RestartNote}}",
+	"destroyed":     "cluster `{{.Name}}` finished deprovisioning; its cloud resources were freed after {{.Minutes}} minutes",
+	"job-succeeded": "job {{if .URL}}<{{.URL}} | {{.OriginalMessage}}> succeeded{{else}}{{.OriginalMessage}} succeeded, but no details could be retrieved{{end}}",
+	"job-failed":    "job {{if .URL}}<{{.URL}} | {{.OriginalMessage}}> failed{{else}}{{.OriginalMessage}} failed, but no details could be retrieved{{end}}",
+	"version-watch": "<@{{.User}}>: a new accepted payload matching `{{.Spec}}` is available: `{{.Version}}` - launch it with `launch {{.Version}}`",
+	"reaper":        "cleaned up {{.Count}} leaked cloud resource(s) from completed jobs:\n• {{.Resources}}",
+}
+
+// sampleMessageData pairs each template with populated data so overrides can
+// be executed once at load time, catching references to fields that do not
+// exist.
+var sampleMessageData = map[string]interface{}{
+	"ready":         launchMessageData{Name: "chat-bot-2", Subject: "your cluster", ExpiresInMinutes: 120},
+	"spoke-ready":   launchMessageData{Name: "chat-bot-2", Subject: "spoke cluster `chat-bot-2` in your group"},
+	"failed":        launchMessageData{Name: "chat-bot-2", Subject: "your cluster", Failure: "pod was deleted", URL: "https://example.com"},
+	"starting":      launchMessageData{Name: "chat-bot-2", Subject: "your cluster", Progress: "launched 5 minutes ago", URL: "https://example.com"},
+	"destroyed":     destroyedMessageData{Name: "chat-bot-2", Minutes: 12},
+	"job-succeeded": jobMessageData{OriginalMessage: "test e2e 4.16 aws", URL: "https://example.com"},
+	"job-failed":    jobMessageData{OriginalMessage: "test e2e 4.16 aws", URL: "https://example.com"},
+	"version-watch": versionWatchMessageData{User: "U123", Spec: "4.16 nightly", Version: "4.16.0-0.nightly"},
+	"reaper":        reaperMessageData{Count: 2, Resources: "a\n• b"},
+}
+
+// messageTemplates is the active set; it starts as the defaults and is
+// replaced wholesale by LoadMessageTemplates before the bot starts serving.
+var messageTemplates = mustParseMessageTemplates(defaultMessageTexts)
+
+func mustParseMessageTemplates(texts map[string]string) map[string]*template.Template {
+	templates := make(map[string]*template.Template, len(texts))
+	for name, text := range texts {
+		templates[name] = template.Must(template.New(name).Parse(text))
+	}
+	return templates
+}
+
+// LoadMessageTemplates overrides the default notification texts with the
+// entries in a YAML file of name -> Go template. Unknown names, parse errors,
+// and references to fields the notification does not provide are all
+// reported, so a broken override fails startup instead of a notification.
+func LoadMessageTemplates(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to read message templates: %w", err)
+	}
+	var overrides map[string]string
+	if err := yaml.Unmarshal(data, &overrides); err != nil {
+		return fmt.Errorf("unable to parse message templates: %w", err)
+	}
+	templates := mustParseMessageTemplates(defaultMessageTexts)
+	for name, text := range overrides {
+		sample, ok := sampleMessageData[name]
+		if !ok {
+			names := make([]string, 0, len(defaultMessageTexts))
+			for n := range defaultMessageTexts {
+				names = append(names, n)
+			}
+			sort.Strings(names)
+			return fmt.Errorf("unknown message template %q; valid names: %s", name, strings.Join(names, ", "))
+		}
+		parsed, err := template.New(name).Parse(text)
+		if err != nil {
+			return fmt.Errorf("message template %q does not parse: %w", name, err)
+		}
+		if err := parsed.Execute(&strings.Builder{}, sample); err != nil {
+			return fmt.Errorf("message template %q does not execute: %w", name, err)
+		}
+		templates[name] = parsed
+	}
+	messageTemplates = templates
+	return nil
+}
+
+// renderMessage executes the named template. Overrides are validated at load
+// time, so a failure here is a programming error; the default text is used as
+// a fallback so the user still gets notified.
+func renderMessage(name string, data interface{}) string {
+	sb := &strings.Builder{}
+	if err := messageTemplates[name].Execute(sb, data); err != nil {
+		klog.Errorf("message template %q failed to execute: %v", name, err)
+		sb.Reset()
+		if err := template.Must(template.New(name).Parse(defaultMessageTexts[name])).Execute(sb, data); err != nil {
+			return ""
+		}
+	}
+	return sb.String()
+}
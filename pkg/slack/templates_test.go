@@ -0,0 +1,65 @@
+package slack
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadMessageTemplates(t *testing.T) {
+	testCases := []struct {
+		name        string
+		yaml        string
+		errorString string
+		rendered    string
+	}{
+		{
+			name:     "OverrideDestroyed",
+			yaml:     "destroyed: \"`{{.Name}}` is gone after {{.Minutes}}m\"\n",
+			rendered: "`chat-bot-2` is gone after 12m",
+		},
+		{
+			name:        "UnknownName",
+			yaml:        "torn-down: \"gone\"\n",
+			errorString: "unknown message template",
+		},
+		{
+			name:        "ParseError",
+			yaml:        "destroyed: \"{{.Name\"\n",
+			errorString: "does not parse",
+		},
+		{
+			name:        "UnknownField",
+			yaml:        "destroyed: \"{{.Cluster}} is gone\"\n",
+			errorString: "does not execute",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "templates.yaml")
+			if err := os.WriteFile(path, []byte(testCase.yaml), 0600); err != nil {
+				t.Fatal(err)
+			}
+			err := LoadMessageTemplates(path)
+			defer func() {
+				messageTemplates = mustParseMessageTemplates(defaultMessageTexts)
+			}()
+			if len(testCase.errorString) > 0 {
+				if err == nil || !strings.Contains(err.Error(), testCase.errorString) {
+					t.Fatalf("expected error containing %q, got: %v", testCase.errorString, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if rendered := renderMessage("destroyed", destroyedMessageData{Name: "chat-bot-2", Minutes: 12}); rendered != testCase.rendered {
+				t.Errorf("expected %q, got %q", testCase.rendered, rendered)
+			}
+			if rendered := renderMessage("ready", launchMessageData{ExpiresInMinutes: 60}); !strings.Contains(rendered, "60") {
+				t.Errorf("default template should remain active for other messages, got %q", rendered)
+			}
+		})
+	}
+}
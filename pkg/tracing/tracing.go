@@ -0,0 +1,75 @@
+// Package tracing emits OpenTelemetry spans for the interesting stages of a
+// cluster launch - command received, inputs resolved, prow job created, job
+// completed - so operators can see where slow launches spend their time.
+// Tracing is off unless Init is called with an OTLP endpoint; all helpers are
+// no-ops while it is off, so instrumented code paths need no guards.
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/openshift/ci-chat-bot"
+
+var enabled bool
+
+// Init configures the global tracer provider to export spans to the given
+// OTLP gRPC endpoint and returns a shutdown function that flushes pending
+// spans. An empty endpoint leaves tracing disabled and the shutdown function
+// is a no-op.
+func Init(ctx context.Context, endpoint string) (func(context.Context) error, error) {
+	if len(endpoint) == 0 {
+		return func(context.Context) error { return nil }, nil
+	}
+	exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithEndpoint(endpoint), otlptracegrpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName("ci-chat-bot"))),
+	)
+	otel.SetTracerProvider(provider)
+	enabled = true
+	return provider.Shutdown, nil
+}
+
+// Enabled reports whether Init configured an exporter.
+func Enabled() bool {
+	return enabled
+}
+
+// StartSpan opens a span and returns the function that closes it; pass the
+// error (or nil) from the traced operation so failed stages stand out.
+func StartSpan(name string, attrs ...attribute.KeyValue) func(err error) {
+	if !enabled {
+		return func(error) {}
+	}
+	_, span := otel.Tracer(instrumentationName).Start(context.Background(), name, trace.WithAttributes(attrs...))
+	return func(err error) {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+// RecordSpan emits a span for an operation that already finished, such as a
+// prow job whose completion is only observed by the sync loop after the fact.
+func RecordSpan(name string, start, end time.Time, attrs ...attribute.KeyValue) {
+	if !enabled {
+		return
+	}
+	_, span := otel.Tracer(instrumentationName).Start(context.Background(), name, trace.WithTimestamp(start), trace.WithAttributes(attrs...))
+	span.End(trace.WithTimestamp(end))
+}
@@ -3,7 +3,10 @@ package utils
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	buildconfigclientset "github.com/openshift/client-go/build/clientset/versioned"
@@ -36,6 +39,45 @@ type BuildClusterClientConfig struct {
 
 type BuildClusterClientConfigMap map[string]*BuildClusterClientConfig
 
+// buildClusterClientLock guards BuildClusterClientConfigMap entries so build
+// cluster credentials can be swapped at runtime when they are rotated.
+var buildClusterClientLock sync.RWMutex
+
+// Get returns the clients for the named build cluster.
+func (m BuildClusterClientConfigMap) Get(name string) (*BuildClusterClientConfig, bool) {
+	buildClusterClientLock.RLock()
+	defer buildClusterClientLock.RUnlock()
+	clients, ok := m[name]
+	return clients, ok
+}
+
+// Names returns the build cluster names currently in the map.
+func (m BuildClusterClientConfigMap) Names() []string {
+	buildClusterClientLock.RLock()
+	defer buildClusterClientLock.RUnlock()
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Replace swaps the map's entries for freshly built clients, so credential
+// rotation does not require restarting the bot.
+func (m BuildClusterClientConfigMap) Replace(updated BuildClusterClientConfigMap) {
+	buildClusterClientLock.Lock()
+	defer buildClusterClientLock.Unlock()
+	for name := range m {
+		if _, ok := updated[name]; !ok {
+			delete(m, name)
+		}
+	}
+	for name, clients := range updated {
+		m[name] = clients
+	}
+}
+
 func StripLinks(input string) string {
 	var b strings.Builder
 	for {
@@ -86,6 +128,19 @@ func ParamsFromAnnotation(value string) (map[string]string, error) {
 	return values, nil
 }
 
+// CorrelationID returns the identifier that ties a Slack command's log lines,
+// ProwJob, and artifacts together. The Slack client message ID is used when
+// present; otherwise the ID is derived from the user and message timestamp so
+// every component computes the same value without coordination.
+func CorrelationID(clientMsgID, user, timestamp string) string {
+	if len(clientMsgID) > 0 {
+		return clientMsgID
+	}
+	h := fnv.New64a()
+	h.Write([]byte(user + "/" + timestamp))
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
 func Contains(arr []string, s string) bool {
 	for _, item := range arr {
 		if s == item {
@@ -95,6 +150,112 @@ func Contains(arr []string, s string) bool {
 	return false
 }
 
+// smartPunctuationReplacer maps the punctuation substituted by word processors
+// back to the plain ASCII characters the command parser expects
+var smartPunctuationReplacer = strings.NewReplacer(
+	"\u201c", `"`, // left double quotation mark
+	"\u201d", `"`, // right double quotation mark
+	"\u201e", `"`, // double low-9 quotation mark
+	"\u2018", "'", // left single quotation mark
+	"\u2019", "'", // right single quotation mark
+	"\u2013", "-", // en dash
+	"\u2014", "-", // em dash
+	"\u00a0", " ", // no-break space
+	"\u2007", " ", // figure space
+	"\u202f", " ", // narrow no-break space
+	"\u200b", "", // zero width space
+	"\u200c", "", // zero width non-joiner
+	"\u200d", "", // zero width joiner
+	"\ufeff", "", // zero width no-break space
+)
+
+// NormalizeSmartPunctuation rewrites smart quotes, dashes, and invisible
+// whitespace pasted from rich-text editors so commands copied from docs parse
+// the same as hand-typed ones.
+func NormalizeSmartPunctuation(text string) string {
+	return smartPunctuationReplacer.Replace(text)
+}
+
+// LocalTimeString renders t in the user's timezone for display alongside
+// relative durations; a nil location falls back to UTC.
+func LocalTimeString(t time.Time, loc *time.Location) string {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return t.In(loc).Format("Mon 15:04 MST")
+}
+
+// FlattenFencedCommand rewrites a message sent as a fenced code block with one
+// option per line into the equivalent single-line command, so long launches
+// are not mangled by Slack's line wrapping. The second return reports whether
+// the text was a fenced block.
+func FlattenFencedCommand(text string) (string, bool) {
+	trimmed := strings.TrimSpace(text)
+	if !strings.HasPrefix(trimmed, "```") || !strings.HasSuffix(trimmed, "```") || len(trimmed) < 6 {
+		return text, false
+	}
+	body := strings.TrimSuffix(strings.TrimPrefix(trimmed, "```"), "```")
+	var parts []string
+	for _, line := range strings.Split(body, "\n") {
+		if line = strings.TrimSpace(line); len(line) > 0 {
+			parts = append(parts, line)
+		}
+	}
+	return strings.Join(parts, " "), true
+}
+
+// LevenshteinDistance computes the edit distance between two strings, used to
+// suggest close alternatives for mistyped commands and options.
+func LevenshteinDistance(a, b string) int {
+	if a == b {
+		return 0
+	}
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := 0; j <= len(b); j++ {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = previous[j] + 1
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+			if previous[j-1]+cost < current[j] {
+				current[j] = previous[j-1] + cost
+			}
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// ClosestMatches returns the candidates within maxDistance edits of input,
+// closest first. Ties preserve the order of the candidate list.
+func ClosestMatches(input string, candidates []string, maxDistance int) []string {
+	type scored struct {
+		value    string
+		distance int
+	}
+	var matches []scored
+	for _, candidate := range candidates {
+		if distance := LevenshteinDistance(strings.ToLower(input), strings.ToLower(candidate)); distance <= maxDistance {
+			matches = append(matches, scored{value: candidate, distance: distance})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].distance < matches[j].distance })
+	values := make([]string, 0, len(matches))
+	for _, match := range matches {
+		values = append(values, match.value)
+	}
+	return values
+}
+
 // LoadKubeconfig loads connection configuration
 // for the cluster we're deploying to. We prefer to
 // use in-cluster configuration if possible, but will
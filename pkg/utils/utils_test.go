@@ -0,0 +1,161 @@
+package utils
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNormalizeSmartPunctuation(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "PlainText",
+			input:    `launch 4.18 aws "KEY=VALUE"`,
+			expected: `launch 4.18 aws "KEY=VALUE"`,
+		},
+		{
+			name:     "CurlyQuotes",
+			input:    "workflow-launch test 4.18 \u201cKEY1=VALUE1\u201d,\u2018single\u2019",
+			expected: `workflow-launch test 4.18 "KEY1=VALUE1",'single'`,
+		},
+		{
+			name:     "DashesAndSpaces",
+			input:    "launch 4.18 aws,single—node",
+			expected: "launch 4.18 aws,single-node",
+		},
+		{
+			name:     "ZeroWidthCharacters",
+			input:    "launch\u200b 4.18\ufeff",
+			expected: "launch 4.18",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := NormalizeSmartPunctuation(tc.input); got != tc.expected {
+				t.Errorf("got %q, expected %q", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestFlattenFencedCommand(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+		fenced   bool
+	}{
+		{
+			name:     "NotFenced",
+			input:    "launch 4.18 aws",
+			expected: "launch 4.18 aws",
+			fenced:   false,
+		},
+		{
+			name:     "OneOptionPerLine",
+			input:    "```\nlaunch\n4.18\naws,compact\n```",
+			expected: "launch 4.18 aws,compact",
+			fenced:   true,
+		},
+		{
+			name:     "BlankLinesAndIndentation",
+			input:    "```\nworkflow-launch test 4.18\n\n  \"KEY=VALUE\"\n```",
+			expected: `workflow-launch test 4.18 "KEY=VALUE"`,
+			fenced:   true,
+		},
+		{
+			name:     "SingleLineFence",
+			input:    "```launch 4.18 aws```",
+			expected: "launch 4.18 aws",
+			fenced:   true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, fenced := FlattenFencedCommand(tc.input)
+			if got != tc.expected || fenced != tc.fenced {
+				t.Errorf("got %q (fenced=%t), expected %q (fenced=%t)", got, fenced, tc.expected, tc.fenced)
+			}
+		})
+	}
+}
+
+func TestLevenshteinDistance(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		a        string
+		b        string
+		expected int
+	}{
+		{
+			name:     "Equal",
+			a:        "launch",
+			b:        "launch",
+			expected: 0,
+		},
+		{
+			name:     "Transposition",
+			a:        "lanuch",
+			b:        "launch",
+			expected: 2,
+		},
+		{
+			name:     "Insertion",
+			a:        "gc",
+			b:        "gcp",
+			expected: 1,
+		},
+		{
+			name:     "Empty",
+			a:        "",
+			b:        "aws",
+			expected: 3,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := LevenshteinDistance(tc.a, tc.b); got != tc.expected {
+				t.Errorf("got %d, expected %d", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestClosestMatches(t *testing.T) {
+	t.Parallel()
+	candidates := []string{"aws", "gcp", "azure", "vsphere"}
+	testCases := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "ExactMatchFirst",
+			input:    "gcp",
+			expected: []string{"gcp"},
+		},
+		{
+			name:     "CloseMatch",
+			input:    "asw",
+			expected: []string{"aws"},
+		},
+		{
+			name:     "NoMatch",
+			input:    "kubernetes",
+			expected: nil,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ClosestMatches(tc.input, candidates, 2); !slices.Equal(got, tc.expected) {
+				t.Errorf("got %v, expected %v", got, tc.expected)
+			}
+		})
+	}
+}